@@ -8,8 +8,9 @@ package context
 import (
 	"fmt"
 	"sync"
-	"sync/atomic"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/omec-project/openapi/v2/models"
 )
 
@@ -25,11 +26,12 @@ const (
 
 func init() {
 	UDR_Self().Name = "udr"
-	UDR_Self().EeSubscriptionIDGenerator = 1
-	UDR_Self().SubscriptionDataSubscriptionIDGenerator = 1
-	UDR_Self().PolicyDataSubscriptionIDGenerator = 1
 	UDR_Self().SubscriptionDataSubscriptions = make(map[subsId]*models.SubscriptionDataSubscriptions)
 	UDR_Self().PolicyDataSubscriptions = make(map[subsId]*models.PolicyDataSubscription)
+	UDR_Self().ExposureDataSubscriptionIDGenerator = 1
+	UDR_Self().ExposureDataSubscriptions = make(map[subsId]*models.ExposureDataSubscription)
+	UDR_Self().ApplicationDataSubscriptionIDGenerator = 1
+	UDR_Self().ApplicationDataSubscriptions = make(map[subsId]*models.ApplicationDataSubs)
 }
 
 type UDRContext struct {
@@ -38,45 +40,48 @@ type UDRContext struct {
 	BindingIPv4                             string
 	Key                                     string
 	PEM                                     string
+	CA                                      string
 	RegisterIPv4                            string // IP register to NRF
 	HttpIPv6Address                         string
 	NfId                                    string
 	NrfUri                                  string
 	SubscriptionDataSubscriptions           map[subsId]*models.SubscriptionDataSubscriptions
+	SubscriptionDataSubsMtx                 sync.RWMutex // guards SubscriptionDataSubscriptions
 	PolicyDataSubscriptions                 map[subsId]*models.PolicyDataSubscription
-	UESubsCollection                        sync.Map // map[ueId]*UESubsData
-	UEGroupCollection                       sync.Map // map[ueGroupId]*UEGroupSubsData
+	PolicyDataSubsMtx                       sync.RWMutex // guards PolicyDataSubscriptions
+	ExposureDataSubscriptions               map[subsId]*models.ExposureDataSubscription
+	ExposureDataSubsMtx                     sync.RWMutex // guards ExposureDataSubscriptions and its ID generator
+	ApplicationDataSubscriptions            map[subsId]*models.ApplicationDataSubs
+	ApplicationDataSubsMtx                  sync.RWMutex // guards ApplicationDataSubscriptions and its ID generator
 	mtx                                     sync.RWMutex
 	SBIPort                                 int
-	EeSubscriptionIDGenerator               int
-	SdmSubscriptionIDGenerator              atomic.Int64
-	PolicyDataSubscriptionIDGenerator       int
-	SubscriptionDataSubscriptionIDGenerator int
+	MaxSubscriptionExpiry                   time.Duration // 0 leaves the requested expiry untouched
+	SubscriptionReaperInterval              time.Duration
+	CacheMaxEntries                         int                      // 0 uses the producer default
+	CacheTTL                                time.Duration            // 0 uses the producer default
+	CacheSweepInterval                      time.Duration            // 0 uses the producer default
+	CacheNegativeTTL                        time.Duration            // 0 uses the producer default, negative disables negative caching
+	CacheCollections                        map[string]time.Duration // cached collections and their TTL; nil caches the defaults
+	AdminPort                               int                      // loopback port of the admin endpoints; 0 disables them
+	ExposureDataSubscriptionIDGenerator     int
+	ApplicationDataSubscriptionIDGenerator  int
 	appDataInfluDataSubscriptionIdGenerator uint64
 }
 
-// UESubsData holds the per-UE subscription maps.
-//
-// Mtx guards SdmSubscriptions. The UDM creates an SDM subscription per
-// registration, one goroutine per in-flight registration, so unsynchronised
-// access there aborts the process with "concurrent map writes".
-//
-// EeSubscriptionCollection is deliberately left alone. Its ~20 call sites read
-// and write it without taking Mtx, and CreateEeSubscriptionsProcedure still
-// installs the per-UE entry with Load followed by Store, so two hazards remain
-// on that path: concurrent map writes, and an entry replacement that discards
-// SDM subscriptions already recorded. Both predate this change and want the
-// same treatment applied here, but across every EE site rather than piecemeal --
-// guarding only some of them, or making concurrent EE creators share one
-// instance without guarding the map, converts a lost update into a crash.
+// UESubsData holds the SDM and EE subscriptions of a UE as read from the DB
+// for one request. They are not kept in process, so that no request works on
+// a copy that a write through another replica made stale.
 type UESubsData struct {
 	EeSubscriptionCollection map[subsId]*EeSubscriptionCollection
 	SdmSubscriptions         map[subsId]*models.SdmSubscription
-	Mtx                      sync.RWMutex
 }
 
+// UEGroupSubsData holds the EE subscriptions of a UE group. MemberUeIds
+// records, per subscription, the member UEs it was expanded to so that the
+// per-UE copies can be removed again even if the membership changed since.
 type UEGroupSubsData struct {
 	EeSubscriptions map[subsId]*models.EeSubscription
+	MemberUeIds     map[subsId][]string
 }
 
 type EeSubscriptionCollection struct {
@@ -102,6 +107,13 @@ func UDR_Self() *UDRContext {
 	return &udrContext
 }
 
+// NewSubscriptionID returns an ID for a subscription kept in the shared
+// store. IDs are random UUIDs so that replicas never hand out the same ID and
+// a restarted UDR never reuses one that is still held by a consumer.
+func (context *UDRContext) NewSubscriptionID() string {
+	return uuid.New().String()
+}
+
 func (context *UDRContext) NewAppDataInfluDataSubscriptionID() uint64 {
 	context.mtx.Lock()
 	defer context.mtx.Unlock()
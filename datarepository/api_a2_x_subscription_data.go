@@ -19,14 +19,31 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Get /subscription-data/:ueId/a2x-data
 // Retrieves the subscribed A2X Data of a UE
 func HTTPQueryA2xData(c *gin.Context) {
-	detail := "Handle Get /subscription-data/:ueId/a2x-data is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Get /subscription-data/:ueId/a2x-data")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+
+	rsp := handleRequest(c, req, producer.HandleQueryA2xData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
@@ -19,38 +19,127 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Put /exposure-data/:ueId/access-and-mobility-data
 // Creates and updates the access and mobility exposure data for a UE
 func HTTPCreateOrReplaceAccessAndMobilityData(c *gin.Context) {
-	detail := "Handle Put /exposure-data/:ueId/access-and-mobility-data is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Put /exposure-data/:ueId/access-and-mobility-data")
+	var accessAndMobilityData models.AccessAndMobilityData
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&accessAndMobilityData, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, accessAndMobilityData)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+
+	rsp := handleRequest(c, req, producer.HandleCreateOrReplaceAccessAndMobilityData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Delete /exposure-data/:ueId/access-and-mobility-data
 // Deletes the access and mobility exposure data for a UE
 func HTTPDeleteAccessAndMobilityData(c *gin.Context) {
-	detail := "Handle Delete /exposure-data/:ueId/access-and-mobility-data is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Delete /exposure-data/:ueId/access-and-mobility-data")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+
+	rsp := handleRequest(c, req, producer.HandleDeleteAccessAndMobilityData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Get /exposure-data/:ueId/access-and-mobility-data
 // Retrieves the access and mobility exposure data for a UE
 func HTTPQueryAccessAndMobilityData(c *gin.Context) {
-	detail := "Handle Get /exposure-data/:ueId/access-and-mobility-data is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Get /exposure-data/:ueId/access-and-mobility-data")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+
+	rsp := handleRequest(c, req, producer.HandleQueryAccessAndMobilityData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Patch /exposure-data/:ueId/access-and-mobility-data
 // Updates the access and mobility exposure data for a UE
 func HTTPUpdateAccessAndMobilityData(c *gin.Context) {
-	detail := "Handle Patch /exposure-data/:ueId/access-and-mobility-data is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Patch /exposure-data/:ueId/access-and-mobility-data")
+	var accessAndMobilityData models.AccessAndMobilityData
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&accessAndMobilityData, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, accessAndMobilityData)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+
+	rsp := handleRequest(c, req, producer.HandleUpdateAccessAndMobilityData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
@@ -36,7 +36,7 @@ func HTTPReadAccessAndMobilityPolicyData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandlePolicyDataUesUeIdAmDataGet(req)
+	rsp := handleRequest(c, req, producer.HandlePolicyDataUesUeIdAmDataGet)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
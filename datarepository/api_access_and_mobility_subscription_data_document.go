@@ -38,7 +38,7 @@ func HTTPQueryAmData(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["servingPlmnId"] = c.Params.ByName("servingPlmnId")
 
-	rsp := producer.HandleQueryAmData(req)
+	rsp := handleRequest(c, req, producer.HandleQueryAmData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
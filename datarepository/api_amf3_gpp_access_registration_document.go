@@ -57,7 +57,7 @@ func HTTPAmfContext3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, patchItemArray)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleAmfContext3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleAmfContext3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -95,7 +95,7 @@ func HTTPCreateAmfContext3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, amf3GppAccessRegistration)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleCreateAmfContext3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleCreateAmfContext3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -114,7 +114,7 @@ func HTTPQueryAmfContext3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQueryAmfContext3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleQueryAmfContext3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
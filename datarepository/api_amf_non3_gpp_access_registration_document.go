@@ -57,7 +57,7 @@ func HTTPAmfContextNon3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, patchItemArray)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleAmfContextNon3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleAmfContextNon3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -95,7 +95,7 @@ func HTTPCreateAmfContextNon3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, amfNon3GppAccessRegistration)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleCreateAmfContextNon3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleCreateAmfContextNon3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -114,7 +114,7 @@ func HTTPQueryAmfContextNon3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQueryAmfContextNon3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleQueryAmfContextNon3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -58,7 +58,7 @@ func HTTPCreateAMFSubscriptions(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleCreateAMFSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleCreateAMFSubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
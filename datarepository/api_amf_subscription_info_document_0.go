@@ -65,7 +65,7 @@ func HTTPModifyAmfSubscriptionInfo(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleModifyAmfSubscriptionInfo(req)
+	rsp := handleRequest(c, req, producer.HandleModifyAmfSubscriptionInfo)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
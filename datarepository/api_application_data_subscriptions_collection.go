@@ -19,22 +19,68 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Post /application-data/subs-to-notify
 // Create a subscription to receive notification of application data changes
 func HTTPCreateIndividualApplicationDataSubscription(c *gin.Context) {
-	detail := "Handle Post /application-data/subs-to-notify is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Post /application-data/subs-to-notify")
+	var applicationDataSubs models.ApplicationDataSubs
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&applicationDataSubs, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, applicationDataSubs)
+
+	rsp := handleRequest(c, req, producer.HandleCreateApplicationDataSubscription)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Get /application-data/subs-to-notify
 // Read Application Data change Subscriptions
 func HTTPReadApplicationDataChangeSubscriptions(c *gin.Context) {
-	detail := "Handle Get /application-data/subs-to-notify is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Get /application-data/subs-to-notify")
+	req := httpwrapper.NewRequest(c.Request, nil)
+
+	rsp := handleRequest(c, req, producer.HandleReadApplicationDataSubscriptions)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
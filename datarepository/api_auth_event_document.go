@@ -45,7 +45,7 @@ func HTTPQueryAuthenticationStatus(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQueryAuthenticationStatus(req)
+	rsp := handleRequest(c, req, producer.HandleQueryAuthenticationStatus)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -37,7 +37,7 @@ func HTTPQueryAuthSubsData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQueryAuthSubsData(req)
+	rsp := handleRequest(c, req, producer.HandleQueryAuthSubsData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
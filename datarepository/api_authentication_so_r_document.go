@@ -57,7 +57,7 @@ func HTTPCreateAuthenticationSoR(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, sorData)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleCreateAuthenticationSoR(req)
+	rsp := handleRequest(c, req, producer.HandleCreateAuthenticationSoR)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -76,7 +76,7 @@ func HTTPQueryAuthSoR(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQueryAuthSoR(req)
+	rsp := handleRequest(c, req, producer.HandleQueryAuthSoR)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -57,7 +57,7 @@ func HTTPCreateAuthenticationStatus(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, authEvent)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleCreateAuthenticationStatus(req)
+	rsp := handleRequest(c, req, producer.HandleCreateAuthenticationStatus)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
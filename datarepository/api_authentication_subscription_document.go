@@ -57,7 +57,7 @@ func HTTPModifyAuthenticationSubscription(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, patchItemArray)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleModifyAuthentication(req)
+	rsp := handleRequest(c, req, producer.HandleModifyAuthentication)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -19,14 +19,89 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Get /subscription-data/:ueId/coverage-restriction-data
 // Retrieves the subscribed enhanced Coverage Restriction Data of a UE
 func HTTPQueryCoverageRestrictionData(c *gin.Context) {
-	detail := "Handle Get /subscription-data/:ueId/coverage-restriction-data is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Get /subscription-data/:ueId/coverage-restriction-data")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+
+	rsp := handleRequest(c, req, producer.HandleQueryCoverageRestrictionData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
+}
+
+// Put /subscription-data/:ueId/coverage-restriction-data
+// Creates or replaces the enhanced Coverage Restriction Data of a UE
+func HTTPCreateOrReplaceCoverageRestrictionData(c *gin.Context) {
+	logger.DataRepoLog.Debugln("Handle Put /subscription-data/:ueId/coverage-restriction-data")
+	var enhancedCoverageRestrictionData models.EnhancedCoverageRestrictionData
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&enhancedCoverageRestrictionData, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, enhancedCoverageRestrictionData)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+
+	rsp := handleRequest(c, req, producer.HandleCreateOrReplaceCoverageRestrictionData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
+}
+
+// Delete /subscription-data/:ueId/coverage-restriction-data
+// Deletes the enhanced Coverage Restriction Data of a UE
+func HTTPDeleteCoverageRestrictionData(c *gin.Context) {
+	logger.DataRepoLog.Debugln("Handle Delete /subscription-data/:ueId/coverage-restriction-data")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+
+	rsp := handleRequest(c, req, producer.HandleDeleteCoverageRestrictionData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
@@ -46,7 +46,7 @@ func HTTPRemoveAmfSubscriptionsInfo(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleRemoveAmfSubscriptionsInfo(req)
+	rsp := handleRequest(c, req, producer.HandleRemoveAmfSubscriptionsInfo)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -37,7 +37,7 @@ func HTTPQueryEEData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQueryEEData(req)
+	rsp := handleRequest(c, req, producer.HandleQueryEEData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -55,7 +55,7 @@ func HTTPRemoveEeGroupSubscriptions(c *gin.Context) {
 	req.Params["ueGroupId"] = c.Params.ByName("ueGroupId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleRemoveEeGroupSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleRemoveEeGroupSubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -94,7 +94,7 @@ func HTTPUpdateEeGroupSubscriptions(c *gin.Context) {
 	req.Params["ueGroupId"] = c.Params.ByName("ueGroupId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleUpdateEeGroupSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleUpdateEeGroupSubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
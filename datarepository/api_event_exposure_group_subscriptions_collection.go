@@ -56,7 +56,7 @@ func HTTPCreateEeGroupSubscriptions(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, eeSubscription)
 	req.Params["ueGroupId"] = c.Params.ByName("ueGroupId")
 
-	rsp := producer.HandleCreateEeGroupSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleCreateEeGroupSubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -75,7 +75,7 @@ func HTTPQueryEeGroupSubscriptions(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueGroupId"] = c.Params.ByName("ueGroupId")
 
-	rsp := producer.HandleQueryEeGroupSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleQueryEeGroupSubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
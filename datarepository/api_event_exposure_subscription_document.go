@@ -55,7 +55,7 @@ func HTTPRemoveeeSubscriptions(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleRemoveeeSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleRemoveeeSubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -94,7 +94,7 @@ func HTTPUpdateEesubscriptions(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleUpdateEesubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleUpdateEesubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
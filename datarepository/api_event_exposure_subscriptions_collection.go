@@ -57,7 +57,7 @@ func HTTPCreateEeSubscriptions(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, eeSubscription)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleCreateEeSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleCreateEeSubscriptions)
 	for key, val := range rsp.Header {
 		c.Header(key, val[0])
 	}
@@ -79,7 +79,7 @@ func HTTPQueryeesubscriptions(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQueryeesubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleQueryeesubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
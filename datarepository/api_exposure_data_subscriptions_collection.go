@@ -19,14 +19,50 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Post /exposure-data/subs-to-notify
 // Create a subscription to receive notification of exposure data changes
 func HTTPCreateIndividualExposureDataSubscription(c *gin.Context) {
-	detail := "Handle Post /exposure-data/subs-to-notify is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Post /exposure-data/subs-to-notify")
+	var exposureDataSubscription models.ExposureDataSubscription
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&exposureDataSubscription, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, exposureDataSubscription)
+
+	rsp := handleRequest(c, req, producer.HandleCreateExposureDataSubscription)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
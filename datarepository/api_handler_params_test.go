@@ -8,28 +8,98 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
-	"sync"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/omec-project/openapi/v2/models"
 	udrContext "github.com/omec-project/udr/context"
+	"github.com/omec-project/udr/producer"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
-func resetUDRContextForHandlerTests() {
+// handlerTestDB accepts every write and finds only the documents put with
+// RestfulAPIPutOne or seeded by the test, which is all the subscription
+// handlers under test need from the DB.
+type handlerTestDB struct {
+	documents map[string][]map[string]interface{}
+}
+
+func handlerTestDocumentMatches(document map[string]interface{}, filter bson.M) bool {
+	for field, value := range filter {
+		if document[field] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (db *handlerTestDB) RestfulAPIGetOne(ctx context.Context, collName string, filter bson.M) (map[string]interface{}, error) {
+	documents, err := db.RestfulAPIGetMany(ctx, collName, filter)
+	if len(documents) == 0 {
+		return nil, err
+	}
+	return documents[0], err
+}
+
+func (db *handlerTestDB) RestfulAPIGetMany(_ context.Context, collName string, filter bson.M) ([]map[string]interface{}, error) {
+	var documents []map[string]interface{}
+	for _, document := range db.documents[collName] {
+		if handlerTestDocumentMatches(document, filter) {
+			documents = append(documents, document)
+		}
+	}
+	return documents, nil
+}
+
+func (*handlerTestDB) RestfulAPIPutOneTimeout(context.Context, string, bson.M, map[string]interface{}, int32, string) bool {
+	return true
+}
+
+func (db *handlerTestDB) RestfulAPIPutOne(_ context.Context, collName string, _ bson.M, document map[string]interface{}) (bool, error) {
+	db.documents[collName] = append(db.documents[collName], document)
+	return false, nil
+}
+
+func (*handlerTestDB) RestfulAPIPutOneNotUpdate(context.Context, string, bson.M, map[string]interface{}) (bool, error) {
+	return false, nil
+}
+func (*handlerTestDB) RestfulAPIPutMany(context.Context, string, []bson.M, []map[string]interface{}) error {
+	return nil
+}
+func (*handlerTestDB) RestfulAPIDeleteOne(context.Context, string, bson.M) error  { return nil }
+func (*handlerTestDB) RestfulAPIDeleteMany(context.Context, string, bson.M) error { return nil }
+func (*handlerTestDB) RestfulAPIMergePatch(context.Context, string, bson.M, map[string]interface{}) error {
+	return nil
+}
+func (*handlerTestDB) RestfulAPIJSONPatch(context.Context, string, bson.M, []byte) error { return nil }
+func (*handlerTestDB) RestfulAPIJSONPatchExtend(context.Context, string, bson.M, []byte, string) error {
+	return nil
+}
+func (*handlerTestDB) RestfulAPIPost(context.Context, string, bson.M, map[string]interface{}) (bool, error) {
+	return false, nil
+}
+func (*handlerTestDB) RestfulAPIPostMany(context.Context, string, bson.M, []interface{}) error {
+	return nil
+}
+
+func (*handlerTestDB) RestfulAPIBulkWrite(context.Context, string, []producer.BulkWriteOperation, bool) ([]producer.BulkWriteItemResult, error) {
+	return nil, nil
+}
+
+func resetUDRContextForHandlerTests() *handlerTestDB {
+	db := &handlerTestDB{documents: make(map[string][]map[string]interface{})}
+	producer.CommonDBClient = db
 	udrSelf := udrContext.UDR_Self()
 	udrSelf.SubscriptionDataSubscriptions = make(map[string]*models.SubscriptionDataSubscriptions)
-	udrSelf.EeSubscriptionIDGenerator = 1
-	udrSelf.SubscriptionDataSubscriptionIDGenerator = 1
-	udrSelf.UESubsCollection = sync.Map{}
 	udrSelf.UriScheme = models.URISCHEME_HTTP
 	udrSelf.RegisterIPv4 = "127.0.0.1"
 	udrSelf.SBIPort = 8000
+	return db
 }
 
 func TestHTTPCreateEeSubscriptions_UsesUeIdPathParam(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	resetUDRContextForHandlerTests()
+	db := resetUDRContextForHandlerTests()
 
 	recorder := httptest.NewRecorder()
 	request := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
@@ -46,24 +116,23 @@ func TestHTTPCreateEeSubscriptions_UsesUeIdPathParam(t *testing.T) {
 	if recorder.Code != http.StatusCreated {
 		t.Fatalf("expected %d, got %d with body %s", http.StatusCreated, recorder.Code, recorder.Body.String())
 	}
-	if _, ok := udrContext.UDR_Self().UESubsCollection.Load("imsi-001010000000001"); !ok {
-		t.Fatal("expected subscription to be stored under ueId path param")
+	documents := db.documents[producer.SUBSCDATA_CTXDATA_EE_SUBSCRIPTIONS]
+	if len(documents) != 1 {
+		t.Fatalf("expected 1 stored subscription, got %d", len(documents))
 	}
-	if _, ok := udrContext.UDR_Self().UESubsCollection.Load(""); ok {
-		t.Fatal("did not expect subscription to be stored under empty ueId")
+	if ueId := documents[0]["ueId"]; ueId != "imsi-001010000000001" {
+		t.Fatalf("expected subscription to be stored under ueId path param, got %q", ueId)
 	}
 }
 
 func TestHTTPQueryeesubscriptions_UsesUeIdPathParam(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	resetUDRContextForHandlerTests()
+	db := resetUDRContextForHandlerTests()
 
 	ueId := "imsi-001010000000001"
-	udrContext.UDR_Self().UESubsCollection.Store(ueId, &udrContext.UESubsData{
-		EeSubscriptionCollection: map[string]*udrContext.EeSubscriptionCollection{
-			"1": {EeSubscriptions: &models.EeSubscription{}},
-		},
-	})
+	db.documents[producer.SUBSCDATA_CTXDATA_EE_SUBSCRIPTIONS] = []map[string]interface{}{
+		{"ueId": ueId, "subsId": "1", "subscription": map[string]interface{}{}},
+	}
 
 	recorder := httptest.NewRecorder()
 	request := httptest.NewRequestWithContext(context.Background(), http.MethodGet,
@@ -83,9 +152,12 @@ func TestHTTPQueryeesubscriptions_UsesUeIdPathParam(t *testing.T) {
 
 func TestHTTPRemovesubscriptionDataSubscriptions_UsesSubsIdPathParam(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	resetUDRContextForHandlerTests()
+	db := resetUDRContextForHandlerTests()
 
 	subsId := "sub-1"
+	db.documents[producer.SUBSCDATA_SUBS_TO_NOTIFY] = []map[string]interface{}{
+		{"subsId": subsId, "subscription": map[string]interface{}{}},
+	}
 	udrContext.UDR_Self().SubscriptionDataSubscriptions[subsId] = &models.SubscriptionDataSubscriptions{}
 
 	recorder := httptest.NewRecorder()
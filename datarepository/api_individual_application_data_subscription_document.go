@@ -19,30 +19,89 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Delete /application-data/subs-to-notify/:subsId
 // Delete the individual Application Data subscription
 func HTTPDeleteIndividualApplicationDataSubscription(c *gin.Context) {
-	detail := "Handle Delete /application-data/subs-to-notify/:subsId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Delete /application-data/subs-to-notify/:subsId")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["subsId"] = c.Params.ByName("subsId")
+
+	rsp := handleRequest(c, req, producer.HandleDeleteApplicationDataSubscription)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Get /application-data/subs-to-notify/:subsId
 // Get an existing individual Application Data Subscription resource
 func HTTPReadIndividualApplicationDataSubscription(c *gin.Context) {
-	detail := "Handle Get /application-data/subs-to-notify/:subsId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Get /application-data/subs-to-notify/:subsId")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["subsId"] = c.Params.ByName("subsId")
+
+	rsp := handleRequest(c, req, producer.HandleReadApplicationDataSubscription)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Put /application-data/subs-to-notify/:subsId
 // Modify a subscription to receive notification of application data changes
 func HTTPReplaceIndividualApplicationDataSubscription(c *gin.Context) {
-	detail := "Handle Put /application-data/subs-to-notify/:subsId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Put /application-data/subs-to-notify/:subsId")
+	var applicationDataSubs models.ApplicationDataSubs
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&applicationDataSubs, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, applicationDataSubs)
+	req.Params["subsId"] = c.Params.ByName("subsId")
+
+	rsp := handleRequest(c, req, producer.HandleReplaceApplicationDataSubscription)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
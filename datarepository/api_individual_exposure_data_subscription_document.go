@@ -19,22 +19,70 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Delete /exposure-data/subs-to-notify/:subId
 // Deletes the individual Exposure Data subscription
 func HTTPDeleteIndividualExposureDataSubscription(c *gin.Context) {
-	detail := "Handle Delete /exposure-data/subs-to-notify/:subId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Delete /exposure-data/subs-to-notify/:subId")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["subId"] = c.Params.ByName("subId")
+
+	rsp := handleRequest(c, req, producer.HandleDeleteExposureDataSubscription)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Put /exposure-data/subs-to-notify/:subId
 // updates a subscription to receive notifications of exposure data changes
 func HTTPReplaceIndividualExposureDataSubscription(c *gin.Context) {
-	detail := "Handle Put /exposure-data/subs-to-notify/:subId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Put /exposure-data/subs-to-notify/:subId")
+	var exposureDataSubscription models.ExposureDataSubscription
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&exposureDataSubscription, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, exposureDataSubscription)
+	req.Params["subId"] = c.Params.ByName("subId")
+
+	rsp := handleRequest(c, req, producer.HandleReplaceExposureDataSubscription)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
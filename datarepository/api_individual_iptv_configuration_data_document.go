@@ -19,30 +19,108 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Put /application-data/iptvConfigData/:configurationId
 // Create or update an individual IPTV configuration resource
 func HTTPCreateOrReplaceIndividualIPTVConfigurationData(c *gin.Context) {
-	detail := "Handle Put /application-data/iptvConfigData/:configurationId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Put /application-data/iptvConfigData/:configurationId")
+	var iptvConfigData models.IptvConfigData
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&iptvConfigData, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, iptvConfigData)
+	req.Params["configurationId"] = c.Params.ByName("configurationId")
+
+	rsp := handleRequest(c, req, producer.HandleCreateOrReplaceIPTVConfigurationData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Delete /application-data/iptvConfigData/:configurationId
 // Delete an individual IPTV configuration resource
 func HTTPDeleteIndividualIPTVConfigurationData(c *gin.Context) {
-	detail := "Handle Delete /application-data/iptvConfigData/:configurationId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Delete /application-data/iptvConfigData/:configurationId")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["configurationId"] = c.Params.ByName("configurationId")
+
+	rsp := handleRequest(c, req, producer.HandleDeleteIPTVConfigurationData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Patch /application-data/iptvConfigData/:configurationId
 // Partial update an individual IPTV configuration resource
 func HTTPPartialReplaceIndividualIPTVConfigurationData(c *gin.Context) {
-	detail := "Handle Patch /application-data/iptvConfigData/:configurationId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Patch /application-data/iptvConfigData/:configurationId")
+	var iptvConfigDataPatch models.IptvConfigDataPatch
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&iptvConfigDataPatch, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, iptvConfigDataPatch)
+	req.Params["configurationId"] = c.Params.ByName("configurationId")
+
+	rsp := handleRequest(c, req, producer.HandleUpdateIPTVConfigurationData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
@@ -19,30 +19,108 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Put /application-data/serviceParamData/:serviceParamId
 // Create or update an individual Service Parameter Data resource
 func HTTPCreateOrReplaceServiceParameterData(c *gin.Context) {
-	detail := "Handle Put /application-data/serviceParamData/:serviceParamId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Put /application-data/serviceParamData/:serviceParamId")
+	var serviceParameterData models.ServiceParameterData
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&serviceParameterData, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, serviceParameterData)
+	req.Params["serviceParamId"] = c.Params.ByName("serviceParamId")
+
+	rsp := handleRequest(c, req, producer.HandleCreateOrReplaceServiceParameterData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Delete /application-data/serviceParamData/:serviceParamId
 // Delete an individual Service Parameter Data resource
 func HTTPDeleteIndividualServiceParameterData(c *gin.Context) {
-	detail := "Handle Delete /application-data/serviceParamData/:serviceParamId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Delete /application-data/serviceParamData/:serviceParamId")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["serviceParamId"] = c.Params.ByName("serviceParamId")
+
+	rsp := handleRequest(c, req, producer.HandleDeleteServiceParameterData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Patch /application-data/serviceParamData/:serviceParamId
 // Modify part of the properties of an individual Service Parameter Data resource
 func HTTPUpdateIndividualServiceParameterData(c *gin.Context) {
-	detail := "Handle Patch /application-data/serviceParamData/:serviceParamId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Patch /application-data/serviceParamData/:serviceParamId")
+	var serviceParameterDataPatch models.ServiceParameterDataPatch
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&serviceParameterDataPatch, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, serviceParameterDataPatch)
+	req.Params["serviceParamId"] = c.Params.ByName("serviceParamId")
+
+	rsp := handleRequest(c, req, producer.HandleUpdateServiceParameterData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
@@ -19,14 +19,32 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Get /subscription-data/:ueId/:servingPlmnId/provisioned-data/lcs-bca-data
 // Retrieves the LCS Broadcast Assistance subscription data of a UE
 func HTTPQueryLcsBcaData(c *gin.Context) {
-	detail := "Handle Get /subscription-data/:ueId/:servingPlmnId/provisioned-data/lcs-bca-data is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Get /subscription-data/:ueId/:servingPlmnId/provisioned-data/lcs-bca-data")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+	req.Params["servingPlmnId"] = c.Params.ByName("servingPlmnId")
+
+	rsp := handleRequest(c, req, producer.HandleQueryLcsBcaData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
@@ -73,7 +73,7 @@ func HTTPModifyOperSpecData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, patchItemArray)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandlePatchOperSpecData(req)
+	rsp := handleRequest(c, req, producer.HandlePatchOperSpecData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -92,7 +92,7 @@ func HTTPQueryOperSpecData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQueryOperSpecData(req)
+	rsp := handleRequest(c, req, producer.HandleQueryOperSpecData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
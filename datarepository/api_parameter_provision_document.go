@@ -37,7 +37,7 @@ func HTTPGetppData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleGetppData(req)
+	rsp := handleRequest(c, req, producer.HandleGetppData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
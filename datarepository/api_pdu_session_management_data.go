@@ -19,30 +19,92 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Put /exposure-data/:ueId/session-management-data/:pduSessionId
 // Creates and updates the session management data for a UE and for an individual PDU session
 func HTTPCreateOrReplaceSessionManagementData(c *gin.Context) {
-	detail := "Handle Put /exposure-data/:ueId/session-management-data/:pduSessionId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Put /exposure-data/:ueId/session-management-data/:pduSessionId")
+	var pduSessionManagementData models.PduSessionManagementData
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&pduSessionManagementData, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, pduSessionManagementData)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+	req.Params["pduSessionId"] = c.Params.ByName("pduSessionId")
+
+	rsp := handleRequest(c, req, producer.HandleCreateOrReplaceSessionManagementData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Delete /exposure-data/:ueId/session-management-data/:pduSessionId
 // Deletes the session management data for a UE and for an individual PDU session
 func HTTPDeleteSessionManagementData(c *gin.Context) {
-	detail := "Handle Delete /exposure-data/:ueId/session-management-data/:pduSessionId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Delete /exposure-data/:ueId/session-management-data/:pduSessionId")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+	req.Params["pduSessionId"] = c.Params.ByName("pduSessionId")
+
+	rsp := handleRequest(c, req, producer.HandleDeleteSessionManagementData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
 
 // Get /exposure-data/:ueId/session-management-data/:pduSessionId
 // Retrieves the session management data for a UE and for an individual PDU session
 func HTTPQuerySessionManagementData(c *gin.Context) {
-	detail := "Handle Get /exposure-data/:ueId/session-management-data/:pduSessionId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Get /exposure-data/:ueId/session-management-data/:pduSessionId")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["ueId"] = c.Params.ByName("ueId")
+	req.Params["pduSessionId"] = c.Params.ByName("pduSessionId")
+
+	rsp := handleRequest(c, req, producer.HandleQuerySessionManagementData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
@@ -38,7 +38,7 @@ func HTTPQueryProvisionedData(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["servingPlmnId"] = c.Params.ByName("servingPlmnId")
 
-	rsp := producer.HandleQueryProvisionedData(req)
+	rsp := handleRequest(c, req, producer.HandleQueryProvisionedData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -57,7 +57,7 @@ func HTTPModifyPpData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, patchItemArray)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleModifyPpData(req)
+	rsp := handleRequest(c, req, producer.HandleModifyPpData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -46,7 +46,7 @@ func HTTPGetAmfSubscriptionInfo(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleGetAmfSubscriptionInfo(req)
+	rsp := handleRequest(c, req, producer.HandleGetAmfSubscriptionInfo)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
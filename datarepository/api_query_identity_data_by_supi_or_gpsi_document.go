@@ -37,7 +37,7 @@ func HTTPGetIdentityData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleGetIdentityData(req)
+	rsp := handleRequest(c, req, producer.HandleGetIdentityData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -37,7 +37,7 @@ func HTTPGetOdbData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleGetOdbData(req)
+	rsp := handleRequest(c, req, producer.HandleGetOdbData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
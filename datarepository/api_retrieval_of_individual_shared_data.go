@@ -19,14 +19,146 @@ Generated by: OpenAPI Generator (https://openapi-generator.tech)
 package datarepository
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/openapi/v2/utils"
 	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/util/httpwrapper"
 )
 
 // Get /subscription-data/shared-data/:sharedDataId
 // retrieve individual shared data
 func HTTPGetIndividualSharedData(c *gin.Context) {
-	detail := "Handle Get /subscription-data/shared-data/:sharedDataId is not implemented"
-	logger.DataRepoLog.Warnln(detail)
-	writeNotImplementedProblem(c, detail)
+	logger.DataRepoLog.Debugln("Handle Get /subscription-data/shared-data/:sharedDataId")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["sharedDataId"] = c.Params.ByName("sharedDataId")
+
+	rsp := handleRequest(c, req, producer.HandleGetIndividualSharedData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
+}
+
+// Put /subscription-data/shared-data/:sharedDataId
+// create or replace individual shared data
+func HTTPCreateOrReplaceSharedData(c *gin.Context) {
+	logger.DataRepoLog.Debugln("Handle Put /subscription-data/shared-data/:sharedDataId")
+	var sharedData models.SharedData
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&sharedData, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, sharedData)
+	req.Params["sharedDataId"] = c.Params.ByName("sharedDataId")
+
+	rsp := handleRequest(c, req, producer.HandleCreateOrReplaceSharedData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
+}
+
+// Patch /subscription-data/shared-data/:sharedDataId
+// modify individual shared data
+func HTTPUpdateSharedData(c *gin.Context) {
+	logger.DataRepoLog.Debugln("Handle Patch /subscription-data/shared-data/:sharedDataId")
+	var patchItemArray []models.PatchItem
+
+	requestBody, err := c.GetRawData()
+	if err != nil {
+		problemDetail := utils.ProblemDetailsSystemFailure(err.Error())
+		logger.DataRepoLog.Errorf("Get Request Body error: %+v", err)
+		c.JSON(http.StatusInternalServerError, problemDetail)
+		return
+	}
+
+	err = openapi.Decode(&patchItemArray, requestBody, contentTypeJSON)
+	if err != nil {
+		problemDetail := "[Request Body] " + err.Error()
+		rsp := utils.ProblemDetailsMalformedRequestSyntax(problemDetail)
+		logger.DataRepoLog.Errorln(problemDetail)
+		c.JSON(http.StatusBadRequest, rsp)
+		return
+	}
+
+	req := httpwrapper.NewRequest(c.Request, patchItemArray)
+	req.Params["sharedDataId"] = c.Params.ByName("sharedDataId")
+
+	rsp := handleRequest(c, req, producer.HandleUpdateSharedData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
+}
+
+// Delete /subscription-data/shared-data/:sharedDataId
+// delete individual shared data
+func HTTPDeleteSharedData(c *gin.Context) {
+	logger.DataRepoLog.Debugln("Handle Delete /subscription-data/shared-data/:sharedDataId")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["sharedDataId"] = c.Params.ByName("sharedDataId")
+
+	rsp := handleRequest(c, req, producer.HandleDeleteSharedData)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
+}
+
+// Get /subscription-data/shared-data/:sharedDataId/ue-ids
+// retrieve the UEs whose subscription data references the shared data
+func HTTPQuerySharedDataReferences(c *gin.Context) {
+	logger.DataRepoLog.Debugln("Handle Get /subscription-data/shared-data/:sharedDataId/ue-ids")
+	req := httpwrapper.NewRequest(c.Request, nil)
+	req.Params["sharedDataId"] = c.Params.ByName("sharedDataId")
+
+	rsp := handleRequest(c, req, producer.HandleQuerySharedDataReferences)
+
+	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		problemDetails := utils.ProblemDetailsSystemFailure(err.Error())
+		c.JSON(http.StatusInternalServerError, problemDetails)
+	} else {
+		c.Data(rsp.Status, contentTypeJSON, responseBody.Bytes())
+	}
 }
@@ -38,7 +38,7 @@ func HTTPGetSharedData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Query["sharedDataIds"] = sharedDataIdArray
 
-	rsp := producer.HandleGetSharedData(req)
+	rsp := handleRequest(c, req, producer.HandleGetSharedData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -55,7 +55,7 @@ func HTTPRemovesdmSubscriptions(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleRemovesdmSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleRemovesdmSubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -94,7 +94,7 @@ func HTTPUpdatesdmsubscriptions(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleUpdatesdmsubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleUpdatesdmsubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
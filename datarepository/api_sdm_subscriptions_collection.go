@@ -57,7 +57,7 @@ func HTTPCreateSdmSubscriptions(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, sdmSubscription)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleCreateSdmSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleCreateSdmSubscriptions)
 
 	for key, val := range rsp.Header {
 		c.Header(key, val[0])
@@ -79,7 +79,7 @@ func HTTPQuerysdmsubscriptions(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQuerysdmsubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleQuerysdmsubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -36,7 +36,7 @@ func HTTPReadSessionManagementPolicyData(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandlePolicyDataUesUeIdSmDataGet(req)
+	rsp := handleRequest(c, req, producer.HandlePolicyDataUesUeIdSmDataGet)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -38,7 +38,7 @@ func HTTPQuerySmData(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["servingPlmnId"] = c.Params.ByName("servingPlmnId")
 
-	rsp := producer.HandleQuerySmData(req)
+	rsp := handleRequest(c, req, producer.HandleQuerySmData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
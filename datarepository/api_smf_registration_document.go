@@ -57,7 +57,7 @@ func HTTPCreateOrUpdateSmfRegistration(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, smfRegistration)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleCreateSmfContextNon3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleCreateSmfContextNon3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -76,7 +76,7 @@ func HTTPDeleteSmfRegistration(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["pduSessionId"] = c.Params.ByName("pduSessionId")
 
-	rsp := producer.HandleDeleteSmfContext(req)
+	rsp := handleRequest(c, req, producer.HandleDeleteSmfContext)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -96,7 +96,7 @@ func HTTPQuerySmfRegistration(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["pduSessionId"] = c.Params.ByName("pduSessionId")
 
-	rsp := producer.HandleQuerySmfRegistration(req)
+	rsp := handleRequest(c, req, producer.HandleQuerySmfRegistration)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -37,7 +37,7 @@ func HTTPQuerySmfRegList(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQuerySmfRegList(req)
+	rsp := handleRequest(c, req, producer.HandleQuerySmfRegList)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -38,7 +38,7 @@ func HTTPQuerySmfSelectData(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["servingPlmnId"] = c.Params.ByName("servingPlmnId")
 
-	rsp := producer.HandleQuerySmfSelectData(req)
+	rsp := handleRequest(c, req, producer.HandleQuerySmfSelectData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
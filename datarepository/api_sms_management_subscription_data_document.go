@@ -38,7 +38,7 @@ func HTTPQuerySmsMngData(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["servingPlmnId"] = c.Params.ByName("servingPlmnId")
 
-	rsp := producer.HandleQuerySmsMngData(req)
+	rsp := handleRequest(c, req, producer.HandleQuerySmsMngData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -38,7 +38,7 @@ func HTTPQuerySmsData(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["servingPlmnId"] = c.Params.ByName("servingPlmnId")
 
-	rsp := producer.HandleQuerySmsData(req)
+	rsp := handleRequest(c, req, producer.HandleQuerySmsData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -57,7 +57,7 @@ func HTTPCreateSmsfContext3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, smsfRegistration)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleCreateSmsfContext3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleCreateSmsfContext3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -76,7 +76,7 @@ func HTTPDeleteSmsfContext3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleDeleteSmsfContext3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleDeleteSmsfContext3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -95,7 +95,7 @@ func HTTPQuerySmsfContext3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQuerySmsfContext3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleQuerySmsfContext3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
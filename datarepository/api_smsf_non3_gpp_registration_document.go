@@ -57,7 +57,7 @@ func HTTPCreateSmsfContextNon3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, smsfRegistration)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleCreateSmsfContextNon3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleCreateSmsfContextNon3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -76,7 +76,7 @@ func HTTPDeleteSmsfContextNon3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleDeleteSmsfContextNon3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleDeleteSmsfContextNon3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
@@ -95,7 +95,7 @@ func HTTPQuerySmsfContextNon3gpp(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["ueId"] = c.Params.ByName("ueId")
 
-	rsp := producer.HandleQuerySmsfContextNon3gpp(req)
+	rsp := handleRequest(c, req, producer.HandleQuerySmsfContextNon3gpp)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
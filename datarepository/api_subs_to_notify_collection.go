@@ -72,7 +72,7 @@ func HTTPSubscriptionDataSubscriptions(c *gin.Context) {
 
 	req := httpwrapper.NewRequest(c.Request, subscriptionDataSubscriptions)
 
-	rsp := producer.HandlePostSubscriptionDataSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandlePostSubscriptionDataSubscriptions)
 	for key, val := range rsp.Header {
 		c.Header(key, val[0])
 	}
@@ -53,7 +53,7 @@ func HTTPRemovesubscriptionDataSubscriptions(c *gin.Context) {
 	req := httpwrapper.NewRequest(c.Request, nil)
 	req.Params["subsId"] = c.Params.ByName("subsId")
 
-	rsp := producer.HandleRemovesubscriptionDataSubscriptions(req)
+	rsp := handleRequest(c, req, producer.HandleRemovesubscriptionDataSubscriptions)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
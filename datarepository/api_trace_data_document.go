@@ -38,7 +38,7 @@ func HTTPQueryTraceData(c *gin.Context) {
 	req.Params["ueId"] = c.Params.ByName("ueId")
 	req.Params["servingPlmnId"] = c.Params.ByName("servingPlmnId")
 
-	rsp := producer.HandleQueryTraceData(req)
+	rsp := handleRequest(c, req, producer.HandleQueryTraceData)
 
 	responseBody, err := openapi.SetBody(rsp.Body, contentTypeJSON)
 	if err != nil {
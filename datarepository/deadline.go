@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package datarepository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/utils"
+	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/util/httpwrapper"
+)
+
+const (
+	// headerMaxRspTime carries the time, in milliseconds, within which the
+	// consumer expects the response (TS 29.500).
+	headerMaxRspTime = "3gpp-Sbi-Max-Rsp-Time"
+	// causeTimedOutRequest is the TS 29.500 cause of a 504 answered because
+	// the request could not be served in time.
+	causeTimedOutRequest = "TIMED_OUT_REQUEST"
+)
+
+type producerHandler func(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response
+
+// handleRequest runs handler with the context of the request, which ends
+// when the consumer goes away or after the consumer's 3gpp-Sbi-Max-Rsp-Time.
+// A handler that failed once that deadline passed is answered with 504: the
+// DB calls it made were cut short, and the producer reports those as a
+// system failure or as missing data. A handler that succeeded is answered as
+// usual, however late, as its writes have been made.
+func handleRequest(c *gin.Context, req *httpwrapper.Request, handler producerHandler) *httpwrapper.Response {
+	ctx, cancel := requestContext(c.Request)
+	defer cancel()
+
+	rsp := handler(ctx, req)
+	if rsp.Status >= http.StatusBadRequest && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		logger.DataRepoLog.Warnf("%s %s timed out", c.Request.Method, c.Request.URL.Path)
+		problemDetails := utils.ProblemDetailsWithCause("Request timed out", http.StatusGatewayTimeout, "",
+			causeTimedOutRequest)
+		return httpwrapper.NewResponse(http.StatusGatewayTimeout, nil, problemDetails)
+	}
+	return rsp
+}
+
+// requestContext returns the context of request, bounded by its
+// 3gpp-Sbi-Max-Rsp-Time when it carries a valid one.
+func requestContext(request *http.Request) (context.Context, context.CancelFunc) {
+	maxRspTime, err := strconv.Atoi(strings.TrimSpace(request.Header.Get(headerMaxRspTime)))
+	if err != nil || maxRspTime <= 0 {
+		return context.WithCancel(request.Context())
+	}
+	return context.WithTimeout(request.Context(), time.Duration(maxRspTime)*time.Millisecond)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package datarepository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/util/httpwrapper"
+)
+
+func newDeadlineTestContext(maxRspTime string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+		"/subscription-data/imsi-001010000000001/authentication-data/authentication-subscription", nil)
+	if maxRspTime != "" {
+		c.Request.Header.Set(headerMaxRspTime, maxRspTime)
+	}
+	return c
+}
+
+// waitForDeadline stands in for a handler stuck on a slow database.
+func waitForDeadline(ctx context.Context, _ *httpwrapper.Request) *httpwrapper.Response {
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+	}
+	return httpwrapper.NewResponse(http.StatusNotFound, nil, nil)
+}
+
+func TestHandleRequestAnswers504AfterMaxRspTime(t *testing.T) {
+	c := newDeadlineTestContext("20")
+
+	start := time.Now()
+	rsp := handleRequest(c, httpwrapper.NewRequest(c.Request, nil), waitForDeadline)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("handler ran for %v despite a 20 ms 3gpp-Sbi-Max-Rsp-Time", elapsed)
+	}
+	if rsp.Status != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d", rsp.Status)
+	}
+	problemDetails, ok := rsp.Body.(*models.ProblemDetails)
+	if !ok || problemDetails.GetCause() != causeTimedOutRequest {
+		t.Errorf("expected a %s problem, got %#v", causeTimedOutRequest, rsp.Body)
+	}
+}
+
+func TestHandleRequestKeepsResponseWithinDeadline(t *testing.T) {
+	for _, maxRspTime := range []string{"", "5000", "not-a-number"} {
+		c := newDeadlineTestContext(maxRspTime)
+		rsp := handleRequest(c, httpwrapper.NewRequest(c.Request, nil),
+			func(ctx context.Context, _ *httpwrapper.Request) *httpwrapper.Response {
+				if _, hasDeadline := ctx.Deadline(); hasDeadline != (maxRspTime == "5000") {
+					t.Errorf("3gpp-Sbi-Max-Rsp-Time %q: unexpected deadline %v", maxRspTime, hasDeadline)
+				}
+				return httpwrapper.NewResponse(http.StatusOK, nil, nil)
+			})
+		if rsp.Status != http.StatusOK {
+			t.Errorf("3gpp-Sbi-Max-Rsp-Time %q: expected 200, got %d", maxRspTime, rsp.Status)
+		}
+	}
+}
+
+func TestHandleRequestKeepsSuccessAfterMaxRspTime(t *testing.T) {
+	c := newDeadlineTestContext("20")
+
+	// A write that committed just as the deadline passed.
+	rsp := handleRequest(c, httpwrapper.NewRequest(c.Request, nil),
+		func(ctx context.Context, _ *httpwrapper.Request) *httpwrapper.Response {
+			<-ctx.Done()
+			return httpwrapper.NewResponse(http.StatusNoContent, nil, nil)
+		})
+	if rsp.Status != http.StatusNoContent {
+		t.Errorf("expected the handler's 204, got %d", rsp.Status)
+	}
+}
@@ -388,6 +388,18 @@ func getRoutes() []Route {
 			"/subscription-data/:ueId/coverage-restriction-data",
 			HTTPQueryCoverageRestrictionData,
 		},
+		{
+			"CreateOrReplaceCoverageRestrictionData",
+			http.MethodPut,
+			"/subscription-data/:ueId/coverage-restriction-data",
+			HTTPCreateOrReplaceCoverageRestrictionData,
+		},
+		{
+			"DeleteCoverageRestrictionData",
+			http.MethodDelete,
+			"/subscription-data/:ueId/coverage-restriction-data",
+			HTTPDeleteCoverageRestrictionData,
+		},
 		{
 			"RemoveAmfGroupSubscriptions",
 			http.MethodDelete,
@@ -1126,6 +1138,30 @@ func getRoutes() []Route {
 			"/subscription-data/shared-data/:sharedDataId",
 			HTTPGetIndividualSharedData,
 		},
+		{
+			"CreateOrReplaceSharedData",
+			http.MethodPut,
+			"/subscription-data/shared-data/:sharedDataId",
+			HTTPCreateOrReplaceSharedData,
+		},
+		{
+			"UpdateSharedData",
+			http.MethodPatch,
+			"/subscription-data/shared-data/:sharedDataId",
+			HTTPUpdateSharedData,
+		},
+		{
+			"DeleteSharedData",
+			http.MethodDelete,
+			"/subscription-data/shared-data/:sharedDataId",
+			HTTPDeleteSharedData,
+		},
+		{
+			"QuerySharedDataReferences",
+			http.MethodGet,
+			"/subscription-data/shared-data/:sharedDataId/ue-ids",
+			HTTPQuerySharedDataReferences,
+		},
 		{
 			"GetSharedData",
 			http.MethodGet,
@@ -1414,6 +1450,18 @@ func getRoutes() []Route {
 			"/subscription-data/:ueId/time-sync-data",
 			HTTPQueryTimeSyncSubscriptionData,
 		},
+		{
+			"CreateOrReplaceTimeSyncSubscriptionData",
+			http.MethodPut,
+			"/subscription-data/:ueId/time-sync-data",
+			HTTPCreateOrReplaceTimeSyncSubscriptionData,
+		},
+		{
+			"DeleteTimeSyncSubscriptionData",
+			http.MethodDelete,
+			"/subscription-data/:ueId/time-sync-data",
+			HTTPDeleteTimeSyncSubscriptionData,
+		},
 		{
 			"QueryTraceData",
 			http.MethodGet,
@@ -1480,6 +1528,18 @@ func getRoutes() []Route {
 			"/subscription-data/:ueId/uc-data",
 			HTTPQueryUserConsentData,
 		},
+		{
+			"CreateOrReplaceUserConsentData",
+			http.MethodPut,
+			"/subscription-data/:ueId/uc-data",
+			HTTPCreateOrReplaceUserConsentData,
+		},
+		{
+			"DeleteUserConsentData",
+			http.MethodDelete,
+			"/subscription-data/:ueId/uc-data",
+			HTTPDeleteUserConsentData,
+		},
 		{
 			"QueryV2xData",
 			http.MethodGet,
@@ -36,11 +36,18 @@ const (
 	UDR_DEFAULT_PORT_INT = 8000
 )
 
+const (
+	UDR_DEFAULT_SUBSCRIPTION_REAPER_INTERVAL = 60 // seconds
+)
+
 type Configuration struct {
-	Sbi      *Sbi     `yaml:"sbi"`
-	Mongodb  *Mongodb `yaml:"mongodb"`
-	NrfUri   string   `yaml:"nrfUri"`
-	WebuiUri string   `yaml:"webuiUri"`
+	Sbi           *Sbi           `yaml:"sbi"`
+	Mongodb       *Mongodb       `yaml:"mongodb"`
+	Subscriptions *Subscriptions `yaml:"subscriptions,omitempty"`
+	Cache         *Cache         `yaml:"cache,omitempty"`
+	Admin         *Admin         `yaml:"admin,omitempty"`
+	NrfUri        string         `yaml:"nrfUri"`
+	WebuiUri      string         `yaml:"webuiUri"`
 }
 
 type Sbi struct {
@@ -55,6 +62,7 @@ type Tls struct {
 	Log string `yaml:"log"`
 	Pem string `yaml:"pem"`
 	Key string `yaml:"key"`
+	Ca  string `yaml:"ca,omitempty"` // CA bundle used to verify NFs receiving notifications.
 }
 
 type Mongodb struct {
@@ -64,6 +72,29 @@ type Mongodb struct {
 	AuthUrl        string `yaml:"authUrl"`
 }
 
+type Subscriptions struct {
+	MaxExpiry      int `yaml:"maxExpiry,omitempty"`      // Longest lifetime granted to a new subscription, in seconds. 0 means unlimited.
+	ReaperInterval int `yaml:"reaperInterval,omitempty"` // How often expired subscriptions are removed, in seconds.
+}
+
+// Cache configures the caches in front of the common and auth databases.
+// Collections maps each cached collection to its TTL in seconds, 0 meaning
+// the default TTL. When Collections is absent the provisioned and authentication
+// subscription data is cached; an empty map disables caching.
+type Cache struct {
+	MaxEntries    int            `yaml:"maxEntries,omitempty"`    // Entries kept per database before the least recently used is evicted.
+	TTL           int            `yaml:"ttl,omitempty"`           // Default lifetime of an entry, in seconds.
+	SweepInterval int            `yaml:"sweepInterval,omitempty"` // How often expired entries are dropped, in seconds.
+	NegativeTTL   int            `yaml:"negativeTTL,omitempty"`   // How long a missing document is remembered, in seconds. Negative disables it.
+	Collections   map[string]int `yaml:"collections,omitempty"`
+}
+
+// Admin enables the operator endpoints, such as the cache inspector. They
+// are not authenticated, so they are only served on the loopback interface.
+type Admin struct {
+	Port int `yaml:"port"`
+}
+
 func (c *Config) GetVersion() string {
 	if c.Info != nil && c.Info.Version != "" {
 		return c.Info.Version
@@ -20,6 +20,12 @@ type UdrStats struct {
 	udrSubscriptionData *prometheus.CounterVec
 	udrApplicationData  *prometheus.CounterVec
 	udrPolicyData       *prometheus.CounterVec
+	udrExposureData     *prometheus.CounterVec
+	udrCacheHits        *prometheus.CounterVec
+	udrCacheMisses      *prometheus.CounterVec
+	udrCacheInvalidated *prometheus.CounterVec
+	udrCacheEvictions   *prometheus.CounterVec
+	udrCacheEntries     *prometheus.GaugeVec
 }
 
 var udrStats *UdrStats
@@ -38,6 +44,30 @@ func initUdrStats() *UdrStats {
 			Name: "udr_policy_data",
 			Help: "Counter of total Policy data queries",
 		}, []string{"query_type", "resource_type", "result"}),
+		udrExposureData: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "udr_exposure_data",
+			Help: "Counter of total Exposure data queries",
+		}, []string{"query_type", "resource_type", "result"}),
+		udrCacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "udr_cache_hits",
+			Help: "Counter of DB reads served from the cache",
+		}, []string{"collection"}),
+		udrCacheMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "udr_cache_misses",
+			Help: "Counter of cacheable DB reads that went to the database",
+		}, []string{"collection"}),
+		udrCacheInvalidated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "udr_cache_invalidations",
+			Help: "Counter of cache entries dropped because the data changed or was flushed",
+		}, []string{"collection"}),
+		udrCacheEvictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "udr_cache_evictions",
+			Help: "Counter of cache entries dropped to bound the cache (lru) or once expired (expired)",
+		}, []string{"collection", "reason"}),
+		udrCacheEntries: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "udr_cache_entries",
+			Help: "Number of entries in the cache",
+		}, []string{"collection"}),
 	}
 }
 
@@ -51,6 +81,24 @@ func (ps *UdrStats) register() error {
 	if err := prometheus.Register(ps.udrPolicyData); err != nil {
 		return err
 	}
+	if err := prometheus.Register(ps.udrExposureData); err != nil {
+		return err
+	}
+	if err := prometheus.Register(ps.udrCacheHits); err != nil {
+		return err
+	}
+	if err := prometheus.Register(ps.udrCacheMisses); err != nil {
+		return err
+	}
+	if err := prometheus.Register(ps.udrCacheInvalidated); err != nil {
+		return err
+	}
+	if err := prometheus.Register(ps.udrCacheEvictions); err != nil {
+		return err
+	}
+	if err := prometheus.Register(ps.udrCacheEntries); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -84,3 +132,33 @@ func IncrementUdrApplicationDataStats(queryType, resourceType, result string) {
 func IncrementUdrPolicyDataStats(queryType, resourceType, result string) {
 	udrStats.udrPolicyData.WithLabelValues(queryType, resourceType, result).Inc()
 }
+
+// IncrementUdrExposureDataStats increments number of total Exposure data queries
+func IncrementUdrExposureDataStats(queryType, resourceType, result string) {
+	udrStats.udrExposureData.WithLabelValues(queryType, resourceType, result).Inc()
+}
+
+// IncrementUdrCacheHits increments number of DB reads served from the cache
+func IncrementUdrCacheHits(collection string) {
+	udrStats.udrCacheHits.WithLabelValues(collection).Inc()
+}
+
+// IncrementUdrCacheMisses increments number of cacheable DB reads that went to the database
+func IncrementUdrCacheMisses(collection string) {
+	udrStats.udrCacheMisses.WithLabelValues(collection).Inc()
+}
+
+// IncrementUdrCacheInvalidations increments number of cache entries dropped because the data changed
+func IncrementUdrCacheInvalidations(collection string) {
+	udrStats.udrCacheInvalidated.WithLabelValues(collection).Inc()
+}
+
+// IncrementUdrCacheEvictions increments number of cache entries evicted for reason
+func IncrementUdrCacheEvictions(collection, reason string) {
+	udrStats.udrCacheEvictions.WithLabelValues(collection, reason).Inc()
+}
+
+// AddUdrCacheEntries adjusts the number of entries in the cache by delta
+func AddUdrCacheEntries(collection string, delta int) {
+	udrStats.udrCacheEntries.WithLabelValues(collection).Add(float64(delta))
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/omec-project/udr/logger"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	// BulkWriteAdminPath is where BulkWriteAdminHandler is served, on the
+	// loopback-only admin listener like CacheAdminPath.
+	BulkWriteAdminPath = "/admin/bulk-write"
+	// bulkWriteMaxBytes bounds a bulk write request, which is enough for
+	// tens of thousands of subscribers.
+	bulkWriteMaxBytes    = 64 << 20
+	authSubscriptionColl = "subscriptionData.authenticationData.authenticationSubscription"
+)
+
+type bulkWriteRequest struct {
+	Collection string `json:"collection"`
+	Ordered    bool   `json:"ordered"`
+	Operations []struct {
+		Type BulkWriteOperationType `json:"type"`
+		// Filter only takes string fields, so that a request cannot inject
+		// query operators.
+		Filter map[string]string `json:"filter"`
+		Data   map[string]any    `json:"data,omitempty"`
+	} `json:"operations"`
+}
+
+type bulkWriteItemReport struct {
+	Status  BulkWriteItemStatus `json:"status"`
+	Created bool                `json:"created,omitempty"`
+	Error   string              `json:"error,omitempty"`
+}
+
+type bulkWriteReport struct {
+	Results []bulkWriteItemReport `json:"results"`
+	// Error is set when the outcome of the write is unknown or not durable.
+	Error string `json:"error,omitempty"`
+}
+
+// BulkWriteAdminHandler provisions subscriber data in bulk. A POST names a
+// provisioned data collection, or the authentication subscription one, and
+// lists put and delete operations, each scoped to a UE by the ueId of its
+// filter. They are applied with RestfulAPIBulkWrite, ordered or not, and the
+// outcome of every operation is reported at its index.
+func BulkWriteAdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		var request bulkWriteRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, bulkWriteMaxBytes)).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		client, operations, err := bulkWriteOperations(request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if client == nil {
+			http.Error(w, "database not connected", http.StatusServiceUnavailable)
+			return
+		}
+
+		results, err := client.RestfulAPIBulkWrite(r.Context(), request.Collection, operations, request.Ordered)
+		report := bulkWriteReport{Results: make([]bulkWriteItemReport, len(results))}
+		failed := 0
+		for i, result := range results {
+			report.Results[i] = bulkWriteItemReport{Status: result.Status, Created: result.Created}
+			if result.Err != nil {
+				report.Results[i].Error = result.Err.Error()
+			}
+			if result.Status != BulkWriteApplied {
+				failed++
+			}
+		}
+		status := http.StatusOK
+		if err != nil {
+			logger.DataRepoLog.Warnf("bulk write to %s failed: %+v", request.Collection, err)
+			report.Error = err.Error()
+			status = http.StatusInternalServerError
+		}
+		logger.DataRepoLog.Infof("bulk write of %d operations to %s, %d not applied",
+			len(operations), request.Collection, failed)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			logger.DataRepoLog.Warnln(err)
+		}
+	})
+}
+
+// bulkWriteOperations validates request and returns the operations it lists
+// along with the database holding its collection.
+func bulkWriteOperations(request bulkWriteRequest) (DBInterface, []BulkWriteOperation, error) {
+	var client DBInterface
+	switch {
+	case request.Collection == authSubscriptionColl:
+		client = AuthDBClient
+	case strings.HasPrefix(request.Collection, provisionedDataCollPrefix):
+		client = CommonDBClient
+	default:
+		return nil, nil, fmt.Errorf("collection %q does not hold provisioned subscriber data", request.Collection)
+	}
+	if len(request.Operations) == 0 {
+		return nil, nil, errors.New("no operations")
+	}
+
+	operations := make([]BulkWriteOperation, len(request.Operations))
+	for i, operation := range request.Operations {
+		if operation.Filter["ueId"] == "" {
+			return nil, nil, fmt.Errorf("operation %d: filter has no ueId", i)
+		}
+		filter := make(bson.M, len(operation.Filter))
+		for field, value := range operation.Filter {
+			if strings.HasPrefix(field, "$") {
+				return nil, nil, fmt.Errorf("operation %d: filter field %q is an operator", i, field)
+			}
+			filter[field] = value
+		}
+		switch operation.Type {
+		case BulkWritePut:
+			if len(operation.Data) == 0 {
+				return nil, nil, fmt.Errorf("operation %d: put has no data", i)
+			}
+		case BulkWriteDelete:
+		default:
+			return nil, nil, fmt.Errorf("operation %d: unknown type %q", i, operation.Type)
+		}
+		operations[i] = BulkWriteOperation{Type: operation.Type, Filter: filter, Data: operation.Data}
+	}
+	return client, operations, nil
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// bulkWriteDB records the operations of RestfulAPIBulkWrite and fails the
+// operation at failAt, skipping the rest when the write is ordered.
+type bulkWriteDB struct {
+	stubDB
+	collName   string
+	operations []BulkWriteOperation
+	failAt     int
+}
+
+func (b *bulkWriteDB) RestfulAPIBulkWrite(_ context.Context, collName string, operations []BulkWriteOperation, ordered bool) ([]BulkWriteItemResult, error) {
+	b.collName, b.operations = collName, operations
+	results := make([]BulkWriteItemResult, len(operations))
+	for i := range results {
+		switch {
+		case i == b.failAt:
+			results[i] = BulkWriteItemResult{Status: BulkWriteFailed, Err: context.DeadlineExceeded}
+		case ordered && i > b.failAt:
+			results[i].Status = BulkWriteSkipped
+		default:
+			results[i] = BulkWriteItemResult{Status: BulkWriteApplied, Created: true}
+		}
+	}
+	return results, nil
+}
+
+func TestBulkWriteAdminReportsEveryOperation(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := &bulkWriteDB{failAt: 1}
+	cached := newCachedDBClient(db)
+	CommonDBClient = cached
+	if _, err := cached.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"collection": "` + testColl + `", "ordered": true, "operations": [
+		{"type": "put", "filter": {"ueId": "imsi-001010000000001", "servingPlmnId": "00101"}, "data": {"gpsis": ["msisdn-1"]}},
+		{"type": "put", "filter": {"ueId": "imsi-001010000000002", "servingPlmnId": "00101"}, "data": {"gpsis": ["msisdn-2"]}},
+		{"type": "delete", "filter": {"ueId": "imsi-001010000000003"}}
+	]}`
+	recorder := httptest.NewRecorder()
+	BulkWriteAdminHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, BulkWriteAdminPath, strings.NewReader(body)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("POST returned %d: %s", recorder.Code, recorder.Body)
+	}
+	var report bulkWriteReport
+	if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	want := []BulkWriteItemStatus{BulkWriteApplied, BulkWriteFailed, BulkWriteSkipped}
+	if len(report.Results) != len(want) {
+		t.Fatalf("expected %d results, got %+v", len(want), report.Results)
+	}
+	for i, result := range report.Results {
+		if result.Status != want[i] {
+			t.Errorf("operation %d: got %s, want %s", i, result.Status, want[i])
+		}
+	}
+	if report.Results[1].Error == "" || !report.Results[0].Created {
+		t.Errorf("expected the failure and the creation to be reported, got %+v", report.Results)
+	}
+	if db.collName != testColl || db.operations[2].Type != BulkWriteDelete ||
+		db.operations[0].Filter["servingPlmnId"] != "00101" {
+		t.Errorf("unexpected operations %+v on %s", db.operations, db.collName)
+	}
+	cached.mu.Lock()
+	size := len(cached.entries)
+	cached.mu.Unlock()
+	if size != 0 {
+		t.Errorf("expected the written UE to be invalidated, got %d entries", size)
+	}
+}
+
+func TestBulkWriteAdminRejectsInvalidRequests(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := &bulkWriteDB{failAt: -1}
+	CommonDBClient = db
+
+	for _, body := range []string{
+		`{"collection": "policyData.ues.amData", "operations": [{"type": "delete", "filter": {"ueId": "imsi-001010000000001"}}]}`,
+		`{"collection": "` + testColl + `", "operations": []}`,
+		`{"collection": "` + testColl + `", "operations": [{"type": "delete", "filter": {"servingPlmnId": "00101"}}]}`,
+		`{"collection": "` + testColl + `", "operations": [{"type": "delete", "filter": {"ueId": "imsi-001010000000001", "$where": "true"}}]}`,
+		`{"collection": "` + testColl + `", "operations": [{"type": "put", "filter": {"ueId": "imsi-001010000000001"}}]}`,
+		`{"collection": "` + testColl + `", "operations": [{"type": "upsert", "filter": {"ueId": "imsi-001010000000001"}}]}`,
+		`{"collection": "` + testColl + `", "operations": [{"type": "delete", "filter": {"ueId": {"$ne": ""}}}]}`,
+	} {
+		recorder := httptest.NewRecorder()
+		BulkWriteAdminHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, BulkWriteAdminPath, strings.NewReader(body)))
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("expected %s to be rejected, got %d", body, recorder.Code)
+		}
+	}
+	if db.operations != nil {
+		t.Errorf("expected nothing to be written, got %+v", db.operations)
+	}
+}
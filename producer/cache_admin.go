@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/omec-project/udr/logger"
+)
+
+// CacheAdminPath is where CacheAdminHandler is served. It belongs on the
+// loopback-only admin listener, not on the SBI or the metrics port, which
+// other hosts can reach.
+const CacheAdminPath = "/admin/cache"
+
+type cacheCollectionInfo struct {
+	Collection string `json:"collection"`
+	Entries    int    `json:"entries"`
+	TTL        string `json:"ttl"`
+}
+
+type cacheEntryInfo struct {
+	Collection    string    `json:"collection"`
+	UeId          string    `json:"ueId,omitempty"`
+	ServingPlmnId string    `json:"servingPlmnId,omitempty"`
+	Expiry        time.Time `json:"expiry"`
+}
+
+type cacheInfo struct {
+	Collections []cacheCollectionInfo `json:"collections"`
+	Entries     []cacheEntryInfo      `json:"entries,omitempty"`
+}
+
+// CacheAdminHandler lets operators look into and flush the common DB cache of
+// this replica. Entries are described by their key and expiry only, never by
+// the cached document, and the auth DB cache, which holds subscriber keys, is
+// left out altogether. Both methods take optional ueId and collection query
+// parameters:
+//
+//	GET    lists the cached collections with their size and TTL, and the
+//	       entries of ueId when given
+//	DELETE drops the entries of ueId, of collection, or of both, and
+//	       reports how many were dropped
+func CacheAdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ueId := r.URL.Query().Get("ueId")
+		collName := r.URL.Query().Get("collection")
+
+		switch r.Method {
+		case http.MethodGet:
+			info := cacheInfo{Collections: []cacheCollectionInfo{}}
+			for _, cached := range adminCachedDBClients() {
+				collections, entries := cached.inspect(collName, ueId)
+				info.Collections = append(info.Collections, collections...)
+				info.Entries = append(info.Entries, entries...)
+			}
+			writeCacheAdminResponse(w, info)
+		case http.MethodDelete:
+			if ueId == "" && collName == "" {
+				http.Error(w, "ueId or collection is required", http.StatusBadRequest)
+				return
+			}
+			flushed := 0
+			for _, cached := range adminCachedDBClients() {
+				flushed += cached.flushEntries(collName, ueId)
+			}
+			logger.DataRepoLog.Infof("flushed %d cache entries (ueId %q, collection %q)", flushed, ueId, collName)
+			writeCacheAdminResponse(w, map[string]int{"flushed": flushed})
+		default:
+			w.Header().Set("Allow", "GET, DELETE")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// adminCachedDBClients returns the caches CacheAdminHandler may touch.
+func adminCachedDBClients() []*cachedDBClient {
+	if cached, ok := CommonDBClient.(*cachedDBClient); ok {
+		return []*cachedDBClient{cached}
+	}
+	return nil
+}
+
+func writeCacheAdminResponse(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.DataRepoLog.Warnln(err)
+	}
+}
+
+// inspect summarises the cached collections, restricted to collName when
+// set, and returns the entries of ueId when set.
+func (c *cachedDBClient) inspect(collName string, ueId string) ([]cacheCollectionInfo, []cacheEntryInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sizes := make(map[string]int)
+	var entries []cacheEntryInfo
+	for _, entry := range c.entries {
+		sizes[entry.collName]++
+		if ueId != "" && entry.ueId == ueId && (collName == "" || entry.collName == collName) {
+			entries = append(entries, cacheEntryInfo{
+				Collection:    entry.collName,
+				UeId:          entry.ueId,
+				ServingPlmnId: entry.servingPlmnId,
+				Expiry:        entry.expiry,
+			})
+		}
+	}
+	slices.SortFunc(entries, func(a, b cacheEntryInfo) int {
+		return strings.Compare(a.Collection+"\x00"+a.ServingPlmnId, b.Collection+"\x00"+b.ServingPlmnId)
+	})
+
+	var collections []cacheCollectionInfo
+	for name, ttl := range c.policy.ttls {
+		if collName == "" || name == collName {
+			collections = append(collections, cacheCollectionInfo{Collection: name, Entries: sizes[name], TTL: ttl.String()})
+		}
+	}
+	slices.SortFunc(collections, func(a, b cacheCollectionInfo) int {
+		return strings.Compare(a.Collection, b.Collection)
+	})
+	return collections, entries
+}
+
+// flushEntries drops the entries of ueId in collName, either of which may be
+// empty to match any, and returns how many it dropped.
+func (c *cachedDBClient) flushEntries(collName string, ueId string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.staleInflight(func(scope string) bool {
+		coll, id, _ := strings.Cut(scope, "\x00")
+		return (collName == "" || coll == collName) && (ueId == "" || id == ueId)
+	})
+	return c.removeMatching(func(entry *cacheEntry) bool {
+		return (collName == "" || entry.collName == collName) && (ueId == "" || entry.ueId == ueId)
+	})
+}
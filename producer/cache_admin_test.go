@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestCacheAdminInspectsAndFlushesOneUe(t *testing.T) {
+	savedClient, savedAuthClient := CommonDBClient, AuthDBClient
+	t.Cleanup(func() { CommonDBClient, AuthDBClient = savedClient, savedAuthClient })
+	db := &stubDB{result: map[string]any{"foo": "bar"}}
+	cached := newCachedDBClient(db)
+	CommonDBClient = cached
+	authCached := newCachedDBClient(&stubDB{result: map[string]any{"encPermanentKey": "secret"}})
+	AuthDBClient = authCached
+
+	const ueId = "imsi-001010000000401"
+	const otherUeId = "imsi-001010000000402"
+	for _, filter := range []bson.M{
+		{"ueId": ueId, "servingPlmnId": "00101"},
+		{"ueId": otherUeId, "servingPlmnId": "00101"},
+	} {
+		if _, err := cached.RestfulAPIGetOne(context.Background(), testColl, filter); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := authCached.RestfulAPIGetOne(context.Background(), testColl, bson.M{"ueId": ueId}); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := CacheAdminHandler()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, CacheAdminPath+"?ueId="+ueId, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET returned %d: %s", recorder.Code, recorder.Body)
+	}
+	if strings.Contains(recorder.Body.String(), "bar") || strings.Contains(recorder.Body.String(), "secret") {
+		t.Errorf("expected no cached document in the response, got %s", recorder.Body)
+	}
+	var info cacheInfo
+	if err := json.Unmarshal(recorder.Body.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Entries) != 1 || info.Entries[0].UeId != ueId {
+		t.Errorf("unexpected entries %+v", info.Entries)
+	}
+	for _, collection := range info.Collections {
+		if collection.Collection == testColl && collection.Entries != 2 {
+			t.Errorf("expected 2 entries in %s, got %d", testColl, collection.Entries)
+		}
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, CacheAdminPath+"?ueId="+ueId, nil))
+	var flushed map[string]int
+	if err := json.Unmarshal(recorder.Body.Bytes(), &flushed); err != nil {
+		t.Fatal(err)
+	}
+	if flushed["flushed"] != 1 {
+		t.Errorf("expected 1 entry flushed, got %v", flushed)
+	}
+	cached.mu.Lock()
+	_, other := cached.entries[cacheKey(testColl, bson.M{"ueId": otherUeId, "servingPlmnId": "00101"})]
+	size := len(cached.entries)
+	cached.mu.Unlock()
+	if size != 1 || !other {
+		t.Errorf("expected only the other UE to stay cached, got %d entries", size)
+	}
+	authCached.mu.Lock()
+	authSize := len(authCached.entries)
+	authCached.mu.Unlock()
+	if authSize != 1 {
+		t.Errorf("expected the auth DB cache to be left alone, got %d entries", authSize)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, CacheAdminPath, nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected an unscoped flush to be rejected, got %d", recorder.Code)
+	}
+}
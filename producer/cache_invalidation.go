@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/util/mongoapi"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	// cacheInvalidationColl holds the cache scopes each replica invalidated
+	// after a write. Entries only need to outlive a change stream reconnect,
+	// so a TTL index removes them after cacheInvalidationTTL seconds.
+	cacheInvalidationColl = "cacheInvalidations"
+	cacheInvalidationTTL  = 300
+	publishTimeout        = 5 * time.Second
+	// cacheInvalidationMaxScopes bounds the scopes published for one batch of
+	// writes. Beyond it the other replicas drop the whole collection instead.
+	cacheInvalidationMaxScopes = 1000
+	// cacheFlushScope is published to drop every entry. No collection has an
+	// empty name, so it matches no scope of cacheScope.
+	cacheFlushScope = ""
+)
+
+// cacheInvalidationBus carries the cache scopes invalidated by writes on one
+// replica to every other replica sharing the database.
+type cacheInvalidationBus interface {
+	// Publish announces that the entries cached in scopes are stale.
+	Publish(scopes []string) error
+	// Subscribe calls invalidate with every scope published by the other
+	// replicas until ctx is cancelled. flush is called whenever scopes may
+	// have been missed, so that the whole cache is dropped instead.
+	Subscribe(ctx context.Context, invalidate func(scope string), flush func())
+}
+
+// mongoInvalidationBus publishes scopes as documents in cacheInvalidationColl
+// and follows the inserts of the other replicas with a change stream.
+type mongoInvalidationBus struct {
+	client *mongoapi.MongoClient
+	// origin identifies this process, so that it skips its own scopes.
+	origin string
+}
+
+func newMongoInvalidationBus(client *mongoapi.MongoClient) *mongoInvalidationBus {
+	if !client.RestfulAPICreateTTLIndex(cacheInvalidationColl, cacheInvalidationTTL, "createdAt") {
+		logger.DataRepoLog.Warnf("create TTL index on %s failed", cacheInvalidationColl)
+	}
+	return &mongoInvalidationBus{client: client, origin: uuid.New().String()}
+}
+
+// Publish inserts the scopes in a single round-trip.
+func (b *mongoInvalidationBus) Publish(scopes []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	now := time.Now()
+	documents := make([]any, len(scopes))
+	for i, scope := range scopes {
+		documents[i] = bson.M{
+			"scope":     scope,
+			"origin":    b.origin,
+			"createdAt": now,
+		}
+	}
+	_, err := b.client.GetCollection(cacheInvalidationColl).InsertMany(ctx, documents)
+	return err
+}
+
+func (b *mongoInvalidationBus) Subscribe(ctx context.Context, invalidate func(scope string), flush func()) {
+	backoff := changeStreamInitialBackoff
+	for reconnect := false; ; reconnect = true {
+		// A fresh stream starts at the current time. Scopes published while the
+		// previous one was down are lost, so drop everything on reconnect.
+		opened, err := b.watch(ctx, invalidate, func() {
+			if reconnect {
+				flush()
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		var serverErr mongo.ServerError
+		if errors.As(err, &serverErr) && serverErr.HasErrorCode(errCodeChangeStreamNotSupported) {
+			logger.DataRepoLog.Warnln("MongoDB does not support change streams; " +
+				"cached data may be stale for writes made through other replicas")
+			return
+		}
+		if opened {
+			backoff = changeStreamInitialBackoff
+		}
+		logger.DataRepoLog.Warnf("cache invalidation stream failed, retrying in %v: %+v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, changeStreamMaxBackoff)
+	}
+}
+
+// watch consumes one change stream until it fails, calling opened once it is
+// established. The returned bool reports whether it was.
+func (b *mongoInvalidationBus) watch(ctx context.Context, invalidate func(scope string), opened func()) (bool, error) {
+	pipeline := mongo.Pipeline{bson.D{{Key: "$match", Value: bson.M{
+		"operationType":       "insert",
+		"fullDocument.origin": bson.M{"$ne": b.origin},
+	}}}}
+	stream, err := b.client.GetCollection(cacheInvalidationColl).Watch(ctx, pipeline)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if errClose := stream.Close(context.Background()); errClose != nil {
+			logger.DataRepoLog.Debugln(errClose)
+		}
+	}()
+	opened()
+
+	for stream.Next(ctx) {
+		var event struct {
+			FullDocument struct {
+				Scope string `bson:"scope"`
+			} `bson:"fullDocument"`
+		}
+		if err := stream.Decode(&event); err != nil {
+			logger.DataRepoLog.Warnln(err)
+			continue
+		}
+		invalidate(event.FullDocument.Scope)
+	}
+	return true, stream.Err()
+}
+
+// StartCacheInvalidationListeners applies the invalidations published by the
+// other replicas to the local caches until ctx is cancelled.
+func StartCacheInvalidationListeners(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, cached := range cachedDBClients() {
+		if cached.bus == nil {
+			continue
+		}
+		wg.Go(func() {
+			cached.bus.Subscribe(ctx, cached.dropScope, cached.flush)
+		})
+	}
+	wg.Wait()
+}
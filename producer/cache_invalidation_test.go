@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// memInvalidationHub stands in for the shared database: every bus attached
+// to it receives the scopes published by the others.
+type memInvalidationHub struct {
+	mu          sync.Mutex
+	subscribers map[*memInvalidationBus]chan string
+}
+
+type memInvalidationBus struct {
+	hub *memInvalidationHub
+}
+
+func newMemInvalidationHub() *memInvalidationHub {
+	return &memInvalidationHub{subscribers: make(map[*memInvalidationBus]chan string)}
+}
+
+func (h *memInvalidationHub) bus() *memInvalidationBus {
+	return &memInvalidationBus{hub: h}
+}
+
+func (b *memInvalidationBus) Publish(scopes []string) error {
+	b.hub.mu.Lock()
+	defer b.hub.mu.Unlock()
+	for subscriber, published := range b.hub.subscribers {
+		if subscriber != b {
+			for _, scope := range scopes {
+				published <- scope
+			}
+		}
+	}
+	return nil
+}
+
+func (b *memInvalidationBus) Subscribe(ctx context.Context, invalidate func(scope string), flush func()) {
+	scopes := make(chan string, 16)
+	b.hub.mu.Lock()
+	b.hub.subscribers[b] = scopes
+	b.hub.mu.Unlock()
+	defer func() {
+		b.hub.mu.Lock()
+		delete(b.hub.subscribers, b)
+		b.hub.mu.Unlock()
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case scope := <-scopes:
+			invalidate(scope)
+		}
+	}
+}
+
+// subscribe starts c's listener and waits until it is attached to hub.
+func subscribe(t *testing.T, hub *memInvalidationHub, c *cachedDBClient) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	go func() {
+		defer close(done)
+		c.bus.Subscribe(ctx, c.dropScope, c.flush)
+	}()
+	for {
+		hub.mu.Lock()
+		_, attached := hub.subscribers[c.bus.(*memInvalidationBus)]
+		hub.mu.Unlock()
+		if attached {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWriteOnOneReplicaInvalidatesTheOthers(t *testing.T) {
+	db := newMemDB()
+	hub := newMemInvalidationHub()
+	replicas := make([]*cachedDBClient, 3)
+	for i := range replicas {
+		replicas[i] = newCachedDBClient(db)
+		replicas[i].bus = hub.bus()
+		subscribe(t, hub, replicas[i])
+	}
+
+	const collName = "subscriptionData.authenticationData.authenticationSubscription"
+	filter := bson.M{"ueId": "imsi-001010000000301"}
+	document := map[string]any{"ueId": "imsi-001010000000301", "sequenceNumber": "16f3b3f70fc2"}
+	if _, err := replicas[0].RestfulAPIPutOne(context.Background(), collName, filter, document); err != nil {
+		t.Fatal(err)
+	}
+	for _, replica := range replicas {
+		if _, err := replica.RestfulAPIGetOne(context.Background(), collName, filter); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A re-synchronisation rewrites the sequence number through replica 0.
+	document["sequenceNumber"] = "16f3b3f70fc3"
+	if _, err := replicas[0].RestfulAPIPutOne(context.Background(), collName, filter, document); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for _, replica := range replicas {
+		for {
+			got, err := replica.RestfulAPIGetOne(context.Background(), collName, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got["sequenceNumber"] == "16f3b3f70fc3" {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("replica still serves %v after the write", got)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+// racingDB runs during while a read is in flight.
+type racingDB struct {
+	*memDB
+	during func()
+}
+
+func (r *racingDB) RestfulAPIGetOne(ctx context.Context, collName string, filter bson.M) (map[string]any, error) {
+	document, err := r.memDB.RestfulAPIGetOne(ctx, collName, filter)
+	if r.during != nil {
+		r.during()
+	}
+	return document, err
+}
+
+func TestReadRacingAnInvalidationIsNotCached(t *testing.T) {
+	db := &racingDB{memDB: newMemDB()}
+	c := newCachedDBClient(db)
+	document := map[string]any{"ueId": testFilter["ueId"], "servingPlmnId": testFilter["servingPlmnId"], "foo": "old"}
+	if _, err := db.RestfulAPIPutOne(context.Background(), testColl, testFilter, document); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another replica writes and publishes after the read fetched the old
+	// document but before it was stored.
+	db.during = func() {
+		db.during = nil
+		document["foo"] = "new"
+		if _, err := db.RestfulAPIPutOne(context.Background(), testColl, testFilter, document); err != nil {
+			t.Error(err)
+		}
+		c.invalidate(testColl, testFilter)
+	}
+	if got, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil || got["foo"] != "old" {
+		t.Fatalf("RestfulAPIGetOne() = %v, %v", got, err)
+	}
+
+	if got, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil || got["foo"] != "new" {
+		t.Errorf("expected the read that raced the invalidation not to be cached, got %v, %v", got, err)
+	}
+}
+
+func TestFlushOnOneReplicaFlushesTheOthers(t *testing.T) {
+	db := newMemDB()
+	hub := newMemInvalidationHub()
+	replicas := make([]*cachedDBClient, 2)
+	for i := range replicas {
+		replicas[i] = newCachedDBClient(db)
+		replicas[i].bus = hub.bus()
+		subscribe(t, hub, replicas[i])
+	}
+
+	document := map[string]any{"ueId": testFilter["ueId"], "servingPlmnId": testFilter["servingPlmnId"], "foo": "old"}
+	if _, err := db.RestfulAPIPutOne(context.Background(), testColl, testFilter, document); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := replicas[1].RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
+		t.Fatal(err)
+	}
+
+	// A write the replicas never heard of, as when change stream history
+	// was lost.
+	document["foo"] = "new"
+	if _, err := db.RestfulAPIPutOne(context.Background(), testColl, testFilter, document); err != nil {
+		t.Fatal(err)
+	}
+	replicas[0].flushEverywhere()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		got, err := replicas[1].RestfulAPIGetOne(context.Background(), testColl, testFilter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got["foo"] == "new" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("replica still serves %v after the flush", got)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -6,31 +6,57 @@
 package producer
 
 import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
 	"github.com/omec-project/openapi/v2/models"
+	udr_context "github.com/omec-project/udr/context"
+	"github.com/omec-project/udr/logger"
 	"github.com/omec-project/udr/producer/callback"
+	"github.com/omec-project/udr/util"
 )
 
-func PreHandleOnDataChangeNotify(ueId string, resourceId string, patchItems []models.PatchItem,
-	origValue interface{}, newValue interface{},
-) {
-	notifyItems := []models.NotifyItem{}
-	changes := []models.ChangeItem{}
+// DataInd values of an application data subscription's dataFilters. BDT
+// policy data is not stored by this UDR, so it never changes and dataFilters
+// for it are rejected.
+const (
+	ApplicationDataIndPfd      = "PFD"
+	ApplicationDataIndIptv     = "IPTV"
+	ApplicationDataIndBdt      = "BDT"
+	ApplicationDataIndSvcParam = "SVC_PRAM"
+)
 
-	for _, patchItem := range patchItems {
-		change := models.NewChangeItem(models.ChangeType(patchItem.GetOp()), patchItem.GetPath())
-		if from, ok := patchItem.GetFromOk(); ok {
-			change.SetFrom(*from)
-		}
-		change.SetOrigValue(origValue)
-		change.SetNewValue(newValue)
-		changes = append(changes, *change)
+// applicationDataNotifAttr maps each notified DataInd to the
+// ApplicationDataChangeNotif attribute carrying the changed resource.
+var applicationDataNotifAttr = map[string]string{
+	ApplicationDataIndPfd:      "pfdData",
+	ApplicationDataIndIptv:     "iptvConfigData",
+	ApplicationDataIndSvcParam: "serParaData",
+}
+
+// PreHandleOnDataChangeNotify notifies the subscribers of ueId that the
+// resource resourceId changed from origValue to newValue. Nothing is sent
+// when the two documents do not differ.
+func PreHandleOnDataChangeNotify(ueId string, resourceId string, origValue interface{}, newValue interface{}) {
+	notifyDataChange(ueId, resourceId, changeItems(origValue, newValue))
+}
+
+func notifyDataChange(ueId string, resourceId string, changes []models.ChangeItem) {
+	notifyItems := []models.NotifyItem{}
+	if len(changes) == 0 {
+		return
 	}
 
 	notifyItem := models.NewNotifyItem(resourceId, changes)
 
 	notifyItems = append(notifyItems, *notifyItem)
 
-	go callback.SendOnDataChangeNotify(ueId, notifyItems)
+	callback.Run("data change notification of "+ueId, func(ctx context.Context) {
+		refreshSubscriptionDataSubscriptions(ctx, ueId)
+		callback.SendOnDataChangeNotify(ueId, notifyItems)
+	})
 }
 
 func PreHandlePolicyDataChangeNotification(ueId string, dataId string, value interface{}) {
@@ -60,5 +86,171 @@ func PreHandlePolicyDataChangeNotification(ueId string, dataId string, value int
 		return
 	}
 
-	go callback.SendPolicyDataChangeNotification([]models.PolicyDataChangeNotification{policyDataChangeNotification})
+	requested := time.Now()
+	callback.Run("policy data change notification", func(ctx context.Context) {
+		refreshPolicyDataSubscriptionsSince(ctx, requested)
+		callback.SendPolicyDataChangeNotification([]models.PolicyDataChangeNotification{policyDataChangeNotification})
+	})
+}
+
+// PreHandleExposureDataChangeNotification notifies the exposure-data
+// subscribers monitoring resourcePath. A nil value reports the resource as
+// deleted.
+func PreHandleExposureDataChangeNotification(ueId string, resourcePath string, value interface{}) {
+	exposureDataChangeNotification := models.NewExposureDataChangeNotification()
+	exposureDataChangeNotification.SetUeId(ueId)
+
+	switch v := value.(type) {
+	case models.AccessAndMobilityData:
+		exposureDataChangeNotification.SetAccessAndMobilityData(v)
+	case models.PduSessionManagementData:
+		exposureDataChangeNotification.SetPduSessionManagementData([]models.PduSessionManagementData{v})
+	case nil:
+		apiRoot := udr_context.UDR_Self().GetIPv4GroupUri(udr_context.NUDR_DR)
+		exposureDataChangeNotification.SetDelResources([]string{apiRoot + resourcePath})
+	default:
+		return
+	}
+
+	callback.SendExposureDataChangeNotification(resourcePath, *exposureDataChangeNotification)
+}
+
+// PreHandleApplicationDataChangeNotification notifies the application-data
+// subscribers whose dataFilters cover the resource at resourcePath. A nil
+// value reports the resource as deleted, in which case only resUri is sent.
+func PreHandleApplicationDataChangeNotification(dataInd string, resourcePath string, value interface{}) {
+	var data map[string]interface{}
+	if value != nil {
+		// Round-trip through JSON so documents read back from the DB and
+		// freshly marshalled models are matched the same way.
+		data = util.ToBsonM(value)
+	}
+
+	applicationDataChangeNotif := map[string]interface{}{
+		"resUri": udr_context.UDR_Self().GetIPv4GroupUri(udr_context.NUDR_DR) + resourcePath,
+	}
+	if data != nil {
+		applicationDataChangeNotif[applicationDataNotifAttr[dataInd]] = data
+	}
+
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.ApplicationDataSubsMtx.RLock()
+	var notificationUris []string
+	for _, applicationDataSubs := range udrSelf.ApplicationDataSubscriptions {
+		if applicationDataSubsMatches(*applicationDataSubs, dataInd, data) {
+			notificationUris = append(notificationUris, applicationDataSubs.GetNotificationUri())
+		}
+	}
+	udrSelf.ApplicationDataSubsMtx.RUnlock()
+
+	for _, notificationUri := range notificationUris {
+		callback.SendApplicationDataChangeNotification(notificationUri,
+			[]map[string]interface{}{applicationDataChangeNotif})
+	}
+}
+
+// unsupportedApplicationDataInd returns the first dataInd among the
+// dataFilters of applicationDataSubs that is never notified, or "" if they
+// all are.
+func unsupportedApplicationDataInd(applicationDataSubs models.ApplicationDataSubs) string {
+	dataFilters, _ := util.ToBsonM(applicationDataSubs)["dataFilters"].([]interface{})
+	for _, f := range dataFilters {
+		dataFilter, _ := f.(map[string]interface{})
+		if dataInd, _ := dataFilter["dataInd"].(string); dataInd != "" {
+			if _, ok := applicationDataNotifAttr[dataInd]; !ok {
+				return dataInd
+			}
+		}
+	}
+	return ""
+}
+
+// applicationDataSubsMatches reports whether one of the dataFilters of
+// applicationDataSubs covers data of kind dataInd. A subscription without
+// dataFilters receives every change. Deleted resources (nil data) match on
+// dataInd alone.
+func applicationDataSubsMatches(applicationDataSubs models.ApplicationDataSubs, dataInd string,
+	data map[string]interface{},
+) bool {
+	dataFilters, _ := util.ToBsonM(applicationDataSubs)["dataFilters"].([]interface{})
+	if len(dataFilters) == 0 {
+		return true
+	}
+	for _, f := range dataFilters {
+		dataFilter, ok := f.(map[string]interface{})
+		if ok && applicationDataFilterMatches(dataFilter, dataInd, data) {
+			return true
+		}
+	}
+	return false
+}
+
+// applicationDataFilterMatches applies a single DataFilter. Every attribute
+// list present in the filter must contain the corresponding value of data.
+func applicationDataFilterMatches(dataFilter map[string]interface{}, dataInd string,
+	data map[string]interface{},
+) bool {
+	if ind, _ := dataFilter["dataInd"].(string); ind != dataInd {
+		return false
+	}
+	if data == nil {
+		return true
+	}
+	return filterListContains(dataFilter["dnns"], data["dnn"]) &&
+		filterListContains(dataFilter["internalGroupIds"], data["interGroupId"]) &&
+		filterListContains(dataFilter["supis"], data["supi"]) &&
+		filterListContains(dataFilter["appIds"], applicationDataAppId(data)) &&
+		filterSnssaisContain(dataFilter["snssais"], data["snssai"])
+}
+
+// applicationDataAppId returns the application identifier of an application
+// data document; PFD, IPTV and service parameter data each name it differently.
+func applicationDataAppId(data map[string]interface{}) interface{} {
+	for _, attr := range []string{"applicationId", "afAppId", "appId"} {
+		if appId, ok := data[attr]; ok {
+			return appId
+		}
+	}
+	return nil
+}
+
+func filterListContains(filterValues interface{}, value interface{}) bool {
+	values, _ := filterValues.([]interface{})
+	if len(values) == 0 {
+		return true
+	}
+	return slices.Contains(values, value)
+}
+
+func filterSnssaisContain(filterValues interface{}, value interface{}) bool {
+	values, _ := filterValues.([]interface{})
+	if len(values) == 0 {
+		return true
+	}
+	snssai, ok := toSnssai(value)
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if filterSnssai, ok := toSnssai(v); ok && snssaiEqual(snssai, filterSnssai) {
+			return true
+		}
+	}
+	return false
+}
+
+func toSnssai(value interface{}) (models.Snssai, bool) {
+	var snssai models.Snssai
+	if value == nil {
+		return snssai, false
+	}
+	raw, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(raw, &snssai)
+	}
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return snssai, false
+	}
+	return snssai, true
 }
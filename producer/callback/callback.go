@@ -8,8 +8,10 @@ package callback
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/omec-project/openapi/v2/models"
@@ -19,67 +21,131 @@ import (
 
 const callbackRequestTimeout = 5 * time.Second
 
+// closeCallbackResponseBody drains and closes the response body so that the
+// connection can be reused for the next notification.
 func closeCallbackResponseBody(httpResponse *http.Response) {
 	if httpResponse != nil && httpResponse.Body != nil {
+		if _, err := io.Copy(io.Discard, httpResponse.Body); err != nil {
+			logger.HttpLog.Debugf("callback response body drain failed: %v", err)
+		}
 		if err := httpResponse.Body.Close(); err != nil {
 			logger.HttpLog.Errorf("callback response body close failed: %v", err)
 		}
 	}
 }
 
-func postCallbackJSON(ctx context.Context, callbackURI string, body any) (*http.Response, error) {
-	payload, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-
+func postCallbackJSON(ctx context.Context, client *http.Client, callbackURI string, callbackType string,
+	payload []byte,
+) (*http.Response, error) {
 	request, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURI, bytes.NewReader(payload))
 	if err != nil {
 		return nil, err
 	}
 	request.Header.Set("Content-Type", "application/json")
+	setSbiHeaders(request, callbackType)
+
+	return client.Do(request)
+}
 
-	return http.DefaultClient.Do(request)
+// Run has task executed on the bounded pool that prepares notifications,
+// e.g. to read the subscribers of a change from the DB before sending to
+// them. name identifies the task in the log should it be dropped.
+func Run(name string, task func(ctx context.Context)) {
+	notificationDispatcher.run(name, task)
 }
 
 func SendOnDataChangeNotify(ueId string, notifyItems []models.NotifyItem) {
 	udrSelf := udr_context.UDR_Self()
 
+	now := time.Now()
+	var subscriptionDataSubscriptions []models.SubscriptionDataSubscriptions
+	udrSelf.SubscriptionDataSubsMtx.RLock()
 	for _, subscriptionDataSubscription := range udrSelf.SubscriptionDataSubscriptions {
+		// Expired subscriptions are skipped until the reaper removes them.
+		if subscriptionDataSubscription.HasExpiry() && !subscriptionDataSubscription.GetExpiry().After(now) {
+			continue
+		}
 		if ueId == subscriptionDataSubscription.GetUeId() {
-			dataChangeNotify := models.NewDataChangeNotify()
-			dataChangeNotify.SetUeId(ueId)
-			dataChangeNotify.SetNotifyItems(notifyItems)
-			dataChangeNotify.SetOriginalCallbackReference([]string{subscriptionDataSubscription.GetOriginalCallbackReference()})
-			ctx, cancel := context.WithTimeout(context.Background(), callbackRequestTimeout)
-			httpResponse, err := postCallbackJSON(ctx, subscriptionDataSubscription.GetCallbackReference(), dataChangeNotify)
-			cancel()
-			if err != nil {
-				if httpResponse == nil {
-					logger.HttpLog.Errorln(err.Error())
-				} else if err.Error() != httpResponse.Status {
-					logger.HttpLog.Errorln(err.Error())
-				}
-			}
-			closeCallbackResponseBody(httpResponse)
+			subscriptionDataSubscriptions = append(subscriptionDataSubscriptions, *subscriptionDataSubscription)
 		}
 	}
+	udrSelf.SubscriptionDataSubsMtx.RUnlock()
+
+	for _, subscriptionDataSubscription := range subscriptionDataSubscriptions {
+		dataChangeNotify := models.NewDataChangeNotify()
+		dataChangeNotify.SetUeId(ueId)
+		dataChangeNotify.SetNotifyItems(notifyItems)
+		dataChangeNotify.SetOriginalCallbackReference([]string{subscriptionDataSubscription.GetOriginalCallbackReference()})
+		notificationDispatcher.enqueue(subscriptionDataSubscription.GetCallbackReference(), dataChangeCallback,
+			dataChangeNotify)
+	}
 }
 
 func SendPolicyDataChangeNotification(policyDataChangeNotification []models.PolicyDataChangeNotification) {
 	udrSelf := udr_context.UDR_Self()
 
+	now := time.Now()
+	var notificationUris []string
+	udrSelf.PolicyDataSubsMtx.RLock()
 	for _, policyDataSubscription := range udrSelf.PolicyDataSubscriptions {
-		ctx, cancel := context.WithTimeout(context.Background(), callbackRequestTimeout)
-		httpResponse, err := postCallbackJSON(ctx, policyDataSubscription.GetNotificationUri(), policyDataChangeNotification)
-		cancel()
-		if err != nil {
-			if httpResponse == nil {
-				logger.HttpLog.Errorln(err.Error())
-			} else if err.Error() != httpResponse.Status {
-				logger.HttpLog.Errorln(err.Error())
+		if policyDataSubscription.HasExpiry() && !policyDataSubscription.GetExpiry().After(now) {
+			continue
+		}
+		notificationUris = append(notificationUris, policyDataSubscription.GetNotificationUri())
+	}
+	udrSelf.PolicyDataSubsMtx.RUnlock()
+
+	for _, notificationUri := range notificationUris {
+		notificationDispatcher.enqueue(notificationUri, policyDataChangeCallback, policyDataChangeNotification)
+	}
+}
+
+func SendApplicationDataChangeNotification(notificationUri string, applicationDataChangeNotif []map[string]interface{}) {
+	notificationDispatcher.enqueue(notificationUri, applicationDataChangeCallback, applicationDataChangeNotif)
+}
+
+// SendDataRestorationNotification tells the NF behind
+// dataRestorationCallbackUri that subscriptions it held may have been lost.
+func SendDataRestorationNotification(dataRestorationCallbackUri string,
+	dataRestorationNotification models.DataRestorationNotification,
+) {
+	notificationDispatcher.enqueue(dataRestorationCallbackUri, dataRestorationCallback, dataRestorationNotification)
+}
+
+// monitorsResource reports whether monitoredResourceUri covers the exposure
+// data resource at resourcePath, i.e. it names the resource itself or one of
+// its parent resources. Monitored URIs are absolute, so only their path is
+// compared and the API root in front of "/exposure-data" is ignored.
+func monitorsResource(monitoredResourceUri string, resourcePath string) bool {
+	monitoredPath := monitoredResourceUri
+	if parsed, err := url.Parse(monitoredResourceUri); err == nil {
+		monitoredPath = parsed.Path
+	}
+	idx := strings.Index(monitoredPath, "/exposure-data/")
+	if idx < 0 {
+		return false
+	}
+	monitoredPath = strings.TrimSuffix(monitoredPath[idx:], "/")
+	return resourcePath == monitoredPath || strings.HasPrefix(resourcePath, monitoredPath+"/")
+}
+
+func SendExposureDataChangeNotification(resourcePath string, exposureDataChangeNotification models.ExposureDataChangeNotification) {
+	udrSelf := udr_context.UDR_Self()
+
+	var notificationUris []string
+	udrSelf.ExposureDataSubsMtx.RLock()
+	for _, exposureDataSubscription := range udrSelf.ExposureDataSubscriptions {
+		for _, monitoredResourceUri := range exposureDataSubscription.GetMonitoredResourceUris() {
+			if monitorsResource(monitoredResourceUri, resourcePath) {
+				notificationUris = append(notificationUris, exposureDataSubscription.GetNotificationUri())
+				break
 			}
 		}
-		closeCallbackResponseBody(httpResponse)
+	}
+	udrSelf.ExposureDataSubsMtx.RUnlock()
+
+	for _, notificationUri := range notificationUris {
+		notificationDispatcher.enqueue(notificationUri, exposureDataChangeCallback,
+			[]models.ExposureDataChangeNotification{exposureDataChangeNotification})
 	}
 }
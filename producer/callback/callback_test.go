@@ -98,3 +98,66 @@ func TestSendPolicyDataChangeNotificationUsesNotificationUriDirectly(t *testing.
 		t.Fatalf("expected policy data change notification payload %#v, got %#v", notifications, got)
 	}
 }
+
+func TestMonitorsResourceMatchesResourceAndParents(t *testing.T) {
+	resourcePath := "/exposure-data/imsi-001010000000001/access-and-mobility-data"
+	cases := []struct {
+		monitoredResourceUri string
+		want                 bool
+	}{
+		{"http://udr:29504/nudr-dr/v2/exposure-data/imsi-001010000000001/access-and-mobility-data", true},
+		{"http://udr:29504/nudr-dr/v2/exposure-data/imsi-001010000000001", true},
+		{"http://udr:29504/nudr-dr/v2/exposure-data/imsi-001010000000001/", true},
+		{"http://udr:29504/nudr-dr/v2/exposure-data/imsi-00101000000000", false},
+		{"http://udr:29504/nudr-dr/v2/exposure-data/imsi-001010000000001/session-management-data", false},
+		{"http://udr:29504/nudr-dr/v2/subscription-data/imsi-001010000000001", false},
+	}
+	for _, tc := range cases {
+		if got := monitorsResource(tc.monitoredResourceUri, resourcePath); got != tc.want {
+			t.Errorf("monitorsResource(%q) = %v, want %v", tc.monitoredResourceUri, got, tc.want)
+		}
+	}
+}
+
+func TestSendExposureDataChangeNotificationOnlyNotifiesMatchingSubscriptions(t *testing.T) {
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.ExposureDataSubsMtx.Lock()
+	originalSubscriptions := udrSelf.ExposureDataSubscriptions
+	udrSelf.ExposureDataSubsMtx.Unlock()
+	t.Cleanup(func() {
+		udrSelf.ExposureDataSubsMtx.Lock()
+		udrSelf.ExposureDataSubscriptions = originalSubscriptions
+		udrSelf.ExposureDataSubsMtx.Unlock()
+	})
+
+	requestPath := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath <- r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	apiRoot := "http://udr:29504/nudr-dr/v2"
+	udrSelf.ExposureDataSubsMtx.Lock()
+	udrSelf.ExposureDataSubscriptions = map[string]*models.ExposureDataSubscription{
+		"1": models.NewExposureDataSubscription(server.URL+"/matching",
+			[]string{apiRoot + "/exposure-data/imsi-001010000000001"}),
+		"2": models.NewExposureDataSubscription(server.URL+"/other-ue",
+			[]string{apiRoot + "/exposure-data/imsi-001010000000002"}),
+	}
+	udrSelf.ExposureDataSubsMtx.Unlock()
+
+	notification := models.NewExposureDataChangeNotification()
+	notification.SetUeId("imsi-001010000000001")
+	SendExposureDataChangeNotification("/exposure-data/imsi-001010000000001/access-and-mobility-data", *notification)
+	notificationDispatcher.wait()
+
+	close(requestPath)
+	var paths []string
+	for path := range requestPath {
+		paths = append(paths, path)
+	}
+	if len(paths) != 1 || paths[0] != "/matching" {
+		t.Fatalf("expected a single notification to /matching, got %v", paths)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package callback
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	udr_context "github.com/omec-project/udr/context"
+)
+
+// SBI headers set on every notification (3GPP TS 29.500 clause 5.2.3).
+const (
+	sbiCallbackHeader      = "3gpp-Sbi-Callback"
+	sbiTargetApiRootHeader = "3gpp-Sbi-Target-apiRoot"
+)
+
+// Callback types carried in the 3gpp-Sbi-Callback header.
+const (
+	dataChangeCallback            = "Nudr_DataRepository_DataChangeNotify"
+	policyDataChangeCallback      = "Nudr_DataRepository_PolicyDataChangeNotification"
+	applicationDataChangeCallback = "Nudr_DataRepository_ApplicationDataChangeNotification"
+	exposureDataChangeCallback    = "Nudr_DataRepository_ExposureDataChangeNotification"
+	dataRestorationCallback       = "Nudr_DataRepository_DataRestorationNotification"
+)
+
+// newSbiClient returns the client used for callbacks. It speaks HTTP/2 only:
+// h2 negotiated over TLS for https URIs and h2c with prior knowledge for http
+// URIs, as the SBI requires (3GPP TS 29.500 clause 5.2.2). Connections are
+// pooled per destination by the shared transport.
+//
+// certFile and keyFile, when both set, are presented as the client
+// certificate for mutual TLS. caFile, when set, replaces the system roots
+// for verifying the NF being notified.
+func newSbiClient(certFile, keyFile, caFile string) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" && keyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load callback client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read callback CA bundle: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in callback CA bundle %s", caFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	protocols := new(http.Protocols)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Protocols = protocols
+
+	return &http.Client{Transport: transport, Timeout: callbackRequestTimeout}, nil
+}
+
+// ConfigureClient builds the callback client from the SBI TLS settings of
+// the UDR context. It must be called before the first notification is sent.
+func ConfigureClient(udrSelf *udr_context.UDRContext) error {
+	client, err := newSbiClient(udrSelf.PEM, udrSelf.Key, udrSelf.CA)
+	if err != nil {
+		return err
+	}
+	notificationDispatcher.client = client
+	return nil
+}
+
+// setSbiHeaders sets the headers identifying the request as a notification
+// of callbackType sent by this UDR.
+func setSbiHeaders(request *http.Request, callbackType string) {
+	request.Header.Set(sbiCallbackHeader, callbackType)
+	target := url.URL{Scheme: request.URL.Scheme, Host: request.URL.Host}
+	request.Header.Set(sbiTargetApiRootHeader, target.String())
+	request.Header.Set("User-Agent", "UDR-"+udr_context.UDR_Self().NfId)
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package callback
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	udr_context "github.com/omec-project/udr/context"
+)
+
+func TestSbiClientSpeaksH2cWithSbiHeaders(t *testing.T) {
+	udrSelf := udr_context.UDR_Self()
+	savedNfId := udrSelf.NfId
+	t.Cleanup(func() { udrSelf.NfId = savedNfId })
+	udrSelf.NfId = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	t.Cleanup(server.Close)
+
+	client, err := newSbiClient("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpResponse, err := postCallbackJSON(context.Background(), client, server.URL+"/notify", dataChangeCallback, []byte(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeCallbackResponseBody(httpResponse)
+
+	r := <-requests
+	if r.ProtoMajor != 2 {
+		t.Errorf("expected HTTP/2, got %s", r.Proto)
+	}
+	if got := r.Header.Get(sbiCallbackHeader); got != dataChangeCallback {
+		t.Errorf("%s = %q, want %q", sbiCallbackHeader, got, dataChangeCallback)
+	}
+	if got := r.Header.Get(sbiTargetApiRootHeader); got != server.URL {
+		t.Errorf("%s = %q, want %q", sbiTargetApiRootHeader, got, server.URL)
+	}
+	if got, want := r.Header.Get("User-Agent"), "UDR-"+udrSelf.NfId; got != want {
+		t.Errorf("User-Agent = %q, want %q", got, want)
+	}
+}
+
+func TestSbiClientPresentsClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCertificate(t, dir)
+
+	clientCAs := x509.NewCertPool()
+	caPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs.AppendCertsFromPEM(caPEM)
+
+	protocols := make(chan int, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		protocols <- r.ProtoMajor
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.EnableHTTP2 = true
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	// The test server certificate is signed by the httptest CA, so trust it
+	// explicitly.
+	serverCAFile := filepath.Join(dir, "server-ca.pem")
+	serverCAPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(serverCAFile, serverCAPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := newSbiClient(certFile, keyFile, serverCAFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpResponse, err := postCallbackJSON(context.Background(), client, server.URL+"/notify", dataChangeCallback, []byte(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeCallbackResponseBody(httpResponse)
+	if httpResponse.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected %d, got %s", http.StatusNoContent, httpResponse.Status)
+	}
+	if got := <-protocols; got != 2 {
+		t.Errorf("expected HTTP/2, got HTTP/%d", got)
+	}
+
+	// Without the client certificate the handshake is refused.
+	client, err = newSbiClient("", "", serverCAFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if httpResponse, err := postCallbackJSON(context.Background(), client, server.URL+"/notify", dataChangeCallback,
+		[]byte(`{}`)); err == nil {
+		closeCallbackResponseBody(httpResponse)
+		t.Fatal("expected the request without a client certificate to fail")
+	}
+}
+
+func writeSelfSignedCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "udr"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = filepath.Join(dir, "udr.pem")
+	keyFile = filepath.Join(dir, "udr.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
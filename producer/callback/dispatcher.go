@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/omec-project/udr/logger"
+)
+
+const (
+	notificationQueueSize         = 4096
+	notificationWorkers           = 32
+	notificationMaxPerDestination = 4
+	notificationMaxAttempts       = 5
+	notificationInitialBackoff    = 500 * time.Millisecond
+	notificationMaxBackoff        = 30 * time.Second
+	// Tasks read what a notification needs, typically its subscribers, from
+	// the DB before enqueueing it.
+	notificationTaskQueueSize = 1024
+	notificationTaskWorkers   = 8
+	notificationTaskTimeout   = 10 * time.Second
+)
+
+// notification is a single callback request owed to an NF.
+type notification struct {
+	uri          string
+	callbackType string
+	payload      []byte
+	attempts     int
+}
+
+// destination tracks the deliveries in flight to one host and the
+// notifications waiting for one of them to finish.
+type destination struct {
+	active  int
+	pending []*notification
+}
+
+// dispatcher delivers notifications from a bounded queue with a fixed pool
+// of workers. At most maxPerDestination deliveries run against one host at a
+// time; notifications beyond that wait for the host instead of tying up a
+// worker. Failed deliveries are retried with exponential backoff, and
+// notifications that cannot be delivered are handed to deadLetter. Tasks
+// preparing notifications run on a separate fixed pool, so that a burst of
+// changes does not start a DB read per change at once.
+type dispatcher struct {
+	client            *http.Client
+	queue             chan *notification
+	tasks             chan func()
+	workers           int
+	taskWorkers       int
+	maxPerDestination int
+	maxAttempts       int
+	initialBackoff    time.Duration
+	maxBackoff        time.Duration
+	deadLetter        func(n *notification, reason string)
+
+	startOnce    sync.Once
+	mtx          sync.Mutex
+	queued       int // accepted and not yet delivered or dead-lettered
+	destinations map[string]*destination
+	inFlight     sync.WaitGroup
+}
+
+func newDispatcher(queueSize, workers, maxPerDestination, maxAttempts int,
+	initialBackoff, maxBackoff time.Duration,
+) *dispatcher {
+	return &dispatcher{
+		client:            &http.Client{Timeout: callbackRequestTimeout},
+		queue:             make(chan *notification, queueSize),
+		tasks:             make(chan func(), notificationTaskQueueSize),
+		workers:           workers,
+		taskWorkers:       notificationTaskWorkers,
+		maxPerDestination: maxPerDestination,
+		maxAttempts:       maxAttempts,
+		initialBackoff:    initialBackoff,
+		maxBackoff:        maxBackoff,
+		deadLetter:        logDeadLetter,
+		destinations:      make(map[string]*destination),
+	}
+}
+
+var notificationDispatcher = newDispatcher(notificationQueueSize, notificationWorkers,
+	notificationMaxPerDestination, notificationMaxAttempts, notificationInitialBackoff, notificationMaxBackoff)
+
+// logDeadLetter records a notification that will not be delivered, with
+// enough detail to replay it by hand.
+func logDeadLetter(n *notification, reason string) {
+	logger.HttpLog.Errorw("notification dropped", "uri", n.uri, "callbackType", n.callbackType, "attempts", n.attempts,
+		"reason", reason, "payload", string(n.payload))
+}
+
+// enqueue accepts body for delivery to uri as a notification of
+// callbackType. It never blocks: when the queue is full the notification is
+// dead-lettered straight away.
+func (d *dispatcher) enqueue(uri string, callbackType string, body any) {
+	d.startOnce.Do(d.start)
+
+	n := &notification{uri: uri, callbackType: callbackType}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		d.deadLetter(n, err.Error())
+		return
+	}
+	n.payload = payload
+
+	d.mtx.Lock()
+	if d.queued >= cap(d.queue) {
+		d.mtx.Unlock()
+		d.deadLetter(n, "notification queue full")
+		return
+	}
+	d.queued++
+	d.inFlight.Add(1)
+	d.mtx.Unlock()
+
+	// Cannot block: the channel holds as many entries as may be queued.
+	d.queue <- n
+}
+
+// run has task executed on one of the task workers. It never blocks: when
+// the task queue is full the task is dropped and logged.
+func (d *dispatcher) run(name string, task func(ctx context.Context)) {
+	d.startOnce.Do(d.start)
+
+	d.inFlight.Add(1)
+	select {
+	case d.tasks <- func() {
+		defer d.inFlight.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), notificationTaskTimeout)
+		defer cancel()
+		task(ctx)
+	}:
+	default:
+		d.inFlight.Done()
+		logger.HttpLog.Errorw("notification dropped", "task", name, "reason", "notification task queue full")
+	}
+}
+
+func (d *dispatcher) start() {
+	for range d.workers {
+		go d.work()
+	}
+	for range d.taskWorkers {
+		go func() {
+			for task := range d.tasks {
+				task()
+			}
+		}()
+	}
+}
+
+func (d *dispatcher) work() {
+	for n := range d.queue {
+		host := destinationOf(n.uri)
+
+		d.mtx.Lock()
+		dest, ok := d.destinations[host]
+		if !ok {
+			dest = new(destination)
+			d.destinations[host] = dest
+		}
+		if dest.active >= d.maxPerDestination {
+			dest.pending = append(dest.pending, n)
+			d.mtx.Unlock()
+			continue
+		}
+		dest.active++
+		d.mtx.Unlock()
+
+		// Keep serving the host while notifications are waiting for it.
+		for n != nil {
+			d.deliver(n)
+
+			d.mtx.Lock()
+			if len(dest.pending) > 0 {
+				n = dest.pending[0]
+				dest.pending = dest.pending[1:]
+			} else {
+				n = nil
+				dest.active--
+				if dest.active == 0 {
+					delete(d.destinations, host)
+				}
+			}
+			d.mtx.Unlock()
+		}
+	}
+}
+
+// deliver makes one attempt to send n, and on failure either schedules the
+// next attempt or dead-letters n.
+func (d *dispatcher) deliver(n *notification) {
+	n.attempts++
+	retry, err := d.post(n)
+	if err == nil {
+		d.done()
+		return
+	}
+	if !retry || n.attempts >= d.maxAttempts {
+		d.deadLetter(n, err.Error())
+		d.done()
+		return
+	}
+	logger.HttpLog.Debugf("notification to %s failed (attempt %d): %v", n.uri, n.attempts, err)
+	// The notification stays counted in queued while it waits, so putting it
+	// back on the queue cannot block.
+	time.AfterFunc(d.backoff(n.attempts), func() { d.queue <- n })
+}
+
+func (d *dispatcher) done() {
+	d.mtx.Lock()
+	d.queued--
+	d.mtx.Unlock()
+	d.inFlight.Done()
+}
+
+func (d *dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.initialBackoff
+	for i := 1; i < attempts && backoff < d.maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, d.maxBackoff)
+}
+
+// post sends n once. retry reports whether a failure is worth another
+// attempt: transport errors, 5xx, 408 and 429 are; other responses are not.
+func (d *dispatcher) post(n *notification) (retry bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), callbackRequestTimeout)
+	defer cancel()
+	httpResponse, err := postCallbackJSON(ctx, d.client, n.uri, n.callbackType, n.payload)
+	if err != nil {
+		return true, err
+	}
+	closeCallbackResponseBody(httpResponse)
+	switch code := httpResponse.StatusCode; {
+	case code < http.StatusMultipleChoices:
+		return false, nil
+	case code >= http.StatusInternalServerError, code == http.StatusRequestTimeout,
+		code == http.StatusTooManyRequests:
+		return true, fmt.Errorf("callback %s returned %s", n.uri, httpResponse.Status)
+	default:
+		return false, fmt.Errorf("callback %s returned %s", n.uri, httpResponse.Status)
+	}
+}
+
+// wait blocks until every accepted task has run and every accepted
+// notification has been delivered or dead-lettered.
+func (d *dispatcher) wait() {
+	d.inFlight.Wait()
+}
+
+func destinationOf(uri string) string {
+	if parsed, err := url.Parse(uri); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	return uri
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package callback
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type deadLetters struct {
+	mtx     sync.Mutex
+	reasons []string
+}
+
+func (d *deadLetters) record(_ *notification, reason string) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.reasons = append(d.reasons, reason)
+}
+
+func (d *deadLetters) get() []string {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return append([]string(nil), d.reasons...)
+}
+
+func newTestDispatcher(queueSize, maxPerDestination int, dropped *deadLetters) *dispatcher {
+	d := newDispatcher(queueSize, 8, maxPerDestination, 3, time.Millisecond, 10*time.Millisecond)
+	d.deadLetter = dropped.record
+	return d
+}
+
+func TestDispatcherRetriesUntilDelivered(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	dropped := &deadLetters{}
+	d := newTestDispatcher(8, 1, dropped)
+	d.enqueue(server.URL, dataChangeCallback, map[string]string{"ueId": "imsi-001010000000001"})
+	d.wait()
+
+	if got := hits.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	if reasons := dropped.get(); len(reasons) != 0 {
+		t.Errorf("expected nothing dead-lettered, got %v", reasons)
+	}
+}
+
+func TestDispatcherDeadLettersAfterLastAttempt(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	dropped := &deadLetters{}
+	d := newTestDispatcher(8, 1, dropped)
+	d.enqueue(server.URL, dataChangeCallback, map[string]string{})
+	d.wait()
+
+	if got := hits.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	if reasons := dropped.get(); len(reasons) != 1 {
+		t.Errorf("expected the notification to be dead-lettered once, got %v", reasons)
+	}
+}
+
+func TestDispatcherDoesNotRetryClientErrors(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	dropped := &deadLetters{}
+	d := newTestDispatcher(8, 1, dropped)
+	d.enqueue(server.URL, dataChangeCallback, map[string]string{})
+	d.wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+	if reasons := dropped.get(); len(reasons) != 1 {
+		t.Errorf("expected the notification to be dead-lettered once, got %v", reasons)
+	}
+}
+
+func TestDispatcherLimitsConcurrencyPerDestination(t *testing.T) {
+	var active, peak atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		active.Add(-1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	dropped := &deadLetters{}
+	d := newTestDispatcher(16, 2, dropped)
+	for range 6 {
+		d.enqueue(server.URL, dataChangeCallback, map[string]string{})
+	}
+	// Give the workers time to pick everything up before letting requests
+	// complete, so that the limit is what holds the others back.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	d.wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("expected at most 2 concurrent deliveries, got %d", got)
+	}
+	if reasons := dropped.get(); len(reasons) != 0 {
+		t.Errorf("expected nothing dead-lettered, got %v", reasons)
+	}
+}
+
+func TestDispatcherDeadLettersWhenQueueIsFull(t *testing.T) {
+	dropped := &deadLetters{}
+	d := newTestDispatcher(1, 1, dropped)
+	// Keep the workers from starting so the first notification stays queued.
+	d.startOnce.Do(func() {})
+
+	d.enqueue("http://nf.example/notify", dataChangeCallback, map[string]string{})
+	d.enqueue("http://nf.example/notify", dataChangeCallback, map[string]string{})
+
+	if reasons := dropped.get(); len(reasons) != 1 || reasons[0] != "notification queue full" {
+		t.Errorf("expected the second notification to be dead-lettered, got %v", reasons)
+	}
+}
+
+func TestDispatcherRunsTasksOnBoundedPool(t *testing.T) {
+	dropped := &deadLetters{}
+	d := newTestDispatcher(8, 1, dropped)
+	d.taskWorkers = 2
+
+	var running, maxRunning, ran atomic.Int32
+	release := make(chan struct{})
+	for range 6 {
+		d.run("test", func(context.Context) {
+			current := running.Add(1)
+			for {
+				observed := maxRunning.Load()
+				if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			<-release
+			running.Add(-1)
+			ran.Add(1)
+		})
+	}
+	close(release)
+	d.wait()
+
+	if got := ran.Load(); got != 6 {
+		t.Errorf("expected 6 tasks to run, got %d", got)
+	}
+	if got := maxRunning.Load(); got > 2 {
+		t.Errorf("expected at most 2 tasks at once, got %d", got)
+	}
+}
+
+func TestDispatcherDropsTasksWhenQueueIsFull(t *testing.T) {
+	d := newTestDispatcher(1, 1, &deadLetters{})
+	d.tasks = make(chan func(), 1)
+	// Keep the workers from starting so the first task stays queued.
+	d.startOnce.Do(func() {})
+
+	d.run("first", func(context.Context) {})
+	d.run("second", func(context.Context) {})
+
+	if got := len(d.tasks); got != 1 {
+		t.Errorf("expected only the first task to be queued, got %d", got)
+	}
+}
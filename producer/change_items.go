@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/udr/util"
+)
+
+// changeItems describes how a document changed from origValue to newValue as
+// one ChangeItem per JSON pointer whose value differs, each carrying only the
+// old and new value at its own path. Nested objects and arrays are compared
+// element by element, so a patch that touches one attribute yields one
+// ChangeItem for it rather than the whole document. A move shows up as a
+// removal at the old path and an addition at the new one. A nil origValue or
+// newValue reports the whole resource as added or removed.
+func changeItems(origValue interface{}, newValue interface{}) []models.ChangeItem {
+	switch {
+	case isNilDocument(origValue) && isNilDocument(newValue):
+		return nil
+	case isNilDocument(origValue):
+		change := models.NewChangeItem(models.CHANGETYPE_ADD, "")
+		change.SetNewValue(documentValue(newValue))
+		return []models.ChangeItem{*change}
+	case isNilDocument(newValue):
+		change := models.NewChangeItem(models.CHANGETYPE_REMOVE, "")
+		change.SetOrigValue(documentValue(origValue))
+		return []models.ChangeItem{*change}
+	}
+
+	var changes []models.ChangeItem
+	diffValues("", documentValue(origValue), documentValue(newValue), &changes)
+	return changes
+}
+
+func isNilDocument(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Map && v.IsNil()
+}
+
+// documentValue converts a document read from the DB into plain JSON values
+// and drops the DB identifier, which is not part of the resource.
+func documentValue(value interface{}) map[string]interface{} {
+	document := util.ToBsonM(value)
+	delete(document, "_id")
+	return document
+}
+
+func diffValues(path string, origValue interface{}, newValue interface{}, changes *[]models.ChangeItem) {
+	switch orig := origValue.(type) {
+	case map[string]interface{}:
+		if updated, ok := newValue.(map[string]interface{}); ok {
+			diffObjects(path, orig, updated, changes)
+			return
+		}
+	case []interface{}:
+		if updated, ok := newValue.([]interface{}); ok {
+			diffArrays(path, orig, updated, changes)
+			return
+		}
+	}
+	if !reflect.DeepEqual(origValue, newValue) {
+		change := models.NewChangeItem(models.CHANGETYPE_REPLACE, path)
+		change.SetOrigValue(origValue)
+		change.SetNewValue(newValue)
+		*changes = append(*changes, *change)
+	}
+}
+
+func diffObjects(path string, orig map[string]interface{}, updated map[string]interface{},
+	changes *[]models.ChangeItem,
+) {
+	keys := make([]string, 0, len(orig)+len(updated))
+	for key := range orig {
+		keys = append(keys, key)
+	}
+	for key := range updated {
+		if _, ok := orig[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		keyPath := path + "/" + escapeJSONPointer(key)
+		origValue, inOrig := orig[key]
+		newValue, inUpdated := updated[key]
+		switch {
+		case !inUpdated:
+			change := models.NewChangeItem(models.CHANGETYPE_REMOVE, keyPath)
+			change.SetOrigValue(origValue)
+			*changes = append(*changes, *change)
+		case !inOrig:
+			change := models.NewChangeItem(models.CHANGETYPE_ADD, keyPath)
+			change.SetNewValue(newValue)
+			*changes = append(*changes, *change)
+		default:
+			diffValues(keyPath, origValue, newValue, changes)
+		}
+	}
+}
+
+// diffArrays compares the elements both arrays have by index, then reports
+// trailing elements as added or removed. Removals are listed from the end so
+// that each path is still valid when the items are applied in order.
+func diffArrays(path string, orig []interface{}, updated []interface{}, changes *[]models.ChangeItem) {
+	common := min(len(orig), len(updated))
+	for i := range common {
+		diffValues(path+"/"+strconv.Itoa(i), orig[i], updated[i], changes)
+	}
+	for i := common; i < len(updated); i++ {
+		change := models.NewChangeItem(models.CHANGETYPE_ADD, path+"/"+strconv.Itoa(i))
+		change.SetNewValue(updated[i])
+		*changes = append(*changes, *change)
+	}
+	for i := len(orig) - 1; i >= common; i-- {
+		change := models.NewChangeItem(models.CHANGETYPE_REMOVE, path+"/"+strconv.Itoa(i))
+		change.SetOrigValue(orig[i])
+		*changes = append(*changes, *change)
+	}
+}
+
+// escapeJSONPointer escapes a key for use as a JSON pointer reference token
+// (RFC 6901 section 3).
+func escapeJSONPointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/omec-project/openapi/v2/models"
+)
+
+func TestChangeItemsReportsEachChangedPath(t *testing.T) {
+	origValue := map[string]interface{}{
+		"_id":           "65a0c0ffee",
+		"ueId":          "imsi-001010000000001",
+		"amfInstanceId": "amf-1",
+		"guami": map[string]interface{}{
+			"plmnId": map[string]interface{}{"mcc": "001", "mnc": "01"},
+			"amfId":  "cafe00",
+		},
+		"backupAmfInfo": []interface{}{
+			map[string]interface{}{"backupAmf": "amf-b1"},
+			map[string]interface{}{"backupAmf": "amf-b2"},
+		},
+		"rat/type": "NR",
+	}
+	newValue := map[string]interface{}{
+		"_id":           "65a0c0ffee",
+		"ueId":          "imsi-001010000000001",
+		"amfInstanceId": "amf-2",
+		"guami": map[string]interface{}{
+			"plmnId": map[string]interface{}{"mcc": "001", "mnc": "01"},
+			"amfId":  "cafe01",
+		},
+		"backupAmfInfo": []interface{}{
+			map[string]interface{}{"backupAmf": "amf-b1"},
+		},
+		"pei": "imeisv-4370816125816151",
+	}
+
+	type change struct {
+		op        models.ChangeType
+		path      string
+		origValue interface{}
+		newValue  interface{}
+	}
+	want := []change{
+		{models.CHANGETYPE_REPLACE, "/amfInstanceId", "amf-1", "amf-2"},
+		{models.CHANGETYPE_REMOVE, "/backupAmfInfo/1", map[string]interface{}{"backupAmf": "amf-b2"}, nil},
+		{models.CHANGETYPE_REPLACE, "/guami/amfId", "cafe00", "cafe01"},
+		{models.CHANGETYPE_ADD, "/pei", nil, "imeisv-4370816125816151"},
+		{models.CHANGETYPE_REMOVE, "/rat~1type", "NR", nil},
+	}
+
+	var got []change
+	for _, item := range changeItems(origValue, newValue) {
+		got = append(got, change{item.GetOp(), item.GetPath(), item.GetOrigValue(), item.GetNewValue()})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changeItems() =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestChangeItemsForWholeResource(t *testing.T) {
+	document := map[string]interface{}{"_id": "65a0c0ffee", "sharedDataId": "iot-profile"}
+
+	if changes := changeItems(document, document); len(changes) != 0 {
+		t.Errorf("expected no changes for an unchanged document, got %#v", changes)
+	}
+
+	changes := changeItems(document, nil)
+	if len(changes) != 1 || changes[0].GetOp() != models.CHANGETYPE_REMOVE || changes[0].GetPath() != "" {
+		t.Fatalf("expected a single removal of the resource, got %#v", changes)
+	}
+	if orig, _ := changes[0].GetOrigValue().(map[string]interface{}); orig["sharedDataId"] != "iot-profile" || orig["_id"] != nil {
+		t.Errorf("expected the removed resource without its DB identifier, got %#v", changes[0].GetOrigValue())
+	}
+}
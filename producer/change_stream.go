@@ -0,0 +1,318 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/omec-project/openapi/v2/models"
+	udr_context "github.com/omec-project/udr/context"
+	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/util"
+	"github.com/omec-project/util/mongoapi"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// provisionedDataCollPrefix selects the collections written by the
+// provisioning system (webui, simapp) directly in MongoDB. The UDR writes a
+// few of them too: time sync, user consent and coverage restriction data,
+// and bulk writes on the admin listener. None of those writes notifies
+// subscribers itself, so the change stream is what notifies every change to
+// provisioned data, whoever made it.
+const provisionedDataCollPrefix = "subscriptionData.provisionedData."
+
+const (
+	changeStreamInitialBackoff = time.Second
+	changeStreamMaxBackoff     = time.Minute
+
+	// Server error codes: change streams need a replica set or sharded
+	// cluster, and servers older than 6.0 reject fullDocumentBeforeChange.
+	errCodeChangeStreamNotSupported = 40573
+	errCodeUnknownField             = 40415
+	// The resume token is no longer in the oplog, or cannot be used.
+	errCodeChangeStreamFatalError  = 280
+	errCodeChangeStreamHistoryLost = 286
+
+	// provisionedDataWatcherLease elects the replica following the stream.
+	provisionedDataWatcherLease = "provisionedDataWatcher"
+	// changeStreamCheckpointInterval bounds how often the resume token is
+	// stored with the lease, and so the events a new holder sees twice.
+	changeStreamCheckpointInterval = 5 * time.Second
+)
+
+// provisionedDataChange is the part of a change stream event the watcher
+// uses.
+type provisionedDataChange struct {
+	OperationType string `bson:"operationType"`
+	Ns            struct {
+		Coll string `bson:"coll"`
+	} `bson:"ns"`
+	FullDocument             map[string]interface{} `bson:"fullDocument"`
+	FullDocumentBeforeChange map[string]interface{} `bson:"fullDocumentBeforeChange"`
+	UpdateDescription        *struct {
+		UpdatedFields map[string]interface{} `bson:"updatedFields"`
+		RemovedFields []string               `bson:"removedFields"`
+	} `bson:"updateDescription"`
+}
+
+// commonMongoClient is the client behind CommonDBClient, kept for the
+// change stream, which DBInterface does not expose.
+var commonMongoClient *mongoapi.MongoClient
+
+// StartProvisionedDataWatcher follows a MongoDB change stream on the
+// provisioned data collections until ctx is cancelled. Every insert, update,
+// replace or delete invalidates the cached copies of the document on every
+// replica and is reported to the UE's data change subscribers, as if it had
+// been written through the UDR. Shared data is followed too, to keep its
+// cached copies current.
+//
+// Only the replica holding the provisionedDataWatcherLease follows the
+// stream, so that each change is notified once. It stores its resume token
+// with the lease, and a replica taking the lease over resumes from there, so
+// no event is missed while the server keeps it in its oplog. Events since
+// the last checkpoint may be notified twice on a takeover.
+func StartProvisionedDataWatcher(ctx context.Context) {
+	if commonMongoClient == nil {
+		return
+	}
+	l := newLease(commonMongoClient, provisionedDataWatcherLease)
+	runAsLeader(ctx, provisionedDataWatcherLease, l, func(ctx context.Context) {
+		followProvisionedData(ctx, l)
+	})
+}
+
+// followProvisionedData follows the change stream from the checkpoint of l
+// until ctx is cancelled.
+func followProvisionedData(ctx context.Context, l *lease) {
+	database := commonMongoClient.GetCollection(SUBSCDATA_SHAREDDATA).Database()
+
+	resumeToken, err := l.lastCheckpoint(ctx)
+	if err != nil {
+		logger.DataRepoLog.Warnf("read provisioned data change stream checkpoint failed: %+v", err)
+	}
+	preImages := true
+	backoff := changeStreamInitialBackoff
+	for {
+		opened, err := watchProvisionedData(ctx, database, preImages, &resumeToken, l.checkpoint)
+		if ctx.Err() != nil {
+			return
+		}
+		var serverErr mongo.ServerError
+		switch {
+		case resumeToken != nil && errors.As(err, &serverErr) &&
+			(serverErr.HasErrorCode(errCodeChangeStreamHistoryLost) || serverErr.HasErrorCode(errCodeChangeStreamFatalError)):
+			// The changes since the token are gone, so neither their
+			// notifications nor which cached entries they made stale can be
+			// recovered. Drop every cached entry and start from now.
+			logger.DataRepoLog.Warnf("provisioned data changes were lost from the oplog, "+
+				"dropping cached data and watching from now: %+v", err)
+			resumeToken = nil
+			if err := l.checkpoint(ctx, nil); err != nil {
+				logger.DataRepoLog.Warnln(err)
+			}
+			flushCachedEverywhere()
+			continue
+		case errors.As(err, &serverErr) && serverErr.HasErrorCode(errCodeChangeStreamNotSupported):
+			logger.DataRepoLog.Warnln("MongoDB does not support change streams; " +
+				"provisioned data written outside the UDR will not be notified")
+			return
+		case preImages && errors.As(err, &serverErr) && serverErr.HasErrorCode(errCodeUnknownField):
+			logger.DataRepoLog.Infoln("MongoDB does not provide pre-images; watching provisioned data without them")
+			preImages = false
+			continue
+		}
+		if opened {
+			backoff = changeStreamInitialBackoff
+		}
+		logger.DataRepoLog.Warnf("provisioned data change stream failed, retrying in %v: %+v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, changeStreamMaxBackoff)
+	}
+}
+
+// watchProvisionedData consumes one change stream until it fails, passing
+// the resume token to checkpoint at most every changeStreamCheckpointInterval.
+// opened reports whether the stream was established.
+func watchProvisionedData(ctx context.Context, database *mongo.Database, preImages bool,
+	resumeToken *bson.Raw, checkpoint func(ctx context.Context, token bson.Raw) error,
+) (opened bool, err error) {
+	pipeline := mongo.Pipeline{bson.D{{Key: "$match", Value: bson.M{
+		"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}},
+		"$or": bson.A{
+			bson.M{"ns.coll": bson.M{"$regex": "^" + strings.ReplaceAll(provisionedDataCollPrefix, ".", `\.`)}},
+			bson.M{"ns.coll": SUBSCDATA_SHAREDDATA},
+		},
+	}}}}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if preImages {
+		opts.SetFullDocumentBeforeChange(options.WhenAvailable)
+	}
+	if *resumeToken != nil {
+		opts.SetResumeAfter(*resumeToken)
+	}
+
+	stream, err := database.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if errClose := stream.Close(context.Background()); errClose != nil {
+			logger.DataRepoLog.Debugln(errClose)
+		}
+	}()
+	logger.DataRepoLog.Infoln("watching provisioned data for changes")
+
+	var checkpointed time.Time
+	for stream.Next(ctx) {
+		var change provisionedDataChange
+		if err := stream.Decode(&change); err != nil {
+			logger.DataRepoLog.Warnln(err)
+		} else {
+			handleProvisionedDataChange(change)
+		}
+		*resumeToken = stream.ResumeToken()
+		if time.Since(checkpointed) >= changeStreamCheckpointInterval {
+			if err := checkpoint(ctx, *resumeToken); err != nil {
+				logger.DataRepoLog.Warnf("store provisioned data change stream checkpoint failed: %+v", err)
+			}
+			checkpointed = time.Now()
+		}
+	}
+	return true, stream.Err()
+}
+
+// handleProvisionedDataChange invalidates the cached copies of the changed
+// document and notifies the UE's data change subscribers. Without a
+// pre-image, an update is described from the fields it set and removed, and
+// a delete cannot be attributed to a UE, so only the cache is cleared, as it
+// is for shared data and for collections that back no known resource.
+func handleProvisionedDataChange(change provisionedDataChange) {
+	collName := change.Ns.Coll
+
+	document := change.FullDocument
+	if document == nil {
+		document = change.FullDocumentBeforeChange
+	}
+	ueId := documentString(document, "ueId")
+	invalidateCached(collName, bson.M{"ueId": ueId, "sharedDataId": documentString(document, "sharedDataId")})
+	if ueId == "" {
+		return
+	}
+	resourceUri, ok := provisionedDataResourceUri(collName, ueId, documentString(document, "servingPlmnId"))
+	if !ok {
+		logger.DataRepoLog.Warnf("no resource is known for collection %s, change not notified", collName)
+		return
+	}
+
+	var changes []models.ChangeItem
+	switch change.OperationType {
+	case "insert":
+		changes = changeItems(nil, change.FullDocument)
+	case "delete":
+		changes = changeItems(change.FullDocumentBeforeChange, nil)
+	case "update", "replace":
+		if change.FullDocumentBeforeChange != nil || change.UpdateDescription == nil {
+			changes = changeItems(change.FullDocumentBeforeChange, change.FullDocument)
+		} else {
+			changes = updateDescriptionChangeItems(change.UpdateDescription.UpdatedFields,
+				change.UpdateDescription.RemovedFields)
+		}
+	}
+	notifyDataChange(ueId, resourceUri, changes)
+}
+
+// updateDescriptionChangeItems turns the dotted field paths of an update
+// event into change items. The values before the update are not known.
+func updateDescriptionChangeItems(updatedFields map[string]interface{}, removedFields []string) []models.ChangeItem {
+	var changes []models.ChangeItem
+	updated := util.ToBsonM(updatedFields)
+	for _, field := range slices.Sorted(maps.Keys(updated)) {
+		change := models.NewChangeItem(models.CHANGETYPE_REPLACE, dottedPathToJSONPointer(field))
+		change.SetNewValue(updated[field])
+		changes = append(changes, *change)
+	}
+	for _, field := range removedFields {
+		changes = append(changes, *models.NewChangeItem(models.CHANGETYPE_REMOVE, dottedPathToJSONPointer(field)))
+	}
+	return changes
+}
+
+func dottedPathToJSONPointer(field string) string {
+	var pointer strings.Builder
+	for _, segment := range strings.Split(field, ".") {
+		pointer.WriteString("/" + escapeJSONPointer(segment))
+	}
+	return pointer.String()
+}
+
+// provisionedDataResources maps each provisioned data collection onto the
+// path of the resource it backs, relative to /subscription-data/{ueId}. The
+// data sets served per serving PLMN sit below {servingPlmnId}/provisioned-data,
+// the others directly below the UE.
+var provisionedDataResources = map[string]struct {
+	path           string
+	perServingPlmn bool
+}{
+	provisionedDataCollPrefix + "amData":                       {"am-data", true},
+	provisionedDataCollPrefix + "smData":                       {"sm-data", true},
+	provisionedDataCollPrefix + "smfSelectionSubscriptionData": {"smf-selection-subscription-data", true},
+	provisionedDataCollPrefix + "smsData":                      {"sms-data", true},
+	provisionedDataCollPrefix + "smsMngData":                   {"sms-mng-data", true},
+	provisionedDataCollPrefix + "traceData":                    {"trace-data", true},
+	SUBSCDATA_PROVDATA_LCS_BCA:                                 {"lcs-bca-data", true},
+	SUBSCDATA_PROVDATA_LCS_PRIVACY:                             {"lcs-privacy-data", false},
+	SUBSCDATA_PROVDATA_LCS_MO:                                  {"lcs-mo-data", false},
+	SUBSCDATA_PROVDATA_LCS_SUBSCRIPTION:                        {"lcs-subscription-data", false},
+	SUBSCDATA_PROVDATA_V2X:                                     {"v2x-data", false},
+	SUBSCDATA_PROVDATA_A2X:                                     {"a2x-data", false},
+	SUBSCDATA_PROVDATA_PROSE:                                   {"prose-data", false},
+	SUBSCDATA_PROVDATA_RANGING_SLPOS:                           {"ranging-slpos-data", false},
+	SUBSCDATA_PROVDATA_TIME_SYNC:                               {"time-sync-data", false},
+	SUBSCDATA_PROVDATA_USER_CONSENT:                            {"uc-data", false},
+	SUBSCDATA_PROVDATA_COVERAGE_RESTRICTION:                    {"coverage-restriction-data", false},
+}
+
+// provisionedDataResourceUri returns the URI of the resource backed by
+// collName, e.g. .../{servingPlmnId}/provisioned-data/am-data for amData. ok
+// is false if no resource is known for collName.
+func provisionedDataResourceUri(collName string, ueId string, servingPlmnId string) (uri string, ok bool) {
+	resource, ok := provisionedDataResources[collName]
+	if !ok {
+		return "", false
+	}
+	uri = fmt.Sprintf("%s/subscription-data/%s/", udr_context.UDR_Self().GetIPv4GroupUri(udr_context.NUDR_DR), ueId)
+	if resource.perServingPlmn {
+		uri += servingPlmnId + "/provisioned-data/"
+	}
+	return uri + resource.path, true
+}
+
+// invalidateCached drops the cached entries of the UE or shared data set
+// named by filter, or of the whole collection when it names neither, here
+// and on the other replicas.
+func invalidateCached(collName string, filter bson.M) {
+	if cached, ok := CommonDBClient.(*cachedDBClient); ok {
+		cached.written(collName, filter)
+	}
+}
+
+// flushCachedEverywhere drops every cached entry of the common database,
+// here and on the other replicas.
+func flushCachedEverywhere() {
+	if cached, ok := CommonDBClient.(*cachedDBClient); ok {
+		cached.flushEverywhere()
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omec-project/openapi/v2/models"
+	udr_context "github.com/omec-project/udr/context"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestProvisionedDataChangeInvalidatesCacheAndNotifies(t *testing.T) {
+	notifications := make(chan models.DataChangeNotify, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var dataChangeNotify models.DataChangeNotify
+		if err := json.Unmarshal(body, &dataChangeNotify); err != nil {
+			t.Errorf("unmarshal notification: %v", err)
+		}
+		notifications <- dataChangeNotify
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := newMemDB()
+	cached := newCachedDBClient(db)
+	CommonDBClient = cached
+
+	udrSelf := udr_context.UDR_Self()
+	savedSubscriptions := udrSelf.SubscriptionDataSubscriptions
+	t.Cleanup(func() { udrSelf.SubscriptionDataSubscriptions = savedSubscriptions })
+	udrSelf.SubscriptionDataSubscriptions = make(map[string]*models.SubscriptionDataSubscriptions)
+
+	const ueId = "imsi-001010000000204"
+	const servingPlmnId = "00101"
+	const collName = "subscriptionData.provisionedData.amData"
+	subscription := models.NewSubscriptionDataSubscriptions(server.URL+"/notify", []string{})
+	subscription.SetUeId(ueId)
+	if _, pd := PostSubscriptionDataSubscriptionsProcedure(context.Background(), *subscription); pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+
+	filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
+	amData := map[string]any{
+		"ueId": ueId, "servingPlmnId": servingPlmnId,
+		"subscribedUeAmbr": map[string]any{"uplink": "1 Gbps", "downlink": "2 Gbps"},
+	}
+	if _, err := db.RestfulAPIPutOne(context.Background(), collName, filter, amData); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cached.RestfulAPIGetOne(context.Background(), collName, filter); err != nil {
+		t.Fatal(err)
+	}
+
+	// The provisioning system rewrites the document behind the UDR's back.
+	updated := map[string]any{
+		"ueId": ueId, "servingPlmnId": servingPlmnId,
+		"subscribedUeAmbr": map[string]any{"uplink": "500 Mbps", "downlink": "2 Gbps"},
+	}
+	if _, err := db.RestfulAPIPutOne(context.Background(), collName, filter, updated); err != nil {
+		t.Fatal(err)
+	}
+	change := provisionedDataChange{OperationType: "update", FullDocument: updated}
+	change.Ns.Coll = collName
+	change.UpdateDescription = &struct {
+		UpdatedFields map[string]interface{} `bson:"updatedFields"`
+		RemovedFields []string               `bson:"removedFields"`
+	}{UpdatedFields: map[string]interface{}{"subscribedUeAmbr.uplink": "500 Mbps"}}
+
+	handleProvisionedDataChange(change)
+
+	document, err := cached.RestfulAPIGetOne(context.Background(), collName, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ambr, _ := document["subscribedUeAmbr"].(map[string]any); ambr["uplink"] != "500 Mbps" {
+		t.Errorf("expected the cached document to be invalidated, got %v", document)
+	}
+
+	select {
+	case dataChangeNotify := <-notifications:
+		items := dataChangeNotify.GetNotifyItems()
+		if len(items) != 1 || !strings.HasSuffix(items[0].GetResourceId(),
+			"/subscription-data/"+ueId+"/"+servingPlmnId+"/provisioned-data/am-data") {
+			t.Fatalf("unexpected notify items %#v", items)
+		}
+		changes := items[0].GetChanges()
+		if len(changes) != 1 || changes[0].GetPath() != "/subscribedUeAmbr/uplink" ||
+			changes[0].GetNewValue() != "500 Mbps" {
+			t.Errorf("unexpected changes %#v", changes)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the data change notification")
+	}
+}
+
+func TestProvisionedDataResourceUri(t *testing.T) {
+	const ueId = "imsi-001010000000205"
+	const servingPlmnId = "00101"
+	base := udr_context.UDR_Self().GetIPv4GroupUri(udr_context.NUDR_DR) + "/subscription-data/" + ueId
+	for collName, want := range map[string]string{
+		"subscriptionData.provisionedData.smfSelectionSubscriptionData": base + "/" + servingPlmnId +
+			"/provisioned-data/smf-selection-subscription-data",
+		SUBSCDATA_PROVDATA_LCS_BCA:     base + "/" + servingPlmnId + "/provisioned-data/lcs-bca-data",
+		SUBSCDATA_PROVDATA_LCS_PRIVACY: base + "/lcs-privacy-data",
+		SUBSCDATA_PROVDATA_V2X:         base + "/v2x-data",
+	} {
+		if got, ok := provisionedDataResourceUri(collName, ueId, servingPlmnId); !ok || got != want {
+			t.Errorf("%s: expected %q, got %q", collName, want, got)
+		}
+	}
+	if got, ok := provisionedDataResourceUri("subscriptionData.provisionedData.unknownData", ueId, servingPlmnId); ok {
+		t.Errorf("expected no resource for an unknown collection, got %q", got)
+	}
+}
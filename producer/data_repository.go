@@ -5,12 +5,16 @@
 package producer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/go-viper/mapstructure/v2"
@@ -20,6 +24,7 @@ import (
 	udr_context "github.com/omec-project/udr/context"
 	"github.com/omec-project/udr/logger"
 	stats "github.com/omec-project/udr/metrics"
+	"github.com/omec-project/udr/producer/callback"
 	"github.com/omec-project/udr/util"
 	"github.com/omec-project/util/httpwrapper"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -29,15 +34,36 @@ const (
 	APPDATA_INFLUDATA_DB_COLLECTION_NAME       = "applicationData.influenceData"
 	APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME = "applicationData.influenceData.subsToNotify"
 	APPDATA_PFD_DB_COLLECTION_NAME             = "applicationData.pfds"
+	APPDATA_IPTVCONFIGDATA_DB_COLLECTION_NAME  = "applicationData.iptvConfigData"
+	APPDATA_SVCPARAMDATA_DB_COLLECTION_NAME    = "applicationData.serviceParamData"
 	POLICYDATA_BDTDATA                         = "policyData.bdtData"
 	POLICYDATA_UES_OPSPECDATA                  = "policyData.ues.operatorSpecificData"
 	POLICYDATA_UES_SMDATA_USAGEMONDATA         = "policyData.ues.smData.usageMonData"
 	POLICYDATA_UES_UEPOLICYSET                 = "policyData.ues.uePolicySet"
 	SUBSCDATA_CTXDATA_AMF_3GPPACCESS           = "subscriptionData.contextData.amf3gppAccess"
+	SUBSCDATA_RESTORATION_TARGETS              = "subscriptionData.subsToNotify.restorationTargets"
 	SUBSCDATA_CTXDATA_AMF_NON3GPPACCESS        = "subscriptionData.contextData.amfNon3gppAccess"
 	SUBSCDATA_CTXDATA_SMF_REGISTRATION         = "subscriptionData.contextData.smfRegistrations"
 	SUBSCDATA_CTXDATA_SMSF_3GPPACCESS          = "subscriptionData.contextData.smsf3gppAccess"
 	SUBSCDATA_CTXDATA_SMSF_NON3GPPACCESS       = "subscriptionData.contextData.smsfNon3gppAccess"
+	SUBSCDATA_CTXDATA_LOCATION                 = "subscriptionData.contextData.location"
+	SUBSCDATA_CTXDATA_ROAMING_INFORMATION      = "subscriptionData.contextData.roamingInformation"
+	SUBSCDATA_CTXDATA_PEI_INFO                 = "subscriptionData.contextData.peiInfo"
+	SUBSCDATA_PROVDATA_LCS_PRIVACY             = "subscriptionData.provisionedData.lcsPrivacyData"
+	SUBSCDATA_PROVDATA_LCS_MO                  = "subscriptionData.provisionedData.lcsMoData"
+	SUBSCDATA_PROVDATA_LCS_BCA                 = "subscriptionData.provisionedData.lcsBcaData"
+	SUBSCDATA_PROVDATA_LCS_SUBSCRIPTION        = "subscriptionData.provisionedData.lcsSubscriptionData"
+	SUBSCDATA_PROVDATA_V2X                     = "subscriptionData.provisionedData.v2xData"
+	SUBSCDATA_PROVDATA_A2X                     = "subscriptionData.provisionedData.a2xData"
+	SUBSCDATA_PROVDATA_PROSE                   = "subscriptionData.provisionedData.proseData"
+	SUBSCDATA_PROVDATA_RANGING_SLPOS           = "subscriptionData.provisionedData.rangingSlPosData"
+	SUBSCDATA_PROVDATA_TIME_SYNC               = "subscriptionData.provisionedData.timeSyncData"
+	SUBSCDATA_PROVDATA_USER_CONSENT            = "subscriptionData.provisionedData.ucData"
+	SUBSCDATA_PROVDATA_COVERAGE_RESTRICTION    = "subscriptionData.provisionedData.coverageRestrictionData"
+	SUBSCDATA_GROUPDATA_MEMBERSHIP             = "subscriptionData.groupData.groupMembership"
+	SUBSCDATA_SHAREDDATA                       = "subscriptionData.sharedData"
+	EXPOSUREDATA_SESSION_MANAGEMENT            = "exposureData.sessionManagementData"
+	EXPOSUREDATA_ACCESS_AND_MOBILITY           = "exposureData.accessAndMobilityData"
 
 	SUBSCDATA_AUTHDATA_AUTHSTATUS = "subscriptionData.authenticationData.authenticationStatus"
 	AccessTypeAMF3GPP             = "amf-3gpp-access"
@@ -52,6 +78,7 @@ const (
 	PLMNUEPolicySet               = "plmn-ue-policy-set"
 	SponsorConnectivityData       = "sponsor-connectivity-data"
 	SubsToNotify                  = "subs-to-notify"
+	DataRestoration               = "data-restoration"
 	AMData                        = "am-data"
 	OperatorSpecificData          = "operator-specific-data"
 	SMData                        = "sm-data"
@@ -72,12 +99,45 @@ const (
 	SMSManagementData             = "sms-mng-data"
 	SMSData                       = "sms-data"
 	TraceData                     = "trace-data"
+	LCSPrivacyData                = "lcs-privacy-data"
+	LCSMOData                     = "lcs-mo-data"
+	LCSBCAData                    = "lcs-bca-data"
+	LCSSubscriptionData           = "lcs-subscription-data"
+	V2XData                       = "v2x-data"
+	A2XData                       = "a2x-data"
+	ProSeData                     = "prose-data"
+	RangingSlPosData              = "ranging-slpos-data"
+	SessionManagementData         = "session-management-data"
+	AccessAndMobilityData         = "access-and-mobility-data"
+	UELocation                    = "location"
+	RoamingInformation            = "roaming-information"
+	PEIInformation                = "pei-info"
+	TimeSyncData                  = "time-sync-data"
+	UserConsentData               = "uc-data"
+	CoverageRestrictionData       = "coverage-restriction-data"
+	GroupIdentifiers              = "group-identifiers"
+	IPTVConfigData                = "iptv-config-data"
+	ServiceParamData              = "service-param-data"
 )
 
+// sidelinkDataSets maps the dataset-names values of the provisioned-data
+// resource onto the collection each dataset is stored in and the attribute
+// it is returned under in ProvisionedDataSets.
+var sidelinkDataSets = []struct {
+	dataSetName string
+	attribute   string
+	collName    string
+}{
+	{"V2X", "v2xData", SUBSCDATA_PROVDATA_V2X},
+	{"PROSE", "proseData", SUBSCDATA_PROVDATA_PROSE},
+	{"A2X", "a2xData", SUBSCDATA_PROVDATA_A2X},
+	{"RANGING_SL_POS", "rangingSlPosData", SUBSCDATA_PROVDATA_RANGING_SLPOS},
+}
+
 var CurrentResourceUri string
 
-func getDataFromDB(collName string, filter bson.M) (map[string]interface{}, *models.ProblemDetails) {
-	data, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+func getDataFromDB(ctx context.Context, collName string, filter bson.M) (map[string]interface{}, *models.ProblemDetails) {
+	data, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -90,21 +150,21 @@ func getDataFromDB(collName string, filter bson.M) (map[string]interface{}, *mod
 	return data, nil
 }
 
-func deleteDataFromDB(collName string, filter bson.M) error {
-	errDelOne := CommonDBClient.RestfulAPIDeleteOne(collName, filter)
+func deleteDataFromDB(ctx context.Context, collName string, filter bson.M) error {
+	errDelOne := CommonDBClient.RestfulAPIDeleteOne(ctx, collName, filter)
 	if errDelOne != nil {
 		logger.DataRepoLog.Warnln(errDelOne)
 	}
 	return errDelOne
 }
 
-func HandleQueryAmData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryAmData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryAmData")
 
 	collName := "subscriptionData.provisionedData.amData"
 	ueId := request.Params["ueId"]
 	servingPlmnId := request.Params["servingPlmnId"]
-	response, problemDetails := QueryAmDataProcedure(collName, ueId, servingPlmnId)
+	response, problemDetails := QueryAmDataProcedure(ctx, collName, ueId, servingPlmnId)
 
 	if problemDetails == nil {
 		return httpwrapper.NewResponse(http.StatusOK, nil, response)
@@ -112,11 +172,11 @@ func HandleQueryAmData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func QueryAmDataProcedure(collName string, ueId string, servingPlmnId string) (*map[string]interface{},
+func QueryAmDataProcedure(ctx context.Context, collName string, ueId string, servingPlmnId string) (*map[string]interface{},
 	*models.ProblemDetails,
 ) {
 	filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
-	accessAndMobilitySubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	accessAndMobilitySubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -126,13 +186,13 @@ func QueryAmDataProcedure(collName string, ueId string, servingPlmnId string) (*
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleAmfContext3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleAmfContext3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle AmfContext3gpp")
 	collName := SUBSCDATA_CTXDATA_AMF_3GPPACCESS
 	patchItem := request.Body.([]models.PatchItem)
 	ueId := request.Params["ueId"]
 
-	problemDetails := AmfContext3gppProcedure(collName, ueId, patchItem)
+	problemDetails := AmfContext3gppProcedure(ctx, collName, ueId, patchItem)
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("update", AccessTypeAMF3GPP, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
@@ -141,9 +201,9 @@ func HandleAmfContext3gpp(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func AmfContext3gppProcedure(collName string, ueId string, patchItem []models.PatchItem) *models.ProblemDetails {
+func AmfContext3gppProcedure(ctx context.Context, collName string, ueId string, patchItem []models.PatchItem) *models.ProblemDetails {
 	filter := bson.M{"ueId": ueId}
-	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -152,27 +212,27 @@ func AmfContext3gppProcedure(collName string, ueId string, patchItem []models.Pa
 	if err != nil {
 		logger.DataRepoLog.Errorln(err)
 	}
-	failure := CommonDBClient.RestfulAPIJSONPatch(collName, filter, patchJSON)
+	failure := CommonDBClient.RestfulAPIJSONPatch(ctx, collName, filter, patchJSON)
 
 	if failure == nil {
-		newValue, errGetOneNew := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		newValue, errGetOneNew := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOneNew != nil {
 			logger.DataRepoLog.Warnln(errGetOneNew)
 		}
-		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, patchItem, origValue, newValue)
+		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, origValue, newValue)
 		return nil
 	}
 	return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
 }
 
-func HandleCreateAmfContext3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateAmfContext3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateAmfContext3gpp")
 
 	Amf3GppAccessRegistration := request.Body.(models.Amf3GppAccessRegistration)
 	ueId := request.Params["ueId"]
 	collName := SUBSCDATA_CTXDATA_AMF_3GPPACCESS
 
-	err := CreateAmfContext3gppProcedure(collName, ueId, Amf3GppAccessRegistration)
+	err := CreateAmfContext3gppProcedure(ctx, collName, ueId, Amf3GppAccessRegistration)
 	if err == nil {
 		stats.IncrementUdrSubscriptionDataStats("create", AccessTypeAMF3GPP, "SUCCESS")
 	} else {
@@ -182,27 +242,27 @@ func HandleCreateAmfContext3gpp(request *httpwrapper.Request) *httpwrapper.Respo
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func CreateAmfContext3gppProcedure(collName string, ueId string,
+func CreateAmfContext3gppProcedure(ctx context.Context, collName string, ueId string,
 	Amf3GppAccessRegistration models.Amf3GppAccessRegistration,
 ) error {
 	filter := bson.M{"ueId": ueId}
 	putData := util.ToBsonM(Amf3GppAccessRegistration)
 	putData["ueId"] = ueId
 
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
 	return errPutOne
 }
 
-func HandleQueryAmfContext3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryAmfContext3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryAmfContext3gpp")
 
 	ueId := request.Params["ueId"]
 	collName := SUBSCDATA_CTXDATA_AMF_3GPPACCESS
 
-	response, problemDetails := QueryAmfContext3gppProcedure(collName, ueId)
+	response, problemDetails := QueryAmfContext3gppProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", AccessTypeAMF3GPP, "SUCCESS")
@@ -216,9 +276,9 @@ func HandleQueryAmfContext3gpp(request *httpwrapper.Request) *httpwrapper.Respon
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryAmfContext3gppProcedure(collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+func QueryAmfContext3gppProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
-	amf3GppAccessRegistration, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	amf3GppAccessRegistration, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -229,7 +289,7 @@ func QueryAmfContext3gppProcedure(collName string, ueId string) (*map[string]int
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleAmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleAmfContextNon3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle AmfContextNon3gpp")
 
 	ueId := request.Params["ueId"]
@@ -237,7 +297,7 @@ func HandleAmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Response
 	patchItem := request.Body.([]models.PatchItem)
 	filter := bson.M{"ueId": ueId}
 
-	problemDetails := AmfContextNon3gppProcedure(ueId, collName, patchItem, filter)
+	problemDetails := AmfContextNon3gppProcedure(ctx, ueId, collName, patchItem, filter)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("update", AccessTypeAMFNon3GPP, "SUCCESS")
@@ -247,10 +307,10 @@ func HandleAmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Response
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func AmfContextNon3gppProcedure(ueId string, collName string, patchItem []models.PatchItem,
+func AmfContextNon3gppProcedure(ctx context.Context, ueId string, collName string, patchItem []models.PatchItem,
 	filter bson.M,
 ) *models.ProblemDetails {
-	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -259,26 +319,26 @@ func AmfContextNon3gppProcedure(ueId string, collName string, patchItem []models
 	if err != nil {
 		logger.DataRepoLog.Error(err)
 	}
-	failure := CommonDBClient.RestfulAPIJSONPatch(collName, filter, patchJSON)
+	failure := CommonDBClient.RestfulAPIJSONPatch(ctx, collName, filter, patchJSON)
 	if failure == nil {
-		newValue, errGetOneNew := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		newValue, errGetOneNew := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOneNew != nil {
 			logger.DataRepoLog.Warnln(errGetOneNew)
 		}
-		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, patchItem, origValue, newValue)
+		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, origValue, newValue)
 		return nil
 	}
 	return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
 }
 
-func HandleCreateAmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateAmfContextNon3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateAmfContextNon3gpp")
 
 	AmfNon3GppAccessRegistration := request.Body.(models.AmfNon3GppAccessRegistration)
 	collName := SUBSCDATA_CTXDATA_AMF_NON3GPPACCESS
 	ueId := request.Params["ueId"]
 
-	err := CreateAmfContextNon3gppProcedure(AmfNon3GppAccessRegistration, collName, ueId)
+	err := CreateAmfContextNon3gppProcedure(ctx, AmfNon3GppAccessRegistration, collName, ueId)
 	if err == nil {
 		stats.IncrementUdrSubscriptionDataStats("create", AccessTypeAMFNon3GPP, "SUCCESS")
 	} else {
@@ -288,27 +348,27 @@ func HandleCreateAmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Re
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func CreateAmfContextNon3gppProcedure(AmfNon3GppAccessRegistration models.AmfNon3GppAccessRegistration,
+func CreateAmfContextNon3gppProcedure(ctx context.Context, AmfNon3GppAccessRegistration models.AmfNon3GppAccessRegistration,
 	collName string, ueId string,
 ) error {
 	putData := util.ToBsonM(AmfNon3GppAccessRegistration)
 	putData["ueId"] = ueId
 	filter := bson.M{"ueId": ueId}
 
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
 	return errPutOne
 }
 
-func HandleQueryAmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryAmfContextNon3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryAmfContextNon3gpp")
 
 	collName := SUBSCDATA_CTXDATA_AMF_NON3GPPACCESS
 	ueId := request.Params["ueId"]
 
-	response, problemDetails := QueryAmfContextNon3gppProcedure(collName, ueId)
+	response, problemDetails := QueryAmfContextNon3gppProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", AccessTypeAMFNon3GPP, "SUCCESS")
@@ -323,9 +383,9 @@ func HandleQueryAmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Res
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryAmfContextNon3gppProcedure(collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+func QueryAmfContextNon3gppProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
-	response, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	response, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -336,14 +396,14 @@ func QueryAmfContextNon3gppProcedure(collName string, ueId string) (*map[string]
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleModifyAuthentication(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleModifyAuthentication(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle ModifyAuthentication")
 
 	collName := "subscriptionData.authenticationData.authenticationSubscription"
 	ueId := request.Params["ueId"]
 	patchItem := request.Body.([]models.PatchItem)
 
-	problemDetails := ModifyAuthenticationProcedure(collName, ueId, patchItem)
+	problemDetails := ModifyAuthenticationProcedure(ctx, collName, ueId, patchItem)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("update", AuthenticationSubscription, "SUCCESS")
@@ -353,15 +413,15 @@ func HandleModifyAuthentication(request *httpwrapper.Request) *httpwrapper.Respo
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func ModifyAuthenticationProcedure(collName string, ueId string, patchItem []models.PatchItem) *models.ProblemDetails {
+func ModifyAuthenticationProcedure(ctx context.Context, collName string, ueId string, patchItem []models.PatchItem) *models.ProblemDetails {
 	filter := bson.M{"ueId": ueId}
-	origValue, errGetOne := AuthDBClient.RestfulAPIGetOne(collName, filter)
+	origValue, errGetOne := AuthDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
 	if sequenceNumber, ok := origValue["sequenceNumber"].(string); ok {
 		origValue["sequenceNumber"] = map[string]interface{}{"sqn": sequenceNumber}
-		if _, errPut := AuthDBClient.RestfulAPIPutOne(collName, filter, origValue); errPut != nil {
+		if _, errPut := AuthDBClient.RestfulAPIPutOne(ctx, collName, filter, origValue); errPut != nil {
 			logger.DataRepoLog.Warnln(errPut)
 		}
 	}
@@ -370,26 +430,26 @@ func ModifyAuthenticationProcedure(collName string, ueId string, patchItem []mod
 	if err != nil {
 		logger.DataRepoLog.Error(err)
 	}
-	failure := AuthDBClient.RestfulAPIJSONPatch(collName, filter, patchJSON)
+	failure := AuthDBClient.RestfulAPIJSONPatch(ctx, collName, filter, patchJSON)
 
 	if failure == nil {
-		newValue, errGetOneNew := AuthDBClient.RestfulAPIGetOne(collName, filter)
+		newValue, errGetOneNew := AuthDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOneNew != nil {
 			logger.DataRepoLog.Warnln(errGetOneNew)
 		}
-		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, patchItem, origValue, newValue)
+		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, origValue, newValue)
 		return nil
 	}
 	return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
 }
 
-func HandleQueryAuthSubsData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryAuthSubsData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryAuthSubsData")
 
 	collName := "subscriptionData.authenticationData.authenticationSubscription"
 	ueId := request.Params["ueId"]
 
-	response, problemDetails := QueryAuthSubsDataProcedure(collName, ueId)
+	response, problemDetails := QueryAuthSubsDataProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", AuthenticationSubscription, "SUCCESS")
@@ -404,10 +464,10 @@ func HandleQueryAuthSubsData(request *httpwrapper.Request) *httpwrapper.Response
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryAuthSubsDataProcedure(collName string, ueId string) (map[string]interface{}, *models.ProblemDetails) {
+func QueryAuthSubsDataProcedure(ctx context.Context, collName string, ueId string) (map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	authenticationSubscription, errGetOne := AuthDBClient.RestfulAPIGetOne(collName, filter)
+	authenticationSubscription, errGetOne := AuthDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -421,13 +481,13 @@ func QueryAuthSubsDataProcedure(collName string, ueId string) (map[string]interf
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleCreateAuthenticationSoR(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateAuthenticationSoR(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateAuthenticationSoR")
 	putData := util.ToBsonM(request.Body)
 	ueId := request.Params["ueId"]
 	collName := "subscriptionData.ueUpdateConfirmationData.sorData"
 
-	err := CreateAuthenticationSoRProcedure(collName, ueId, putData)
+	err := CreateAuthenticationSoRProcedure(ctx, collName, ueId, putData)
 	if err == nil {
 		stats.IncrementUdrSubscriptionDataStats("create", SORData, "SUCCESS")
 	} else {
@@ -437,24 +497,24 @@ func HandleCreateAuthenticationSoR(request *httpwrapper.Request) *httpwrapper.Re
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func CreateAuthenticationSoRProcedure(collName string, ueId string, putData bson.M) error {
+func CreateAuthenticationSoRProcedure(ctx context.Context, collName string, ueId string, putData bson.M) error {
 	filter := bson.M{"ueId": ueId}
 	putData["ueId"] = ueId
 
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
 	return errPutOne
 }
 
-func HandleQueryAuthSoR(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryAuthSoR(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryAuthSoR")
 
 	ueId := request.Params["ueId"]
 	collName := "subscriptionData.ueUpdateConfirmationData.sorData"
 
-	response, problemDetails := QueryAuthSoRProcedure(collName, ueId)
+	response, problemDetails := QueryAuthSoRProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", SORData, "SUCCESS")
@@ -469,10 +529,10 @@ func HandleQueryAuthSoR(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryAuthSoRProcedure(collName string, ueId string) (map[string]interface{}, *models.ProblemDetails) {
+func QueryAuthSoRProcedure(ctx context.Context, collName string, ueId string) (map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	sorData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	sorData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -483,14 +543,14 @@ func QueryAuthSoRProcedure(collName string, ueId string) (map[string]interface{}
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleCreateAuthenticationStatus(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateAuthenticationStatus(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateAuthenticationStatus")
 
 	putData := util.ToBsonM(request.Body)
 	ueId := request.Params["ueId"]
 	collName := SUBSCDATA_AUTHDATA_AUTHSTATUS
 
-	err := CreateAuthenticationStatusProcedure(collName, ueId, putData)
+	err := CreateAuthenticationStatusProcedure(ctx, collName, ueId, putData)
 	if err == nil {
 		stats.IncrementUdrSubscriptionDataStats("create", AuthenticationStatus, "SUCCESS")
 	} else {
@@ -500,24 +560,24 @@ func HandleCreateAuthenticationStatus(request *httpwrapper.Request) *httpwrapper
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func CreateAuthenticationStatusProcedure(collName string, ueId string, putData bson.M) error {
+func CreateAuthenticationStatusProcedure(ctx context.Context, collName string, ueId string, putData bson.M) error {
 	filter := bson.M{"ueId": ueId}
 	putData["ueId"] = ueId
 
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
 	return errPutOne
 }
 
-func HandleQueryAuthenticationStatus(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryAuthenticationStatus(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryAuthenticationStatus")
 
 	ueId := request.Params["ueId"]
 	collName := SUBSCDATA_AUTHDATA_AUTHSTATUS
 
-	response, problemDetails := QueryAuthenticationStatusProcedure(collName, ueId)
+	response, problemDetails := QueryAuthenticationStatusProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", AuthenticationStatus, "SUCCESS")
@@ -532,12 +592,12 @@ func HandleQueryAuthenticationStatus(request *httpwrapper.Request) *httpwrapper.
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryAuthenticationStatusProcedure(collName string, ueId string) (*map[string]interface{},
+func QueryAuthenticationStatusProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{},
 	*models.ProblemDetails,
 ) {
 	filter := bson.M{"ueId": ueId}
 
-	authEvent, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	authEvent, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -548,7 +608,7 @@ func QueryAuthenticationStatusProcedure(collName string, ueId string) (*map[stri
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleApplicationDataInfluenceDataGet(queryParams map[string][]string) *httpwrapper.Response {
+func HandleApplicationDataInfluenceDataGet(ctx context.Context, queryParams map[string][]string) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataInfluenceDataGet: queryParams=%#v", queryParams)
 
 	influIDs := queryParams["influence-Ids"]
@@ -562,17 +622,17 @@ func HandleApplicationDataInfluenceDataGet(queryParams map[string][]string) *htt
 		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 	}
 
-	response := getApplicationDataInfluenceDatafromDB(influIDs, dnns, snssais, intGroupIDs, supis)
+	response := getApplicationDataInfluenceDatafromDB(ctx, influIDs, dnns, snssais, intGroupIDs, supis)
 	stats.IncrementUdrApplicationDataStats("get", InfluenceData, "SUCCESS")
 
 	return httpwrapper.NewResponse(http.StatusOK, nil, response)
 }
 
-func getApplicationDataInfluenceDatafromDB(influIDs, dnns, snssais,
+func getApplicationDataInfluenceDatafromDB(ctx context.Context, influIDs, dnns, snssais,
 	intGroupIDs, supis []string,
 ) []map[string]interface{} {
 	filter := bson.M{}
-	allInfluDatas, errGetMany := CommonDBClient.RestfulAPIGetMany(APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter)
+	allInfluDatas, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter)
 	if errGetMany != nil {
 		logger.DataRepoLog.Warnln(errGetMany)
 	}
@@ -599,8 +659,11 @@ func filterDataByString(filterName string, filterValues []string,
 	}
 	var matchedDatas []map[string]interface{}
 	for _, data := range datas {
+		// Documents without the attribute (e.g. group-wide data carrying no
+		// SUPI) never match a filter on it.
+		value, _ := data[filterName].(string)
 		for _, v := range filterValues {
-			if data[filterName].(string) == v {
+			if value == v {
 				matchedDatas = append(matchedDatas, data)
 				break
 			}
@@ -617,9 +680,12 @@ func filterDataBySnssai(snssaiValues []string,
 	}
 	var matchedDatas []map[string]interface{}
 	for _, data := range datas {
+		snssai, ok := data["snssai"].(map[string]interface{})
+		if !ok {
+			continue
+		}
 		dataSnssai := models.NewSnssaiWithDefaults()
-		if err := json.Unmarshal(
-			util.MapToByte(data["snssai"].(map[string]interface{})), dataSnssai); err != nil {
+		if err := json.Unmarshal(util.MapToByte(snssai), dataSnssai); err != nil {
 			logger.DataRepoLog.Warnln(err)
 			continue
 		}
@@ -640,17 +706,17 @@ func filterDataBySnssai(snssaiValues []string,
 	return matchedDatas
 }
 
-func HandleApplicationDataInfluenceDataInfluenceIdDelete(influID string) *httpwrapper.Response {
+func HandleApplicationDataInfluenceDataInfluenceIdDelete(ctx context.Context, influID string) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataInfluenceDataInfluenceIdDelete: influID=%q", influID)
 
-	deleteApplicationDataIndividualInfluenceDataFromDB(influID)
+	deleteApplicationDataIndividualInfluenceDataFromDB(ctx, influID)
 
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func deleteApplicationDataIndividualInfluenceDataFromDB(influID string) {
+func deleteApplicationDataIndividualInfluenceDataFromDB(ctx context.Context, influID string) {
 	filter := bson.M{"influenceId": influID}
-	err := deleteDataFromDB(APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter)
+	err := deleteDataFromDB(ctx, APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter)
 	if err == nil {
 		stats.IncrementUdrApplicationDataStats("delete", InfluenceData, "SUCCESS")
 	} else {
@@ -658,23 +724,23 @@ func deleteApplicationDataIndividualInfluenceDataFromDB(influID string) {
 	}
 }
 
-func HandleApplicationDataInfluenceDataInfluenceIdPatch(influID string,
+func HandleApplicationDataInfluenceDataInfluenceIdPatch(ctx context.Context, influID string,
 	trInfluDataPatch *models.TrafficInfluDataPatch,
 ) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataInfluenceDataInfluenceIdPatch: influID=%q", influID)
 
-	response, status := patchApplicationDataIndividualInfluenceDataToDB(influID, trInfluDataPatch)
+	response, status := patchApplicationDataIndividualInfluenceDataToDB(ctx, influID, trInfluDataPatch)
 	stats.IncrementUdrApplicationDataStats("update", InfluenceData, "SUCCESS")
 
 	return httpwrapper.NewResponse(status, nil, response)
 }
 
-func patchApplicationDataIndividualInfluenceDataToDB(influID string,
+func patchApplicationDataIndividualInfluenceDataToDB(ctx context.Context, influID string,
 	trInfluDataPatch *models.TrafficInfluDataPatch,
 ) (bson.M, int) {
 	filter := bson.M{"influenceId": influID}
 
-	oldData, errGetOne := CommonDBClient.RestfulAPIGetOne(APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter)
+	oldData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -702,7 +768,7 @@ func patchApplicationDataIndividualInfluenceDataToDB(influID string,
 
 	// Add "influenceId" entry to DB
 	newData["influenceId"] = influID
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter, newData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter, newData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
@@ -712,17 +778,17 @@ func patchApplicationDataIndividualInfluenceDataToDB(influID string,
 	return newData, http.StatusOK
 }
 
-func HandleApplicationDataInfluenceDataInfluenceIdPut(influID string,
+func HandleApplicationDataInfluenceDataInfluenceIdPut(ctx context.Context, influID string,
 	trInfluData *models.TrafficInfluData,
 ) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataInfluenceDataInfluenceIdPut: influID=%q", influID)
 
-	response, status := putApplicationDataIndividualInfluenceDataToDB(influID, trInfluData)
+	response, status := putApplicationDataIndividualInfluenceDataToDB(ctx, influID, trInfluData)
 
 	return httpwrapper.NewResponse(status, nil, response)
 }
 
-func putApplicationDataIndividualInfluenceDataToDB(influID string,
+func putApplicationDataIndividualInfluenceDataToDB(ctx context.Context, influID string,
 	trInfluData *models.TrafficInfluData,
 ) (bson.M, int) {
 	filter := bson.M{"influenceId": influID}
@@ -730,7 +796,7 @@ func putApplicationDataIndividualInfluenceDataToDB(influID string,
 
 	// Add "influenceId" entry to DB
 	data["influenceId"] = influID
-	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter, data)
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, APPDATA_INFLUDATA_DB_COLLECTION_NAME, filter, data)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
@@ -743,7 +809,7 @@ func putApplicationDataIndividualInfluenceDataToDB(influID string,
 	return data, http.StatusCreated
 }
 
-func HandleApplicationDataInfluenceDataSubsToNotifyGet(queryParams map[string][]string) *httpwrapper.Response {
+func HandleApplicationDataInfluenceDataSubsToNotifyGet(ctx context.Context, queryParams map[string][]string) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataInfluenceDataSubsToNotifyGet: queryParams=%#v", queryParams)
 
 	dnn := queryParams["dnn"]
@@ -776,13 +842,13 @@ func HandleApplicationDataInfluenceDataSubsToNotifyGet(queryParams map[string][]
 		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 	}
 
-	response := getApplicationDataInfluenceDataSubsToNotifyfromDB(dnn, snssai, intGroupID, supi)
+	response := getApplicationDataInfluenceDataSubsToNotifyfromDB(ctx, dnn, snssai, intGroupID, supi)
 	stats.IncrementUdrApplicationDataStats("get", InfluenceDataNotify, "SUCCESS")
 
 	return httpwrapper.NewResponse(http.StatusOK, nil, response)
 }
 
-func getApplicationDataInfluenceDataSubsToNotifyfromDB(dnn, snssai, intGroupID,
+func getApplicationDataInfluenceDataSubsToNotifyfromDB(ctx context.Context, dnn, snssai, intGroupID,
 	supi []string,
 ) []map[string]interface{} {
 	filter := bson.M{}
@@ -795,7 +861,7 @@ func getApplicationDataInfluenceDataSubsToNotifyfromDB(dnn, snssai, intGroupID,
 	if len(supi) != 0 {
 		filter["supis"] = supi[0]
 	}
-	matchedSubs, errGetMany := CommonDBClient.RestfulAPIGetMany(APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter)
+	matchedSubs, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter)
 	if errGetMany != nil {
 		logger.DataRepoLog.Warnln(errGetMany)
 	}
@@ -838,12 +904,12 @@ func filterDataBySnssais(snssaiValue string,
 	return matchedDatas
 }
 
-func HandleApplicationDataInfluenceDataSubsToNotifyPost(trInfluSub *models.TrafficInfluSub) *httpwrapper.Response {
+func HandleApplicationDataInfluenceDataSubsToNotifyPost(ctx context.Context, trInfluSub *models.TrafficInfluSub) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle ApplicationDataInfluenceDataSubsToNotifyPost")
 	udrSelf := udr_context.UDR_Self()
 
 	newSubscID := strconv.FormatUint(udrSelf.NewAppDataInfluDataSubscriptionID(), 10)
-	response, status := postApplicationDataInfluenceDataSubsToNotifyToDB(newSubscID, trInfluSub)
+	response, status := postApplicationDataInfluenceDataSubsToNotifyToDB(ctx, newSubscID, trInfluSub)
 
 	/* Contains the URI of the newly created resource, according
 	   to the structure: {apiRoot}/application-data/influenceData/subs-to-notify/{subscID} */
@@ -855,7 +921,7 @@ func HandleApplicationDataInfluenceDataSubsToNotifyPost(trInfluSub *models.Traff
 	return httpwrapper.NewResponse(status, headers, response)
 }
 
-func postApplicationDataInfluenceDataSubsToNotifyToDB(subscID string,
+func postApplicationDataInfluenceDataSubsToNotifyToDB(ctx context.Context, subscID string,
 	trInfluSub *models.TrafficInfluSub,
 ) (bson.M, int) {
 	filter := bson.M{"subscriptionId": subscID}
@@ -863,7 +929,7 @@ func postApplicationDataInfluenceDataSubsToNotifyToDB(subscID string,
 
 	// Add "subscriptionId" entry to DB
 	data["subscriptionId"] = subscID
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter, data)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter, data)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
@@ -872,11 +938,11 @@ func postApplicationDataInfluenceDataSubsToNotifyToDB(subscID string,
 	return data, http.StatusCreated
 }
 
-func HandleApplicationDataInfluenceDataSubsToNotifySubscriptionIdDelete(subscID string) *httpwrapper.Response {
+func HandleApplicationDataInfluenceDataSubsToNotifySubscriptionIdDelete(ctx context.Context, subscID string) *httpwrapper.Response {
 	logger.DataRepoLog.Infof(
 		"handle ApplicationDataInfluenceDataSubsToNotifySubscriptionIdDelete: subscID=%q", subscID)
 
-	err := deleteApplicationDataIndividualInfluenceDataSubsToNotifyFromDB(subscID)
+	err := deleteApplicationDataIndividualInfluenceDataSubsToNotifyFromDB(ctx, subscID)
 	if err == nil {
 		stats.IncrementUdrApplicationDataStats("delete", InfluenceDataSubscription, "SUCCESS")
 	} else {
@@ -886,15 +952,15 @@ func HandleApplicationDataInfluenceDataSubsToNotifySubscriptionIdDelete(subscID
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func deleteApplicationDataIndividualInfluenceDataSubsToNotifyFromDB(subscID string) error {
+func deleteApplicationDataIndividualInfluenceDataSubsToNotifyFromDB(ctx context.Context, subscID string) error {
 	filter := bson.M{"subscriptionId": subscID}
-	return deleteDataFromDB(APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter)
+	return deleteDataFromDB(ctx, APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter)
 }
 
-func HandleApplicationDataInfluenceDataSubsToNotifySubscriptionIdGet(subscID string) *httpwrapper.Response {
+func HandleApplicationDataInfluenceDataSubsToNotifySubscriptionIdGet(ctx context.Context, subscID string) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataInfluenceDataSubsToNotifySubscriptionIdGet: subscID=%s", subscID)
 
-	response, problemDetails := getApplicationDataIndividualInfluenceDataSubsToNotifyFromDB(subscID)
+	response, problemDetails := getApplicationDataIndividualInfluenceDataSubsToNotifyFromDB(ctx, subscID)
 
 	if problemDetails != nil {
 		stats.IncrementUdrApplicationDataStats("get", InfluenceDataSubscription, "FAILURE")
@@ -905,10 +971,11 @@ func HandleApplicationDataInfluenceDataSubsToNotifySubscriptionIdGet(subscID str
 }
 
 func getApplicationDataIndividualInfluenceDataSubsToNotifyFromDB(
+	ctx context.Context,
 	subscID string,
 ) (map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"subscriptionId": subscID}
-	data, problemDetails := getDataFromDB(APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter)
+	data, problemDetails := getDataFromDB(ctx, APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter)
 	if data != nil {
 		// Delete "subscriptionId" entry which is added by us
 		delete(data, "subscriptionId")
@@ -917,12 +984,13 @@ func getApplicationDataIndividualInfluenceDataSubsToNotifyFromDB(
 }
 
 func HandleApplicationDataInfluenceDataSubsToNotifySubscriptionIdPut(
+	ctx context.Context,
 	subscID string, trInfluSub *models.TrafficInfluSub,
 ) *httpwrapper.Response {
 	logger.DataRepoLog.Infof(
 		"handle HandleApplicationDataInfluenceDataSubsToNotifySubscriptionIdPut: subscID=%q", subscID)
 
-	response, status := putApplicationDataIndividualInfluenceDataSubsToNotifyToDB(subscID, trInfluSub)
+	response, status := putApplicationDataIndividualInfluenceDataSubsToNotifyToDB(ctx, subscID, trInfluSub)
 	if response != nil {
 		stats.IncrementUdrApplicationDataStats("update", InfluenceDataSubscription, "SUCCESS")
 	} else {
@@ -932,13 +1000,13 @@ func HandleApplicationDataInfluenceDataSubsToNotifySubscriptionIdPut(
 	return httpwrapper.NewResponse(status, nil, response)
 }
 
-func putApplicationDataIndividualInfluenceDataSubsToNotifyToDB(subscID string,
+func putApplicationDataIndividualInfluenceDataSubsToNotifyToDB(ctx context.Context, subscID string,
 	trInfluSub *models.TrafficInfluSub,
 ) (bson.M, int) {
 	filter := bson.M{"subscriptionId": subscID}
 	newData := util.ToBsonM(*trInfluSub)
 
-	oldData, errGetOne := CommonDBClient.RestfulAPIGetOne(APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter)
+	oldData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -948,7 +1016,7 @@ func putApplicationDataIndividualInfluenceDataSubsToNotifyToDB(subscID string,
 	// Add "subscriptionId" entry to DB
 	newData["subscriptionId"] = subscID
 	// Modify with new data
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter, newData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, APPDATA_INFLUDATA_SUBSC_DB_COLLECTION_NAME, filter, newData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
@@ -957,11 +1025,12 @@ func putApplicationDataIndividualInfluenceDataSubsToNotifyToDB(subscID string,
 	return newData, http.StatusOK
 }
 
-func HandleApplicationDataPfdsAppIdDelete(appID string) *httpwrapper.Response {
+func HandleApplicationDataPfdsAppIdDelete(ctx context.Context, appID string) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataPfdsAppIdDelete: appID=%s", appID)
 
-	err := deleteApplicationDataIndividualPfdFromDB(appID)
+	err := deleteApplicationDataIndividualPfdFromDB(ctx, appID)
 	if err == nil {
+		PreHandleApplicationDataChangeNotification(ApplicationDataIndPfd, "/application-data/pfds/"+appID, nil)
 		stats.IncrementUdrApplicationDataStats("delete", "pfds", "SUCCESS")
 	} else {
 		stats.IncrementUdrApplicationDataStats("delete", "pfds", "FAILURE")
@@ -969,15 +1038,15 @@ func HandleApplicationDataPfdsAppIdDelete(appID string) *httpwrapper.Response {
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func deleteApplicationDataIndividualPfdFromDB(appID string) error {
+func deleteApplicationDataIndividualPfdFromDB(ctx context.Context, appID string) error {
 	filter := bson.M{"applicationId": appID}
-	return deleteDataFromDB(APPDATA_PFD_DB_COLLECTION_NAME, filter)
+	return deleteDataFromDB(ctx, APPDATA_PFD_DB_COLLECTION_NAME, filter)
 }
 
-func HandleApplicationDataPfdsAppIdGet(appID string) *httpwrapper.Response {
+func HandleApplicationDataPfdsAppIdGet(ctx context.Context, appID string) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataPfdsAppIdGet: appID=%s", appID)
 
-	response, problemDetails := getApplicationDataIndividualPfdFromDB(appID)
+	response, problemDetails := getApplicationDataIndividualPfdFromDB(ctx, appID)
 
 	if problemDetails != nil {
 		stats.IncrementUdrApplicationDataStats("get", "pfds", "FAILURE")
@@ -987,16 +1056,17 @@ func HandleApplicationDataPfdsAppIdGet(appID string) *httpwrapper.Response {
 	return httpwrapper.NewResponse(http.StatusOK, nil, response)
 }
 
-func getApplicationDataIndividualPfdFromDB(appID string) (map[string]interface{}, *models.ProblemDetails) {
+func getApplicationDataIndividualPfdFromDB(ctx context.Context, appID string) (map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"applicationId": appID}
-	return getDataFromDB(APPDATA_PFD_DB_COLLECTION_NAME, filter)
+	return getDataFromDB(ctx, APPDATA_PFD_DB_COLLECTION_NAME, filter)
 }
 
-func HandleApplicationDataPfdsAppIdPut(appID string, pfdDataForApp *models.PfdDataForApp) *httpwrapper.Response {
+func HandleApplicationDataPfdsAppIdPut(ctx context.Context, appID string, pfdDataForApp *models.PfdDataForApp) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataPfdsAppIdPut: appID=%s", appID)
 
-	response, status := putApplicationDataIndividualPfdToDB(appID, pfdDataForApp)
+	response, status := putApplicationDataIndividualPfdToDB(ctx, appID, pfdDataForApp)
 	if response != nil {
+		PreHandleApplicationDataChangeNotification(ApplicationDataIndPfd, "/application-data/pfds/"+appID, response)
 		stats.IncrementUdrApplicationDataStats("update", "pfds", "SUCCESS")
 	} else {
 		stats.IncrementUdrApplicationDataStats("update", "pfds", "FAILURE")
@@ -1004,11 +1074,11 @@ func HandleApplicationDataPfdsAppIdPut(appID string, pfdDataForApp *models.PfdDa
 	return httpwrapper.NewResponse(status, nil, response)
 }
 
-func putApplicationDataIndividualPfdToDB(appID string, pfdDataForApp *models.PfdDataForApp) (bson.M, int) {
+func putApplicationDataIndividualPfdToDB(ctx context.Context, appID string, pfdDataForApp *models.PfdDataForApp) (bson.M, int) {
 	filter := bson.M{"applicationId": appID}
 	data := util.ToBsonM(*pfdDataForApp)
 
-	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(APPDATA_PFD_DB_COLLECTION_NAME, filter, data)
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, APPDATA_PFD_DB_COLLECTION_NAME, filter, data)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
@@ -1019,23 +1089,23 @@ func putApplicationDataIndividualPfdToDB(appID string, pfdDataForApp *models.Pfd
 	return data, http.StatusCreated
 }
 
-func HandleApplicationDataPfdsGet(pfdsAppIDs []string) *httpwrapper.Response {
+func HandleApplicationDataPfdsGet(ctx context.Context, pfdsAppIDs []string) *httpwrapper.Response {
 	logger.DataRepoLog.Debugf("handle ApplicationDataPfdsGet: pfdsAppIDs=%#v", pfdsAppIDs)
 
 	// TODO: Parse appID with separator ','
 	// Ex: "app1,app2,..."
-	response := getApplicationDataPfdsFromDB(pfdsAppIDs)
+	response := getApplicationDataPfdsFromDB(ctx, pfdsAppIDs)
 	stats.IncrementUdrApplicationDataStats("get", "pfds", "SUCCESS")
 	return httpwrapper.NewResponse(http.StatusOK, nil, response)
 }
 
-func getApplicationDataPfdsFromDB(pfdsAppIDs []string) (response []map[string]interface{}) {
+func getApplicationDataPfdsFromDB(ctx context.Context, pfdsAppIDs []string) (response []map[string]interface{}) {
 	filter := bson.M{}
 
 	var matchedPfds []map[string]interface{}
 	var errGetMany error
 	if len(pfdsAppIDs) == 0 {
-		matchedPfds, errGetMany = CommonDBClient.RestfulAPIGetMany(APPDATA_PFD_DB_COLLECTION_NAME, filter)
+		matchedPfds, errGetMany = CommonDBClient.RestfulAPIGetMany(ctx, APPDATA_PFD_DB_COLLECTION_NAME, filter)
 		if errGetMany != nil {
 			logger.DataRepoLog.Warnln(errGetMany)
 		}
@@ -1045,7 +1115,7 @@ func getApplicationDataPfdsFromDB(pfdsAppIDs []string) (response []map[string]in
 	} else {
 		for _, v := range pfdsAppIDs {
 			filter := bson.M{"applicationId": v}
-			data, errGetOne := CommonDBClient.RestfulAPIGetOne(APPDATA_PFD_DB_COLLECTION_NAME, filter)
+			data, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, APPDATA_PFD_DB_COLLECTION_NAME, filter)
 			if errGetOne != nil {
 				logger.DataRepoLog.Warnln(errGetOne)
 			}
@@ -1059,13 +1129,13 @@ func getApplicationDataPfdsFromDB(pfdsAppIDs []string) (response []map[string]in
 	return matchedPfds
 }
 
-func HandlePolicyDataBdtDataBdtReferenceIdDelete(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataBdtDataBdtReferenceIdDelete(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataBdtDataBdtReferenceIdDelete")
 
 	collName := POLICYDATA_BDTDATA
 	bdtReferenceId := request.Params["bdtReferenceId"]
 
-	err := PolicyDataBdtDataBdtReferenceIdDeleteProcedure(collName, bdtReferenceId)
+	err := PolicyDataBdtDataBdtReferenceIdDeleteProcedure(ctx, collName, bdtReferenceId)
 	if err == nil {
 		stats.IncrementUdrPolicyDataStats("delete", BDTData, "SUCCESS")
 	} else {
@@ -1074,22 +1144,22 @@ func HandlePolicyDataBdtDataBdtReferenceIdDelete(request *httpwrapper.Request) *
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func PolicyDataBdtDataBdtReferenceIdDeleteProcedure(collName string, bdtReferenceId string) error {
+func PolicyDataBdtDataBdtReferenceIdDeleteProcedure(ctx context.Context, collName string, bdtReferenceId string) error {
 	filter := bson.M{"bdtReferenceId": bdtReferenceId}
-	errDelOne := CommonDBClient.RestfulAPIDeleteOne(collName, filter)
+	errDelOne := CommonDBClient.RestfulAPIDeleteOne(ctx, collName, filter)
 	if errDelOne != nil {
 		logger.DataRepoLog.Warnln(errDelOne)
 	}
 	return errDelOne
 }
 
-func HandlePolicyDataBdtDataBdtReferenceIdGet(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataBdtDataBdtReferenceIdGet(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataBdtDataBdtReferenceIdGet")
 
 	collName := POLICYDATA_BDTDATA
 	bdtReferenceId := request.Params["bdtReferenceId"]
 
-	response, problemDetails := PolicyDataBdtDataBdtReferenceIdGetProcedure(collName, bdtReferenceId)
+	response, problemDetails := PolicyDataBdtDataBdtReferenceIdGetProcedure(ctx, collName, bdtReferenceId)
 	if response != nil {
 		stats.IncrementUdrPolicyDataStats("get", BDTData, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusOK, nil, response)
@@ -1103,12 +1173,12 @@ func HandlePolicyDataBdtDataBdtReferenceIdGet(request *httpwrapper.Request) *htt
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func PolicyDataBdtDataBdtReferenceIdGetProcedure(collName string, bdtReferenceId string) (*map[string]interface{},
+func PolicyDataBdtDataBdtReferenceIdGetProcedure(ctx context.Context, collName string, bdtReferenceId string) (*map[string]interface{},
 	*models.ProblemDetails,
 ) {
 	filter := bson.M{"bdtReferenceId": bdtReferenceId}
 
-	bdtData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	bdtData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -1119,14 +1189,14 @@ func PolicyDataBdtDataBdtReferenceIdGetProcedure(collName string, bdtReferenceId
 	return nil, utils.ProblemDetailsDataNotFound()
 }
 
-func HandlePolicyDataBdtDataBdtReferenceIdPut(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataBdtDataBdtReferenceIdPut(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataBdtDataBdtReferenceIdPut")
 
 	collName := POLICYDATA_BDTDATA
 	bdtReferenceId := request.Params["bdtReferenceId"]
 	bdtData := request.Body.(models.BdtData)
 
-	response := PolicyDataBdtDataBdtReferenceIdPutProcedure(collName, bdtReferenceId, bdtData)
+	response := PolicyDataBdtDataBdtReferenceIdPutProcedure(ctx, collName, bdtReferenceId, bdtData)
 	if response != nil {
 		stats.IncrementUdrPolicyDataStats("update", BDTData, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusOK, nil, response)
@@ -1137,14 +1207,14 @@ func HandlePolicyDataBdtDataBdtReferenceIdPut(request *httpwrapper.Request) *htt
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func PolicyDataBdtDataBdtReferenceIdPutProcedure(collName string, bdtReferenceId string,
+func PolicyDataBdtDataBdtReferenceIdPutProcedure(ctx context.Context, collName string, bdtReferenceId string,
 	bdtData models.BdtData,
 ) bson.M {
 	putData := util.ToBsonM(bdtData)
 	putData["bdtReferenceId"] = bdtReferenceId
 	filter := bson.M{"bdtReferenceId": bdtReferenceId}
 
-	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
@@ -1155,32 +1225,32 @@ func PolicyDataBdtDataBdtReferenceIdPutProcedure(collName string, bdtReferenceId
 	return putData
 }
 
-func HandlePolicyDataBdtDataGet(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataBdtDataGet(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataBdtDataGet")
 
 	collName := POLICYDATA_BDTDATA
 
-	response := PolicyDataBdtDataGetProcedure(collName)
+	response := PolicyDataBdtDataGetProcedure(ctx, collName)
 	stats.IncrementUdrPolicyDataStats("get", BDTData, "SUCCESS")
 	return httpwrapper.NewResponse(http.StatusOK, nil, response)
 }
 
-func PolicyDataBdtDataGetProcedure(collName string) (response *[]map[string]interface{}) {
+func PolicyDataBdtDataGetProcedure(ctx context.Context, collName string) (response *[]map[string]interface{}) {
 	filter := bson.M{}
-	bdtDataArray, errGetMany := CommonDBClient.RestfulAPIGetMany(collName, filter)
+	bdtDataArray, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, collName, filter)
 	if errGetMany != nil {
 		logger.DataRepoLog.Warnln(errGetMany)
 	}
 	return &bdtDataArray
 }
 
-func HandlePolicyDataPlmnsPlmnIdUePolicySetGet(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataPlmnsPlmnIdUePolicySetGet(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataPlmnsPlmnIdUePolicySetGet")
 
 	collName := "policyData.plmns.uePolicySet"
 	plmnId := request.Params["plmnId"]
 
-	response, problemDetails := PolicyDataPlmnsPlmnIdUePolicySetGetProcedure(collName, plmnId)
+	response, problemDetails := PolicyDataPlmnsPlmnIdUePolicySetGetProcedure(ctx, collName, plmnId)
 
 	if response != nil {
 		stats.IncrementUdrPolicyDataStats("get", PLMNUEPolicySet, "SUCCESS")
@@ -1195,11 +1265,11 @@ func HandlePolicyDataPlmnsPlmnIdUePolicySetGet(request *httpwrapper.Request) *ht
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func PolicyDataPlmnsPlmnIdUePolicySetGetProcedure(collName string,
+func PolicyDataPlmnsPlmnIdUePolicySetGetProcedure(ctx context.Context, collName string,
 	plmnId string,
 ) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"plmnId": plmnId}
-	uePolicySet, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	uePolicySet, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -1210,13 +1280,13 @@ func PolicyDataPlmnsPlmnIdUePolicySetGetProcedure(collName string,
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandlePolicyDataSponsorConnectivityDataSponsorIdGet(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataSponsorConnectivityDataSponsorIdGet(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataSponsorConnectivityDataSponsorIdGet")
 
 	collName := "policyData.sponsorConnectivityData"
 	sponsorId := request.Params["sponsorId"]
 
-	response, status := PolicyDataSponsorConnectivityDataSponsorIdGetProcedure(collName, sponsorId)
+	response, status := PolicyDataSponsorConnectivityDataSponsorIdGetProcedure(ctx, collName, sponsorId)
 
 	switch status {
 	case http.StatusOK:
@@ -1232,12 +1302,12 @@ func HandlePolicyDataSponsorConnectivityDataSponsorIdGet(request *httpwrapper.Re
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func PolicyDataSponsorConnectivityDataSponsorIdGetProcedure(collName string,
+func PolicyDataSponsorConnectivityDataSponsorIdGetProcedure(ctx context.Context, collName string,
 	sponsorId string,
 ) (*map[string]interface{}, int) {
 	filter := bson.M{"sponsorId": sponsorId}
 
-	sponsorConnectivityData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	sponsorConnectivityData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -1248,12 +1318,17 @@ func PolicyDataSponsorConnectivityDataSponsorIdGetProcedure(collName string,
 	return nil, http.StatusNoContent
 }
 
-func HandlePolicyDataSubsToNotifyPost(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataSubsToNotifyPost(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataSubsToNotifyPost")
 
 	PolicyDataSubscription := request.Body.(models.PolicyDataSubscription)
+	clampSubscriptionExpiry(&PolicyDataSubscription, time.Now())
 
-	locationHeader := PolicyDataSubsToNotifyPostProcedure(PolicyDataSubscription)
+	locationHeader, problemDetails := PolicyDataSubsToNotifyPostProcedure(ctx, PolicyDataSubscription)
+	if problemDetails != nil {
+		stats.IncrementUdrPolicyDataStats("create", SubsToNotify, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
 
 	headers := http.Header{}
 	headers.Set("Location", locationHeader)
@@ -1261,27 +1336,32 @@ func HandlePolicyDataSubsToNotifyPost(request *httpwrapper.Request) *httpwrapper
 	return httpwrapper.NewResponse(http.StatusCreated, headers, PolicyDataSubscription)
 }
 
-func PolicyDataSubsToNotifyPostProcedure(PolicyDataSubscription models.PolicyDataSubscription) string {
+func PolicyDataSubsToNotifyPostProcedure(ctx context.Context, PolicyDataSubscription models.PolicyDataSubscription) (string,
+	*models.ProblemDetails,
+) {
 	udrSelf := udr_context.UDR_Self()
 
-	newSubscriptionID := strconv.Itoa(udrSelf.PolicyDataSubscriptionIDGenerator)
-	udrSelf.PolicyDataSubscriptions[newSubscriptionID] = &PolicyDataSubscription
-	udrSelf.PolicyDataSubscriptionIDGenerator++
+	newSubscriptionID := udrSelf.NewSubscriptionID()
+
+	if err := putPolicyDataSubscription(ctx, newSubscriptionID, &PolicyDataSubscription); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return "", utils.ProblemDetailsSystemFailure(err.Error())
+	}
 
 	/* Contains the URI of the newly created resource, according
 	   to the structure: {apiRoot}/subscription-data/subs-to-notify/{subsId} */
 	locationHeader := fmt.Sprintf("%s/policy-data/subs-to-notify/%s", udrSelf.GetIPv4GroupUri(udr_context.NUDR_DR),
 		newSubscriptionID)
 
-	return locationHeader
+	return locationHeader, nil
 }
 
-func HandlePolicyDataSubsToNotifySubsIdDelete(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataSubsToNotifySubsIdDelete(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataSubsToNotifySubsIdDelete")
 
 	subsId := request.Params["subsId"]
 
-	problemDetails := PolicyDataSubsToNotifySubsIdDeleteProcedure(subsId)
+	problemDetails := PolicyDataSubsToNotifySubsIdDeleteProcedure(ctx, subsId)
 
 	if problemDetails == nil {
 		stats.IncrementUdrPolicyDataStats("delete", SubsToNotify, "SUCCESS")
@@ -1291,24 +1371,26 @@ func HandlePolicyDataSubsToNotifySubsIdDelete(request *httpwrapper.Request) *htt
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func PolicyDataSubsToNotifySubsIdDeleteProcedure(subsId string) (problemDetails *models.ProblemDetails) {
-	udrSelf := udr_context.UDR_Self()
-	_, ok := udrSelf.PolicyDataSubscriptions[subsId]
-	if !ok {
+func PolicyDataSubsToNotifySubsIdDeleteProcedure(ctx context.Context, subsId string) (problemDetails *models.ProblemDetails) {
+	if _, ok := loadPolicyDataSubscription(ctx, subsId); !ok {
 		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
-	delete(udrSelf.PolicyDataSubscriptions, subsId)
+	if err := deletePolicyDataSubscription(ctx, subsId); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsSystemFailure(err.Error())
+	}
 
 	return nil
 }
 
-func HandlePolicyDataSubsToNotifySubsIdPut(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataSubsToNotifySubsIdPut(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataSubsToNotifySubsIdPut")
 
 	subsId := request.Params["subsId"]
 	policyDataSubscription := request.Body.(models.PolicyDataSubscription)
+	clampSubscriptionExpiry(&policyDataSubscription, time.Now())
 
-	response, problemDetails := PolicyDataSubsToNotifySubsIdPutProcedure(subsId, policyDataSubscription)
+	response, problemDetails := PolicyDataSubsToNotifySubsIdPutProcedure(ctx, subsId, policyDataSubscription)
 
 	if problemDetails == nil {
 		stats.IncrementUdrPolicyDataStats("update", SubsToNotify, "SUCCESS")
@@ -1318,27 +1400,27 @@ func HandlePolicyDataSubsToNotifySubsIdPut(request *httpwrapper.Request) *httpwr
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func PolicyDataSubsToNotifySubsIdPutProcedure(subsId string,
+func PolicyDataSubsToNotifySubsIdPutProcedure(ctx context.Context, subsId string,
 	policyDataSubscription models.PolicyDataSubscription,
 ) (*models.PolicyDataSubscription, *models.ProblemDetails) {
-	udrSelf := udr_context.UDR_Self()
-	_, ok := udrSelf.PolicyDataSubscriptions[subsId]
-	if !ok {
+	if _, ok := loadPolicyDataSubscription(ctx, subsId); !ok {
 		return nil, utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
-
-	udrSelf.PolicyDataSubscriptions[subsId] = &policyDataSubscription
+	if err := putPolicyDataSubscription(ctx, subsId, &policyDataSubscription); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return nil, utils.ProblemDetailsSystemFailure(err.Error())
+	}
 
 	return &policyDataSubscription, nil
 }
 
-func HandlePolicyDataUesUeIdAmDataGet(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdAmDataGet(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdAmDataGet")
 
 	collName := "policyData.ues.amData"
 	ueId := request.Params["ueId"]
 
-	response, problemDetails := PolicyDataUesUeIdAmDataGetProcedure(collName, ueId)
+	response, problemDetails := PolicyDataUesUeIdAmDataGetProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrPolicyDataStats("get", AMData, "SUCCESS")
@@ -1353,12 +1435,12 @@ func HandlePolicyDataUesUeIdAmDataGet(request *httpwrapper.Request) *httpwrapper
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func PolicyDataUesUeIdAmDataGetProcedure(collName string,
+func PolicyDataUesUeIdAmDataGetProcedure(ctx context.Context, collName string,
 	ueId string,
 ) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	amPolicyData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	amPolicyData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -1369,13 +1451,13 @@ func PolicyDataUesUeIdAmDataGetProcedure(collName string,
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandlePolicyDataUesUeIdOperatorSpecificDataGet(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdOperatorSpecificDataGet(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdOperatorSpecificDataGet")
 
 	collName := POLICYDATA_UES_OPSPECDATA
 	ueId := request.Params["ueId"]
 
-	response, problemDetails := PolicyDataUesUeIdOperatorSpecificDataGetProcedure(collName, ueId)
+	response, problemDetails := PolicyDataUesUeIdOperatorSpecificDataGetProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrPolicyDataStats("get", OperatorSpecificData, "SUCCESS")
@@ -1390,12 +1472,12 @@ func HandlePolicyDataUesUeIdOperatorSpecificDataGet(request *httpwrapper.Request
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func PolicyDataUesUeIdOperatorSpecificDataGetProcedure(collName string,
+func PolicyDataUesUeIdOperatorSpecificDataGetProcedure(ctx context.Context, collName string,
 	ueId string,
 ) (*interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	operatorSpecificDataContainerMapCover, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	operatorSpecificDataContainerMapCover, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -1407,14 +1489,14 @@ func PolicyDataUesUeIdOperatorSpecificDataGetProcedure(collName string,
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandlePolicyDataUesUeIdOperatorSpecificDataPatch(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdOperatorSpecificDataPatch(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdOperatorSpecificDataPatch")
 
 	collName := POLICYDATA_UES_OPSPECDATA
 	ueId := request.Params["ueId"]
 	patchItem := request.Body.([]models.PatchItem)
 
-	problemDetails := PolicyDataUesUeIdOperatorSpecificDataPatchProcedure(collName, ueId, patchItem)
+	problemDetails := PolicyDataUesUeIdOperatorSpecificDataPatchProcedure(ctx, collName, ueId, patchItem)
 
 	if problemDetails == nil {
 		stats.IncrementUdrPolicyDataStats("update", OperatorSpecificData, "SUCCESS")
@@ -1424,7 +1506,7 @@ func HandlePolicyDataUesUeIdOperatorSpecificDataPatch(request *httpwrapper.Reque
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func PolicyDataUesUeIdOperatorSpecificDataPatchProcedure(collName string, ueId string,
+func PolicyDataUesUeIdOperatorSpecificDataPatchProcedure(ctx context.Context, collName string, ueId string,
 	patchItem []models.PatchItem,
 ) *models.ProblemDetails {
 	filter := bson.M{"ueId": ueId}
@@ -1434,7 +1516,7 @@ func PolicyDataUesUeIdOperatorSpecificDataPatchProcedure(collName string, ueId s
 		logger.DataRepoLog.Warnln(err)
 	}
 
-	failure := CommonDBClient.RestfulAPIJSONPatchExtend(collName, filter, patchJSON,
+	failure := CommonDBClient.RestfulAPIJSONPatchExtend(ctx, collName, filter, patchJSON,
 		"operatorSpecificDataContainerMap")
 
 	if failure == nil {
@@ -1443,7 +1525,7 @@ func PolicyDataUesUeIdOperatorSpecificDataPatchProcedure(collName string, ueId s
 	return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
 }
 
-func HandlePolicyDataUesUeIdOperatorSpecificDataPut(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdOperatorSpecificDataPut(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdOperatorSpecificDataPut")
 
 	// json.NewDecoder(c.Request.Body).Decode(&operatorSpecificDataContainerMap)
@@ -1452,7 +1534,7 @@ func HandlePolicyDataUesUeIdOperatorSpecificDataPut(request *httpwrapper.Request
 	ueId := request.Params["ueId"]
 	OperatorSpecificDataContainer := request.Body.(map[string]models.OperatorSpecificDataContainer)
 
-	err := PolicyDataUesUeIdOperatorSpecificDataPutProcedure(collName, ueId, OperatorSpecificDataContainer)
+	err := PolicyDataUesUeIdOperatorSpecificDataPutProcedure(ctx, collName, ueId, OperatorSpecificDataContainer)
 	if err == nil {
 		stats.IncrementUdrPolicyDataStats("create", OperatorSpecificData, "SUCCESS")
 	} else {
@@ -1462,7 +1544,7 @@ func HandlePolicyDataUesUeIdOperatorSpecificDataPut(request *httpwrapper.Request
 	return httpwrapper.NewResponse(http.StatusOK, nil, map[string]interface{}{})
 }
 
-func PolicyDataUesUeIdOperatorSpecificDataPutProcedure(collName string, ueId string,
+func PolicyDataUesUeIdOperatorSpecificDataPutProcedure(ctx context.Context, collName string, ueId string,
 	OperatorSpecificDataContainer map[string]models.OperatorSpecificDataContainer,
 ) error {
 	filter := bson.M{"ueId": ueId}
@@ -1470,14 +1552,14 @@ func PolicyDataUesUeIdOperatorSpecificDataPutProcedure(collName string, ueId str
 	putData := map[string]interface{}{"operatorSpecificDataContainerMap": OperatorSpecificDataContainer}
 	putData["ueId"] = ueId
 
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
 	return errPutOne
 }
 
-func HandlePolicyDataUesUeIdSmDataGet(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdSmDataGet(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdSmDataGet")
 
 	collName := "policyData.ues.smData"
@@ -1503,7 +1585,7 @@ func HandlePolicyDataUesUeIdSmDataGet(request *httpwrapper.Request) *httpwrapper
 	}
 	dnn := request.Query.Get("dnn")
 
-	response, problemDetails := PolicyDataUesUeIdSmDataGetProcedure(collName, ueId, sNssai, dnn)
+	response, problemDetails := PolicyDataUesUeIdSmDataGetProcedure(ctx, collName, ueId, sNssai, dnn)
 	if response != nil {
 		stats.IncrementUdrPolicyDataStats("get", SMData, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusOK, nil, response)
@@ -1517,7 +1599,7 @@ func HandlePolicyDataUesUeIdSmDataGet(request *httpwrapper.Request) *httpwrapper
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func PolicyDataUesUeIdSmDataGetProcedure(collName string, ueId string, snssai models.Snssai,
+func PolicyDataUesUeIdSmDataGetProcedure(ctx context.Context, collName string, ueId string, snssai models.Snssai,
 	dnn string,
 ) (*models.SmPolicyData, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
@@ -1527,17 +1609,18 @@ func PolicyDataUesUeIdSmDataGetProcedure(collName string, ueId string, snssai mo
 		addSmPolicySnssaiDnnFilter(filter, hexSnssai, dnn)
 	}
 
-	smPolicyData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	smPolicyData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
 	if smPolicyData != nil {
-		return SmDataGetProcedureSmPolicyDataResponse(ueId, smPolicyData)
+		return SmDataGetProcedureSmPolicyDataResponse(ctx, ueId, smPolicyData)
 	}
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
 func SmDataGetProcedureSmPolicyDataResponse(
+	ctx context.Context,
 	ueId string,
 	smPolicyData map[string]interface{},
 ) (*models.SmPolicyData, *models.ProblemDetails) {
@@ -1548,7 +1631,7 @@ func SmDataGetProcedureSmPolicyDataResponse(
 	}
 	collName := POLICYDATA_UES_SMDATA_USAGEMONDATA
 	filter := bson.M{"ueId": ueId}
-	usageMonDataMapArray, errGetMany := CommonDBClient.RestfulAPIGetMany(collName, filter)
+	usageMonDataMapArray, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, collName, filter)
 	if errGetMany != nil {
 		logger.DataRepoLog.Warnln(errGetMany)
 	}
@@ -1568,14 +1651,14 @@ func SmDataGetProcedureSmPolicyDataResponse(
 	return smPolicyDataResp, nil
 }
 
-func HandlePolicyDataUesUeIdSmDataPatch(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdSmDataPatch(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdSmDataPatch")
 
 	collName := POLICYDATA_UES_SMDATA_USAGEMONDATA
 	ueId := request.Params["ueId"]
 	usageMonData := request.Body.(map[string]models.UsageMonData)
 
-	problemDetails := PolicyDataUesUeIdSmDataPatchProcedure(collName, ueId, usageMonData)
+	problemDetails := PolicyDataUesUeIdSmDataPatchProcedure(ctx, collName, ueId, usageMonData)
 	if problemDetails == nil {
 		stats.IncrementUdrPolicyDataStats("update", SMData, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
@@ -1584,7 +1667,7 @@ func HandlePolicyDataUesUeIdSmDataPatch(request *httpwrapper.Request) *httpwrapp
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func PolicyDataUesUeIdSmDataPatchProcedure(collName string, ueId string,
+func PolicyDataUesUeIdSmDataPatchProcedure(ctx context.Context, collName string, ueId string,
 	UsageMonData map[string]models.UsageMonData,
 ) *models.ProblemDetails {
 	filter := bson.M{"ueId": ueId}
@@ -1593,12 +1676,12 @@ func PolicyDataUesUeIdSmDataPatchProcedure(collName string, ueId string,
 	for k, usageMonData := range UsageMonData {
 		limitId := k
 		filterTmp := bson.M{"ueId": ueId, "limitId": limitId}
-		failure := CommonDBClient.RestfulAPIMergePatch(collName, filterTmp, util.ToBsonM(usageMonData))
+		failure := CommonDBClient.RestfulAPIMergePatch(ctx, collName, filterTmp, util.ToBsonM(usageMonData))
 		if failure != nil {
 			successAll = false
 		} else {
 			usageMonData := models.NewUsageMonDataWithDefaults()
-			usageMonDataBsonM, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filterTmp)
+			usageMonDataBsonM, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filterTmp)
 			if errGetOne != nil {
 				logger.DataRepoLog.Warnln(errGetOne)
 			}
@@ -1609,17 +1692,18 @@ func PolicyDataUesUeIdSmDataPatchProcedure(collName string, ueId string,
 			PreHandlePolicyDataChangeNotification(ueId, limitId, *usageMonData)
 		}
 	}
-	return SmDataPatchProcedureSuccessAll(successAll, collName, ueId, filter)
+	return SmDataPatchProcedureSuccessAll(ctx, successAll, collName, ueId, filter)
 }
 
 func SmDataPatchProcedureSuccessAll(
+	ctx context.Context,
 	successAll bool,
 	collName string,
 	ueId string,
 	filter bson.M,
 ) *models.ProblemDetails {
 	if successAll {
-		smPolicyDataBsonM, errGetOneNew := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		smPolicyDataBsonM, errGetOneNew := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOneNew != nil {
 			logger.DataRepoLog.Warnln(errGetOneNew)
 		}
@@ -1630,7 +1714,7 @@ func SmDataPatchProcedureSuccessAll(
 		}
 		collName := POLICYDATA_UES_SMDATA_USAGEMONDATA
 		filter := bson.M{"ueId": ueId}
-		usageMonDataMapArray, errGetMany := CommonDBClient.RestfulAPIGetMany(collName, filter)
+		usageMonDataMapArray, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, collName, filter)
 		if errGetMany != nil {
 			logger.DataRepoLog.Warnln(errGetMany)
 		}
@@ -1653,14 +1737,14 @@ func SmDataPatchProcedureSuccessAll(
 	return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
 }
 
-func HandlePolicyDataUesUeIdSmDataUsageMonIdDelete(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdSmDataUsageMonIdDelete(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdSmDataUsageMonIdDelete")
 
 	collName := POLICYDATA_UES_SMDATA_USAGEMONDATA
 	ueId := request.Params["ueId"]
 	usageMonId := request.Params["usageMonId"]
 
-	err := PolicyDataUesUeIdSmDataUsageMonIdDeleteProcedure(collName, ueId, usageMonId)
+	err := PolicyDataUesUeIdSmDataUsageMonIdDeleteProcedure(ctx, collName, ueId, usageMonId)
 	if err == nil {
 		stats.IncrementUdrPolicyDataStats("delete", SMData, "SUCCESS")
 	} else {
@@ -1669,23 +1753,23 @@ func HandlePolicyDataUesUeIdSmDataUsageMonIdDelete(request *httpwrapper.Request)
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func PolicyDataUesUeIdSmDataUsageMonIdDeleteProcedure(collName string, ueId string, usageMonId string) error {
+func PolicyDataUesUeIdSmDataUsageMonIdDeleteProcedure(ctx context.Context, collName string, ueId string, usageMonId string) error {
 	filter := bson.M{"ueId": ueId, "usageMonId": usageMonId}
-	errDelOne := CommonDBClient.RestfulAPIDeleteOne(collName, filter)
+	errDelOne := CommonDBClient.RestfulAPIDeleteOne(ctx, collName, filter)
 	if errDelOne != nil {
 		logger.DataRepoLog.Warnln(errDelOne)
 	}
 	return errDelOne
 }
 
-func HandlePolicyDataUesUeIdSmDataUsageMonIdGet(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdSmDataUsageMonIdGet(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdSmDataUsageMonIdGet")
 
 	collName := POLICYDATA_UES_SMDATA_USAGEMONDATA
 	ueId := request.Params["ueId"]
 	usageMonId := request.Params["usageMonId"]
 
-	response := PolicyDataUesUeIdSmDataUsageMonIdGetProcedure(collName, usageMonId, ueId)
+	response := PolicyDataUesUeIdSmDataUsageMonIdGetProcedure(ctx, collName, usageMonId, ueId)
 
 	if response != nil {
 		stats.IncrementUdrPolicyDataStats("get", SMData, "SUCCESS")
@@ -1695,12 +1779,12 @@ func HandlePolicyDataUesUeIdSmDataUsageMonIdGet(request *httpwrapper.Request) *h
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func PolicyDataUesUeIdSmDataUsageMonIdGetProcedure(collName string, usageMonId string,
+func PolicyDataUesUeIdSmDataUsageMonIdGetProcedure(ctx context.Context, collName string, usageMonId string,
 	ueId string,
 ) *map[string]interface{} {
 	filter := bson.M{"ueId": ueId, "usageMonId": usageMonId}
 
-	usageMonData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	usageMonData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -1708,7 +1792,7 @@ func PolicyDataUesUeIdSmDataUsageMonIdGetProcedure(collName string, usageMonId s
 	return &usageMonData
 }
 
-func HandlePolicyDataUesUeIdSmDataUsageMonIdPut(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdSmDataUsageMonIdPut(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdSmDataUsageMonIdPut")
 
 	ueId := request.Params["ueId"]
@@ -1716,13 +1800,13 @@ func HandlePolicyDataUesUeIdSmDataUsageMonIdPut(request *httpwrapper.Request) *h
 	usageMonData := request.Body.(models.UsageMonData)
 	collName := POLICYDATA_UES_SMDATA_USAGEMONDATA
 
-	response := PolicyDataUesUeIdSmDataUsageMonIdPutProcedure(collName, ueId, usageMonId, usageMonData)
+	response := PolicyDataUesUeIdSmDataUsageMonIdPutProcedure(ctx, collName, ueId, usageMonId, usageMonData)
 	stats.IncrementUdrPolicyDataStats("create", SMData, "SUCCESS")
 
 	return httpwrapper.NewResponse(http.StatusCreated, nil, response)
 }
 
-func PolicyDataUesUeIdSmDataUsageMonIdPutProcedure(collName string, ueId string, usageMonId string,
+func PolicyDataUesUeIdSmDataUsageMonIdPutProcedure(ctx context.Context, collName string, ueId string, usageMonId string,
 	usageMonData models.UsageMonData,
 ) *bson.M {
 	putData := util.ToBsonM(usageMonData)
@@ -1730,20 +1814,20 @@ func PolicyDataUesUeIdSmDataUsageMonIdPutProcedure(collName string, ueId string,
 	putData["usageMonId"] = usageMonId
 	filter := bson.M{"ueId": ueId, "usageMonId": usageMonId}
 
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
 	return &putData
 }
 
-func HandlePolicyDataUesUeIdUePolicySetGet(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdUePolicySetGet(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdUePolicySetGet")
 
 	ueId := request.Params["ueId"]
 	collName := POLICYDATA_UES_UEPOLICYSET
 
-	response, problemDetails := PolicyDataUesUeIdUePolicySetGetProcedure(collName, ueId)
+	response, problemDetails := PolicyDataUesUeIdUePolicySetGetProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrPolicyDataStats("get", UEPolicySet, "SUCCESS")
@@ -1758,12 +1842,12 @@ func HandlePolicyDataUesUeIdUePolicySetGet(request *httpwrapper.Request) *httpwr
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func PolicyDataUesUeIdUePolicySetGetProcedure(collName string, ueId string) (*map[string]interface{},
+func PolicyDataUesUeIdUePolicySetGetProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{},
 	*models.ProblemDetails,
 ) {
 	filter := bson.M{"ueId": ueId}
 
-	uePolicySet, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	uePolicySet, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -1774,14 +1858,14 @@ func PolicyDataUesUeIdUePolicySetGetProcedure(collName string, ueId string) (*ma
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandlePolicyDataUesUeIdUePolicySetPatch(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdUePolicySetPatch(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdUePolicySetPatch")
 
 	collName := POLICYDATA_UES_UEPOLICYSET
 	ueId := request.Params["ueId"]
 	UePolicySet := request.Body.(models.UePolicySet)
 
-	problemDetails := PolicyDataUesUeIdUePolicySetPatchProcedure(collName, ueId, UePolicySet)
+	problemDetails := PolicyDataUesUeIdUePolicySetPatchProcedure(ctx, collName, ueId, UePolicySet)
 
 	if problemDetails == nil {
 		stats.IncrementUdrPolicyDataStats("update", UEPolicySet, "SUCCESS")
@@ -1791,18 +1875,18 @@ func HandlePolicyDataUesUeIdUePolicySetPatch(request *httpwrapper.Request) *http
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func PolicyDataUesUeIdUePolicySetPatchProcedure(collName string, ueId string,
+func PolicyDataUesUeIdUePolicySetPatchProcedure(ctx context.Context, collName string, ueId string,
 	UePolicySet models.UePolicySet,
 ) *models.ProblemDetails {
 	patchData := util.ToBsonM(UePolicySet)
 	patchData["ueId"] = ueId
 	filter := bson.M{"ueId": ueId}
 
-	failure := CommonDBClient.RestfulAPIMergePatch(collName, filter, patchData)
+	failure := CommonDBClient.RestfulAPIMergePatch(ctx, collName, filter, patchData)
 
 	if failure == nil {
 		uePolicySet := models.NewUePolicySetWithDefaults()
-		uePolicySetBsonM, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		uePolicySetBsonM, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOne != nil {
 			logger.DataRepoLog.Warnln(errGetOne)
 		}
@@ -1816,14 +1900,14 @@ func PolicyDataUesUeIdUePolicySetPatchProcedure(collName string, ueId string,
 	return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
 }
 
-func HandlePolicyDataUesUeIdUePolicySetPut(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePolicyDataUesUeIdUePolicySetPut(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PolicyDataUesUeIdUePolicySetPut")
 
 	collName := POLICYDATA_UES_UEPOLICYSET
 	ueId := request.Params["ueId"]
 	UePolicySet := request.Body.(models.UePolicySet)
 
-	response, status := PolicyDataUesUeIdUePolicySetPutProcedure(collName, ueId, UePolicySet)
+	response, status := PolicyDataUesUeIdUePolicySetPutProcedure(ctx, collName, ueId, UePolicySet)
 
 	switch status {
 	case http.StatusNoContent:
@@ -1839,14 +1923,14 @@ func HandlePolicyDataUesUeIdUePolicySetPut(request *httpwrapper.Request) *httpwr
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func PolicyDataUesUeIdUePolicySetPutProcedure(collName string, ueId string,
+func PolicyDataUesUeIdUePolicySetPutProcedure(ctx context.Context, collName string, ueId string,
 	UePolicySet models.UePolicySet,
 ) (bson.M, int) {
 	putData := util.ToBsonM(UePolicySet)
 	putData["ueId"] = ueId
 	filter := bson.M{"ueId": ueId}
 
-	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
@@ -1856,14 +1940,14 @@ func PolicyDataUesUeIdUePolicySetPutProcedure(collName string, ueId string,
 	return nil, http.StatusNoContent
 }
 
-func HandleCreateAMFSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateAMFSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateAMFSubscriptions")
 
 	ueId := request.Params["ueId"]
 	subsId := request.Params["subsId"]
 	AmfSubscriptionInfo := request.Body.([]models.AmfSubscriptionInfo)
 
-	problemDetails := CreateAMFSubscriptionsProcedure(subsId, ueId, AmfSubscriptionInfo)
+	problemDetails := CreateAMFSubscriptionsProcedure(ctx, subsId, ueId, AmfSubscriptionInfo)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("create", AMFSubscriptions, "SUCCESS")
@@ -1873,32 +1957,44 @@ func HandleCreateAMFSubscriptions(request *httpwrapper.Request) *httpwrapper.Res
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func CreateAMFSubscriptionsProcedure(subsId string, ueId string,
+func CreateAMFSubscriptionsProcedure(ctx context.Context, subsId string, ueId string,
 	AmfSubscriptionInfo []models.AmfSubscriptionInfo,
 ) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
 		return utils.ProblemDetailsUserNotFound()
 	}
-	UESubsData := value.(*udr_context.UESubsData)
 
-	_, ok = UESubsData.EeSubscriptionCollection[subsId]
+	eeSubscriptionCollection, ok := UESubsData.EeSubscriptionCollection[subsId]
 	if !ok {
 		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
 
-	UESubsData.EeSubscriptionCollection[subsId].AmfSubscriptionInfos = AmfSubscriptionInfo
+	return setAmfSubscriptionInfos(ctx, ueId, subsId, eeSubscriptionCollection, AmfSubscriptionInfo)
+}
+
+// setAmfSubscriptionInfos stores amfSubscriptionInfos with the EE
+// subscription, persisting it before the cached copy is changed.
+func setAmfSubscriptionInfos(ctx context.Context, ueId string, subsId string, eeSubscriptionCollection *udr_context.EeSubscriptionCollection,
+	amfSubscriptionInfos []models.AmfSubscriptionInfo,
+) *models.ProblemDetails {
+	updated := *eeSubscriptionCollection
+	updated.AmfSubscriptionInfos = amfSubscriptionInfos
+	if err := putEeSubscription(ctx, ueId, subsId, &updated); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsSystemFailure(err.Error())
+	}
+	eeSubscriptionCollection.AmfSubscriptionInfos = amfSubscriptionInfos
 	return nil
 }
 
-func HandleRemoveAmfSubscriptionsInfo(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleRemoveAmfSubscriptionsInfo(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle RemoveAmfSubscriptionsInfo")
 
 	ueId := request.Params["ueId"]
 	subsId := request.Params["subsId"]
 
-	problemDetails := RemoveAmfSubscriptionsInfoProcedure(subsId, ueId)
+	problemDetails := RemoveAmfSubscriptionsInfoProcedure(ctx, subsId, ueId)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("delete", AMFSubscriptions, "SUCCESS")
@@ -1908,37 +2004,33 @@ func HandleRemoveAmfSubscriptionsInfo(request *httpwrapper.Request) *httpwrapper
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func RemoveAmfSubscriptionsInfoProcedure(subsId string, ueId string) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+func RemoveAmfSubscriptionsInfoProcedure(ctx context.Context, subsId string, ueId string) *models.ProblemDetails {
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
 		return utils.ProblemDetailsUserNotFound()
 	}
 
-	UESubsData := value.(*udr_context.UESubsData)
-	_, ok = UESubsData.EeSubscriptionCollection[subsId]
+	eeSubscriptionCollection, ok := UESubsData.EeSubscriptionCollection[subsId]
 
 	if !ok {
 		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
 
-	if UESubsData.EeSubscriptionCollection[subsId].AmfSubscriptionInfos == nil {
+	if eeSubscriptionCollection.AmfSubscriptionInfos == nil {
 		return utils.ProblemDetailsWithCause("AMF Subscription not found", http.StatusNotFound, "", utils.CauseAmfSubscriptionNotFound)
 	}
 
-	UESubsData.EeSubscriptionCollection[subsId].AmfSubscriptionInfos = nil
-
-	return nil
+	return setAmfSubscriptionInfos(ctx, ueId, subsId, eeSubscriptionCollection, nil)
 }
 
-func HandleModifyAmfSubscriptionInfo(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleModifyAmfSubscriptionInfo(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle ModifyAmfSubscriptionInfo")
 
 	patchItem := request.Body.([]models.PatchItem)
 	ueId := request.Params["ueId"]
 	subsId := request.Params["subsId"]
 
-	problemDetails := ModifyAmfSubscriptionInfoProcedure(ueId, subsId, patchItem)
+	problemDetails := ModifyAmfSubscriptionInfoProcedure(ctx, ueId, subsId, patchItem)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("update", AMFSubscriptions, "SUCCESS")
@@ -1948,15 +2040,13 @@ func HandleModifyAmfSubscriptionInfo(request *httpwrapper.Request) *httpwrapper.
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func ModifyAmfSubscriptionInfoProcedure(ueId string, subsId string,
+func ModifyAmfSubscriptionInfoProcedure(ctx context.Context, ueId string, subsId string,
 	patchItem []models.PatchItem,
 ) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
 		return utils.ProblemDetailsUserNotFound()
 	}
-	UESubsData := value.(*udr_context.UESubsData)
 
 	_, ok = UESubsData.EeSubscriptionCollection[subsId]
 
@@ -1995,17 +2085,16 @@ func ModifyAmfSubscriptionInfoProcedure(ueId string, subsId string,
 		logger.DataRepoLog.Error(err)
 	}
 
-	UESubsData.EeSubscriptionCollection[subsId].AmfSubscriptionInfos = modifiedData
-	return nil
+	return setAmfSubscriptionInfos(ctx, ueId, subsId, UESubsData.EeSubscriptionCollection[subsId], modifiedData)
 }
 
-func HandleGetAmfSubscriptionInfo(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleGetAmfSubscriptionInfo(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle GetAmfSubscriptionInfo")
 
 	ueId := request.Params["ueId"]
 	subsId := request.Params["subsId"]
 
-	response, problemDetails := GetAmfSubscriptionInfoProcedure(subsId, ueId)
+	response, problemDetails := GetAmfSubscriptionInfoProcedure(ctx, subsId, ueId)
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", AMFSubscriptions, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusOK, nil, response)
@@ -2019,17 +2108,14 @@ func HandleGetAmfSubscriptionInfo(request *httpwrapper.Request) *httpwrapper.Res
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func GetAmfSubscriptionInfoProcedure(subsId string, ueId string) (*[]models.AmfSubscriptionInfo,
+func GetAmfSubscriptionInfoProcedure(ctx context.Context, subsId string, ueId string) (*[]models.AmfSubscriptionInfo,
 	*models.ProblemDetails,
 ) {
-	udrSelf := udr_context.UDR_Self()
-
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
 		return nil, utils.ProblemDetailsUserNotFound()
 	}
 
-	UESubsData := value.(*udr_context.UESubsData)
 	_, ok = UESubsData.EeSubscriptionCollection[subsId]
 
 	if !ok {
@@ -2042,13 +2128,13 @@ func GetAmfSubscriptionInfoProcedure(subsId string, ueId string) (*[]models.AmfS
 	return &UESubsData.EeSubscriptionCollection[subsId].AmfSubscriptionInfos, nil
 }
 
-func HandleQueryEEData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryEEData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryEEData")
 
 	ueId := request.Params["ueId"]
 	collName := "subscriptionData.eeProfileData"
 
-	response, problemDetails := QueryEEDataProcedure(collName, ueId)
+	response, problemDetails := QueryEEDataProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", EEProfileData, "SUCCESS")
@@ -2063,9 +2149,9 @@ func HandleQueryEEData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryEEDataProcedure(collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+func QueryEEDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
-	eeProfileData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	eeProfileData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -2076,13 +2162,13 @@ func QueryEEDataProcedure(collName string, ueId string) (*map[string]interface{}
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleRemoveEeGroupSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleRemoveEeGroupSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle RemoveEeGroupSubscriptions")
 
 	ueGroupId := request.Params["ueGroupId"]
 	subsId := request.Params["subsId"]
 
-	problemDetails := RemoveEeGroupSubscriptionsProcedure(ueGroupId, subsId)
+	problemDetails := RemoveEeGroupSubscriptionsProcedure(ctx, ueGroupId, subsId)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("delete", GroupData, "SUCCESS")
@@ -2092,68 +2178,86 @@ func HandleRemoveEeGroupSubscriptions(request *httpwrapper.Request) *httpwrapper
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func RemoveEeGroupSubscriptionsProcedure(ueGroupId string, subsId string) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	value, ok := udrSelf.UEGroupCollection.Load(ueGroupId)
+func RemoveEeGroupSubscriptionsProcedure(ctx context.Context, ueGroupId string, subsId string) *models.ProblemDetails {
+	UEGroupSubsData, ok := loadUEGroupSubsData(ctx, ueGroupId)
 	if !ok {
 		return utils.ProblemDetailsUserNotFound()
 	}
 
-	UEGroupSubsData := value.(*udr_context.UEGroupSubsData)
 	_, ok = UEGroupSubsData.EeSubscriptions[subsId]
 
 	if !ok {
 		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
+	if err := deleteEeGroupSubscription(ctx, ueGroupId, subsId); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsSystemFailure(err.Error())
+	}
 	delete(UEGroupSubsData.EeSubscriptions, subsId)
+	for _, ueId := range UEGroupSubsData.MemberUeIds[subsId] {
+		removeMemberEeSubscription(ctx, ueId, subsId)
+	}
+	delete(UEGroupSubsData.MemberUeIds, subsId)
 
 	return nil
 }
 
-func HandleUpdateEeGroupSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleUpdateEeGroupSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle UpdateEeGroupSubscriptions")
 
 	ueGroupId := request.Params["ueGroupId"]
 	subsId := request.Params["subsId"]
 	EeSubscription := request.Body.(models.EeSubscription)
+	clampSubscriptionExpiry(&EeSubscription, time.Now())
 
-	problemDetails := UpdateEeGroupSubscriptionsProcedure(ueGroupId, subsId, EeSubscription)
+	problemDetails := UpdateEeGroupSubscriptionsProcedure(ctx, ueGroupId, subsId, EeSubscription)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("update", GroupData, "SUCCESS")
-		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+		return httpwrapper.NewResponse(http.StatusOK, nil, EeSubscription)
 	}
 	stats.IncrementUdrSubscriptionDataStats("update", GroupData, "FAILURE")
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func UpdateEeGroupSubscriptionsProcedure(ueGroupId string, subsId string,
+func UpdateEeGroupSubscriptionsProcedure(ctx context.Context, ueGroupId string, subsId string,
 	EeSubscription models.EeSubscription,
 ) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	value, ok := udrSelf.UEGroupCollection.Load(ueGroupId)
+	UEGroupSubsData, ok := loadUEGroupSubsData(ctx, ueGroupId)
 	if !ok {
 		return utils.ProblemDetailsUserNotFound()
 	}
 
-	UEGroupSubsData := value.(*udr_context.UEGroupSubsData)
 	_, ok = UEGroupSubsData.EeSubscriptions[subsId]
 
 	if !ok {
 		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
+	memberUeIds := UEGroupSubsData.MemberUeIds[subsId]
+	if err := putEeGroupSubscription(ctx, ueGroupId, subsId, &EeSubscription, memberUeIds); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsSystemFailure(err.Error())
+	}
 	UEGroupSubsData.EeSubscriptions[subsId] = &EeSubscription
+	for _, ueId := range memberUeIds {
+		addMemberEeSubscription(ctx, ueId, subsId, &EeSubscription)
+	}
 
 	return nil
 }
 
-func HandleCreateEeGroupSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateEeGroupSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateEeGroupSubscriptions")
 
 	ueGroupId := request.Params["ueGroupId"]
 	EeSubscription := request.Body.(models.EeSubscription)
+	clampSubscriptionExpiry(&EeSubscription, time.Now())
 
-	locationHeader := CreateEeGroupSubscriptionsProcedure(ueGroupId, EeSubscription)
+	locationHeader, problemDetails := CreateEeGroupSubscriptionsProcedure(ctx, ueGroupId, EeSubscription)
+	if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("create", GroupData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
 
 	headers := http.Header{}
 	headers.Set("Location", locationHeader)
@@ -2161,37 +2265,52 @@ func HandleCreateEeGroupSubscriptions(request *httpwrapper.Request) *httpwrapper
 	return httpwrapper.NewResponse(http.StatusCreated, headers, EeSubscription)
 }
 
-func CreateEeGroupSubscriptionsProcedure(ueGroupId string, EeSubscription models.EeSubscription) string {
+func CreateEeGroupSubscriptionsProcedure(ctx context.Context, ueGroupId string, EeSubscription models.EeSubscription) (string,
+	*models.ProblemDetails,
+) {
 	udrSelf := udr_context.UDR_Self()
 
-	value, ok := udrSelf.UEGroupCollection.Load(ueGroupId)
+	UEGroupSubsData, ok := loadUEGroupSubsData(ctx, ueGroupId)
 	if !ok {
-		udrSelf.UEGroupCollection.Store(ueGroupId, new(udr_context.UEGroupSubsData))
-		value, _ = udrSelf.UEGroupCollection.Load(ueGroupId)
+		UEGroupSubsData = new(udr_context.UEGroupSubsData)
 	}
-	UEGroupSubsData := value.(*udr_context.UEGroupSubsData)
 	if UEGroupSubsData.EeSubscriptions == nil {
 		UEGroupSubsData.EeSubscriptions = make(map[string]*models.EeSubscription)
 	}
 
-	newSubscriptionID := strconv.Itoa(udrSelf.EeSubscriptionIDGenerator)
+	newSubscriptionID := udrSelf.NewSubscriptionID()
+
+	memberUeIds := groupMemberUeIds(ctx, ueGroupId)
+	if err := putEeGroupSubscription(ctx, ueGroupId, newSubscriptionID, &EeSubscription, memberUeIds); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return "", utils.ProblemDetailsSystemFailure(err.Error())
+	}
 	UEGroupSubsData.EeSubscriptions[newSubscriptionID] = &EeSubscription
-	udrSelf.EeSubscriptionIDGenerator++
+
+	// Register the subscription against every member UE as well, so events
+	// reported for a member reach the group subscriber.
+	if UEGroupSubsData.MemberUeIds == nil {
+		UEGroupSubsData.MemberUeIds = make(map[string][]string)
+	}
+	for _, ueId := range memberUeIds {
+		addMemberEeSubscription(ctx, ueId, newSubscriptionID, &EeSubscription)
+	}
+	UEGroupSubsData.MemberUeIds[newSubscriptionID] = memberUeIds
 
 	/* Contains the URI of the newly created resource, according
 	   to the structure: {apiRoot}/subscription-data/group-data/{ueGroupId}/ee-subscriptions */
 	locationHeader := fmt.Sprintf("%s/subscription-data/group-data/%s/ee-subscriptions/%s",
 		udrSelf.GetIPv4GroupUri(udr_context.NUDR_DR), ueGroupId, newSubscriptionID)
 
-	return locationHeader
+	return locationHeader, nil
 }
 
-func HandleQueryEeGroupSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryEeGroupSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryEeGroupSubscriptions")
 
 	ueGroupId := request.Params["ueGroupId"]
 
-	response, problemDetails := QueryEeGroupSubscriptionsProcedure(ueGroupId)
+	response, problemDetails := QueryEeGroupSubscriptionsProcedure(ctx, ueGroupId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", GroupData, "SUCCESS")
@@ -2206,15 +2325,12 @@ func HandleQueryEeGroupSubscriptions(request *httpwrapper.Request) *httpwrapper.
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryEeGroupSubscriptionsProcedure(ueGroupId string) ([]models.EeSubscription, *models.ProblemDetails) {
-	udrSelf := udr_context.UDR_Self()
-
-	value, ok := udrSelf.UEGroupCollection.Load(ueGroupId)
+func QueryEeGroupSubscriptionsProcedure(ctx context.Context, ueGroupId string) ([]models.EeSubscription, *models.ProblemDetails) {
+	UEGroupSubsData, ok := loadUEGroupSubsData(ctx, ueGroupId)
 	if !ok {
 		return nil, utils.ProblemDetailsUserNotFound()
 	}
 
-	UEGroupSubsData := value.(*udr_context.UEGroupSubsData)
 	var eeSubscriptionSlice []models.EeSubscription
 
 	for _, v := range UEGroupSubsData.EeSubscriptions {
@@ -2223,13 +2339,13 @@ func QueryEeGroupSubscriptionsProcedure(ueGroupId string) ([]models.EeSubscripti
 	return eeSubscriptionSlice, nil
 }
 
-func HandleRemoveeeSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleRemoveeeSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle RemoveeeSubscriptions")
 
 	ueId := request.Params["ueId"]
 	subsId := request.Params["subsId"]
 
-	problemDetails := RemoveeeSubscriptionsProcedure(ueId, subsId)
+	problemDetails := RemoveeeSubscriptionsProcedure(ctx, ueId, subsId)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("delete", EESubscriptions, "SUCCESS")
@@ -2239,67 +2355,79 @@ func HandleRemoveeeSubscriptions(request *httpwrapper.Request) *httpwrapper.Resp
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func RemoveeeSubscriptionsProcedure(ueId string, subsId string) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+func RemoveeeSubscriptionsProcedure(ctx context.Context, ueId string, subsId string) *models.ProblemDetails {
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
 		return utils.ProblemDetailsUserNotFound()
 	}
 
-	UESubsData := value.(*udr_context.UESubsData)
 	_, ok = UESubsData.EeSubscriptionCollection[subsId]
 
 	if !ok {
 		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
+	if err := deleteEeSubscription(ctx, ueId, subsId); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsSystemFailure(err.Error())
+	}
 	delete(UESubsData.EeSubscriptionCollection, subsId)
 	return nil
 }
 
-func HandleUpdateEesubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleUpdateEesubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle UpdateEesubscriptions")
 
 	ueId := request.Params["ueId"]
 	subsId := request.Params["subsId"]
 	EeSubscription := request.Body.(models.EeSubscription)
+	clampSubscriptionExpiry(&EeSubscription, time.Now())
 
-	problemDetails := UpdateEesubscriptionsProcedure(ueId, subsId, EeSubscription)
+	problemDetails := UpdateEesubscriptionsProcedure(ctx, ueId, subsId, EeSubscription)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("update", EESubscriptions, "SUCCESS")
-		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+		return httpwrapper.NewResponse(http.StatusOK, nil, EeSubscription)
 	}
 	stats.IncrementUdrSubscriptionDataStats("update", EESubscriptions, "FAILURE")
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func UpdateEesubscriptionsProcedure(ueId string, subsId string,
+func UpdateEesubscriptionsProcedure(ctx context.Context, ueId string, subsId string,
 	EeSubscription models.EeSubscription,
 ) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
 		return utils.ProblemDetailsUserNotFound()
 	}
 
-	UESubsData := value.(*udr_context.UESubsData)
-	_, ok = UESubsData.EeSubscriptionCollection[subsId]
+	eeSubscriptionCollection, ok := UESubsData.EeSubscriptionCollection[subsId]
 
 	if !ok {
 		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
-	UESubsData.EeSubscriptionCollection[subsId].EeSubscriptions = &EeSubscription
+	updated := *eeSubscriptionCollection
+	updated.EeSubscriptions = &EeSubscription
+	if err := putEeSubscription(ctx, ueId, subsId, &updated); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsSystemFailure(err.Error())
+	}
+	eeSubscriptionCollection.EeSubscriptions = &EeSubscription
 
 	return nil
 }
 
-func HandleCreateEeSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateEeSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateEeSubscriptions")
 
 	ueId := request.Params["ueId"]
 	EeSubscription := request.Body.(models.EeSubscription)
+	clampSubscriptionExpiry(&EeSubscription, time.Now())
 
-	locationHeader := CreateEeSubscriptionsProcedure(ueId, EeSubscription)
+	locationHeader, problemDetails := CreateEeSubscriptionsProcedure(ctx, ueId, EeSubscription)
+	if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("create", EESubscriptions, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
 
 	headers := http.Header{}
 	headers.Set("Location", locationHeader)
@@ -2307,38 +2435,31 @@ func HandleCreateEeSubscriptions(request *httpwrapper.Request) *httpwrapper.Resp
 	return httpwrapper.NewResponse(http.StatusCreated, headers, EeSubscription)
 }
 
-func CreateEeSubscriptionsProcedure(ueId string, EeSubscription models.EeSubscription) string {
+func CreateEeSubscriptionsProcedure(ctx context.Context, ueId string, EeSubscription models.EeSubscription) (string, *models.ProblemDetails) {
 	udrSelf := udr_context.UDR_Self()
 
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
-	if !ok {
-		udrSelf.UESubsCollection.Store(ueId, new(udr_context.UESubsData))
-		value, _ = udrSelf.UESubsCollection.Load(ueId)
-	}
-	UESubsData := value.(*udr_context.UESubsData)
-	if UESubsData.EeSubscriptionCollection == nil {
-		UESubsData.EeSubscriptionCollection = make(map[string]*udr_context.EeSubscriptionCollection)
-	}
+	newSubscriptionID := udrSelf.NewSubscriptionID()
 
-	newSubscriptionID := strconv.Itoa(udrSelf.EeSubscriptionIDGenerator)
-	UESubsData.EeSubscriptionCollection[newSubscriptionID] = new(udr_context.EeSubscriptionCollection)
-	UESubsData.EeSubscriptionCollection[newSubscriptionID].EeSubscriptions = &EeSubscription
-	udrSelf.EeSubscriptionIDGenerator++
+	eeSubscriptionCollection := &udr_context.EeSubscriptionCollection{EeSubscriptions: &EeSubscription}
+	if err := putEeSubscription(ctx, ueId, newSubscriptionID, eeSubscriptionCollection); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return "", utils.ProblemDetailsSystemFailure(err.Error())
+	}
 
 	/* Contains the URI of the newly created resource, according
 	   to the structure: {apiRoot}/subscription-data/{ueId}/context-data/ee-subscriptions/{subsId} */
 	locationHeader := fmt.Sprintf("%s/subscription-data/%s/context-data/ee-subscriptions/%s",
 		udrSelf.GetIPv4GroupUri(udr_context.NUDR_DR), ueId, newSubscriptionID)
 
-	return locationHeader
+	return locationHeader, nil
 }
 
-func HandleQueryeesubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryeesubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle Queryeesubscriptions")
 
 	ueId := request.Params["ueId"]
 
-	response, problemDetails := QueryeesubscriptionsProcedure(ueId)
+	response, problemDetails := QueryeesubscriptionsProcedure(ctx, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", EESubscriptions, "SUCCESS")
@@ -2353,15 +2474,11 @@ func HandleQueryeesubscriptions(request *httpwrapper.Request) *httpwrapper.Respo
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryeesubscriptionsProcedure(ueId string) ([]models.EeSubscription, *models.ProblemDetails) {
-	udrSelf := udr_context.UDR_Self()
-
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+func QueryeesubscriptionsProcedure(ctx context.Context, ueId string) ([]models.EeSubscription, *models.ProblemDetails) {
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
 		return nil, utils.ProblemDetailsUserNotFound()
 	}
-
-	UESubsData := value.(*udr_context.UESubsData)
 	var eeSubscriptionSlice []models.EeSubscription
 
 	for _, v := range UESubsData.EeSubscriptionCollection {
@@ -2370,14 +2487,14 @@ func QueryeesubscriptionsProcedure(ueId string) ([]models.EeSubscription, *model
 	return eeSubscriptionSlice, nil
 }
 
-func HandlePatchOperSpecData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePatchOperSpecData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PatchOperSpecData")
 
 	collName := "subscriptionData.operatorSpecificData"
 	ueId := request.Params["ueId"]
 	patchItem := request.Body.([]models.PatchItem)
 
-	problemDetails := PatchOperSpecDataProcedure(collName, ueId, patchItem)
+	problemDetails := PatchOperSpecDataProcedure(ctx, collName, ueId, patchItem)
 
 	if problemDetails == nil {
 		stats.IncrementUdrPolicyDataStats("update", OperatorSpecificData, "SUCCESS")
@@ -2387,10 +2504,10 @@ func HandlePatchOperSpecData(request *httpwrapper.Request) *httpwrapper.Response
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func PatchOperSpecDataProcedure(collName string, ueId string, patchItem []models.PatchItem) *models.ProblemDetails {
+func PatchOperSpecDataProcedure(ctx context.Context, collName string, ueId string, patchItem []models.PatchItem) *models.ProblemDetails {
 	filter := bson.M{"ueId": ueId}
 
-	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Errorln(errGetOne)
 	}
@@ -2400,26 +2517,26 @@ func PatchOperSpecDataProcedure(collName string, ueId string, patchItem []models
 		logger.DataRepoLog.Errorln(err)
 	}
 
-	failure := CommonDBClient.RestfulAPIJSONPatch(collName, filter, patchJSON)
+	failure := CommonDBClient.RestfulAPIJSONPatch(ctx, collName, filter, patchJSON)
 
 	if failure == nil {
-		newValue, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		newValue, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOne != nil {
 			logger.DataRepoLog.Errorln(errGetOne)
 		}
-		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, patchItem, origValue, newValue)
+		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, origValue, newValue)
 		return nil
 	}
 	return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
 }
 
-func HandleQueryOperSpecData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryOperSpecData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryOperSpecData")
 
 	ueId := request.Params["ueId"]
 	collName := "subscriptionData.operatorSpecificData"
 
-	response, problemDetails := QueryOperSpecDataProcedure(collName, ueId)
+	response, problemDetails := QueryOperSpecDataProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrPolicyDataStats("get", OperatorSpecificData, "SUCCESS")
@@ -2434,10 +2551,10 @@ func HandleQueryOperSpecData(request *httpwrapper.Request) *httpwrapper.Response
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryOperSpecDataProcedure(collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+func QueryOperSpecDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	operatorSpecificDataContainer, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	operatorSpecificDataContainer, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -2450,13 +2567,13 @@ func QueryOperSpecDataProcedure(collName string, ueId string) (*map[string]inter
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleGetppData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleGetppData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle GetppData")
 
 	collName := "subscriptionData.ppData"
 	ueId := request.Params["ueId"]
 
-	response, problemDetails := GetppDataProcedure(collName, ueId)
+	response, problemDetails := GetppDataProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", PPData, "SUCCESS")
@@ -2471,10 +2588,10 @@ func HandleGetppData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func GetppDataProcedure(collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+func GetppDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	ppData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	ppData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -2485,13 +2602,17 @@ func GetppDataProcedure(collName string, ueId string) (*map[string]interface{},
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleQueryProvisionedData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQueryProvisionedData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryProvisionedData")
 
 	ueId := request.Params["ueId"]
 	servingPlmnId := request.Params["servingPlmnId"]
+	var dataSetNames []string
+	for _, value := range request.Query["dataset-names"] {
+		dataSetNames = append(dataSetNames, strings.Split(value, ",")...)
+	}
 
-	response, problemDetails := QueryProvisionedDataProcedure(ueId, servingPlmnId)
+	response, problemDetails := QueryProvisionedDataProcedure(ctx, ueId, servingPlmnId, dataSetNames)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", ProvisionedData, "SUCCESS")
@@ -2506,12 +2627,14 @@ func HandleQueryProvisionedData(request *httpwrapper.Request) *httpwrapper.Respo
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryProvisionedDataProcedure(ueId string, servingPlmnId string) (*models.ProvisionedDataSets, *models.ProblemDetails) {
+func QueryProvisionedDataProcedure(ctx context.Context, ueId string, servingPlmnId string,
+	dataSetNames []string,
+) (map[string]interface{}, *models.ProblemDetails) {
 	provisionedDataSets := models.NewProvisionedDataSets()
 	{
 		collName := "subscriptionData.provisionedData.amData"
 		filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
-		accessAndMobilitySubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		accessAndMobilitySubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOne != nil {
 			logger.DataRepoLog.Warnln(errGetOne)
 		}
@@ -2529,7 +2652,7 @@ func QueryProvisionedDataProcedure(ueId string, servingPlmnId string) (*models.P
 	{
 		collName := "subscriptionData.provisionedData.smfSelectionSubscriptionData"
 		filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
-		smfSelectionSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		smfSelectionSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOne != nil {
 			logger.DataRepoLog.Warnln(errGetOne)
 		}
@@ -2547,7 +2670,7 @@ func QueryProvisionedDataProcedure(ueId string, servingPlmnId string) (*models.P
 	{
 		collName := "subscriptionData.provisionedData.smsData"
 		filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
-		smsSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		smsSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOne != nil {
 			logger.DataRepoLog.Warnln(errGetOne)
 		}
@@ -2565,7 +2688,7 @@ func QueryProvisionedDataProcedure(ueId string, servingPlmnId string) (*models.P
 	{
 		collName := "subscriptionData.provisionedData.smData"
 		filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
-		sessionManagementSubscriptionDatas, errGetMany := CommonDBClient.RestfulAPIGetMany(collName, filter)
+		sessionManagementSubscriptionDatas, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, collName, filter)
 		if errGetMany != nil {
 			logger.DataRepoLog.Warnln(errGetMany)
 		}
@@ -2583,7 +2706,7 @@ func QueryProvisionedDataProcedure(ueId string, servingPlmnId string) (*models.P
 	{
 		collName := "subscriptionData.provisionedData.traceData"
 		filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
-		traceData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		traceData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOne != nil {
 			logger.DataRepoLog.Warnln(errGetOne)
 		}
@@ -2607,7 +2730,7 @@ func QueryProvisionedDataProcedure(ueId string, servingPlmnId string) (*models.P
 	{
 		collName := "subscriptionData.provisionedData.smsMngData"
 		filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
-		smsManagementSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		smsManagementSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOne != nil {
 			logger.DataRepoLog.Warnln(errGetOne)
 		}
@@ -2622,20 +2745,26 @@ func QueryProvisionedDataProcedure(ueId string, servingPlmnId string) (*models.P
 		}
 	}
 
-	if !reflect.DeepEqual(provisionedDataSets, models.NewProvisionedDataSets()) {
-		return provisionedDataSets, nil
+	sidelinkData := querySidelinkDataSets(ctx, ueId, dataSetNames)
+	if reflect.DeepEqual(provisionedDataSets, models.NewProvisionedDataSets()) && len(sidelinkData) == 0 {
+		return nil, utils.ProblemDetailsUserNotFound()
 	}
-	return nil, utils.ProblemDetailsUserNotFound()
+	response, err := mergeProvisionedDataSets(provisionedDataSets, sidelinkData)
+	if err != nil {
+		logger.DataRepoLog.Errorf("encode provisioned datasets failed: %+v", err)
+		return nil, utils.ProblemDetailsSystemFailure(err.Error())
+	}
+	return response, nil
 }
 
-func HandleModifyPpData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleModifyPpData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle ModifyPpData")
 
 	collName := "subscriptionData.ppData"
 	patchItem := request.Body.([]models.PatchItem)
 	ueId := request.Params["ueId"]
 
-	problemDetails := ModifyPpDataProcedure(collName, ueId, patchItem)
+	problemDetails := ModifyPpDataProcedure(ctx, collName, ueId, patchItem)
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("update", PPData, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
@@ -2644,10 +2773,10 @@ func HandleModifyPpData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func ModifyPpDataProcedure(collName string, ueId string, patchItem []models.PatchItem) *models.ProblemDetails {
+func ModifyPpDataProcedure(ctx context.Context, collName string, ueId string, patchItem []models.PatchItem) *models.ProblemDetails {
 	filter := bson.M{"ueId": ueId}
 
-	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -2657,26 +2786,26 @@ func ModifyPpDataProcedure(collName string, ueId string, patchItem []models.Patc
 		logger.DataRepoLog.Errorln(err)
 	}
 
-	failure := CommonDBClient.RestfulAPIJSONPatch(collName, filter, patchJSON)
+	failure := CommonDBClient.RestfulAPIJSONPatch(ctx, collName, filter, patchJSON)
 
 	if failure == nil {
-		newValue, errGetOneNew := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		newValue, errGetOneNew := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOneNew != nil {
 			logger.DataRepoLog.Warnln(errGetOneNew)
 		}
-		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, patchItem, origValue, newValue)
+		PreHandleOnDataChangeNotify(ueId, CurrentResourceUri, origValue, newValue)
 		return nil
 	}
 	return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
 }
 
-func HandleGetIdentityData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleGetIdentityData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle GetIdentityData")
 
 	ueId := request.Params["ueId"]
 	collName := "subscriptionData.identityData"
 
-	response, problemDetails := GetIdentityDataProcedure(collName, ueId)
+	response, problemDetails := GetIdentityDataProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", IdentityData, "SUCCESS")
@@ -2691,10 +2820,10 @@ func HandleGetIdentityData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func GetIdentityDataProcedure(collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+func GetIdentityDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	identityData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	identityData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -2705,13 +2834,13 @@ func GetIdentityDataProcedure(collName string, ueId string) (*map[string]interfa
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleGetOdbData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleGetOdbData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle GetOdbData")
 
 	ueId := request.Params["ueId"]
 	collName := "subscriptionData.operatorDeterminedBarringData"
 
-	response, problemDetails := GetOdbDataProcedure(collName, ueId)
+	response, problemDetails := GetOdbDataProcedure(ctx, collName, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", OperatorDeterminedBarringData, "SUCCESS")
@@ -2726,10 +2855,10 @@ func HandleGetOdbData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func GetOdbDataProcedure(collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+func GetOdbDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	operatorDeterminedBarringData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	operatorDeterminedBarringData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -2740,7 +2869,7 @@ func GetOdbDataProcedure(collName string, ueId string) (*map[string]interface{},
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleGetSharedData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleGetSharedData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle GetSharedData")
 
 	var sharedDataIds []string
@@ -2750,9 +2879,9 @@ func HandleGetSharedData(request *httpwrapper.Request) *httpwrapper.Response {
 			sharedDataIds = strings.Split(sharedDataIds[0], ",")
 		}
 	}
-	collName := "subscriptionData.sharedData"
+	collName := SUBSCDATA_SHAREDDATA
 
-	response, problemDetails := GetSharedDataProcedure(collName, sharedDataIds)
+	response, problemDetails := GetSharedDataProcedure(ctx, collName, sharedDataIds)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", SharedData, "SUCCESS")
@@ -2767,13 +2896,13 @@ func HandleGetSharedData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func GetSharedDataProcedure(collName string, sharedDataIds []string) (*[]map[string]interface{},
+func GetSharedDataProcedure(ctx context.Context, collName string, sharedDataIds []string) (*[]map[string]interface{},
 	*models.ProblemDetails,
 ) {
 	var sharedDataArray []map[string]interface{}
 	for _, sharedDataId := range sharedDataIds {
 		filter := bson.M{"sharedDataId": sharedDataId}
-		sharedData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+		sharedData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 		if errGetOne != nil {
 			logger.DataRepoLog.Warnln(errGetOne)
 		}
@@ -2788,13 +2917,13 @@ func GetSharedDataProcedure(collName string, sharedDataIds []string) (*[]map[str
 	return nil, utils.ProblemDetailsDataNotFound()
 }
 
-func HandleRemovesdmSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleRemovesdmSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle RemovesdmSubscriptions")
 
 	ueId := request.Params["ueId"]
 	subsId := request.Params["subsId"]
 
-	problemDetails := RemovesdmSubscriptionsProcedure(ueId, subsId)
+	problemDetails := RemovesdmSubscriptionsProcedure(ctx, ueId, subsId)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("delete", SDMSubscriptions, "SUCCESS")
@@ -2804,72 +2933,74 @@ func HandleRemovesdmSubscriptions(request *httpwrapper.Request) *httpwrapper.Res
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func RemovesdmSubscriptionsProcedure(ueId string, subsId string) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+func RemovesdmSubscriptionsProcedure(ctx context.Context, ueId string, subsId string) *models.ProblemDetails {
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
 		return utils.ProblemDetailsUserNotFound()
 	}
-
-	UESubsData := value.(*udr_context.UESubsData)
-	UESubsData.Mtx.Lock()
-	defer UESubsData.Mtx.Unlock()
-
 	if _, ok = UESubsData.SdmSubscriptions[subsId]; !ok {
 		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
+	if err := deleteSdmSubscription(ctx, ueId, subsId); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsSystemFailure(err.Error())
+	}
 	delete(UESubsData.SdmSubscriptions, subsId)
 
 	return nil
 }
 
-func HandleUpdatesdmsubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleUpdatesdmsubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle Updatesdmsubscriptions")
 
 	ueId := request.Params["ueId"]
 	subsId := request.Params["subsId"]
 	SdmSubscription := request.Body.(models.SdmSubscription)
+	clampSubscriptionExpiry(&SdmSubscription, time.Now())
 
-	problemDetails := UpdatesdmsubscriptionsProcedure(ueId, subsId, SdmSubscription)
+	response, problemDetails := UpdatesdmsubscriptionsProcedure(ctx, ueId, subsId, SdmSubscription)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("update", SDMSubscriptions, "SUCCESS")
-		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
 	}
 	stats.IncrementUdrSubscriptionDataStats("update", SDMSubscriptions, "FAILURE")
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func UpdatesdmsubscriptionsProcedure(ueId string, subsId string,
+func UpdatesdmsubscriptionsProcedure(ctx context.Context, ueId string, subsId string,
 	SdmSubscription models.SdmSubscription,
-) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+) (*models.SdmSubscription, *models.ProblemDetails) {
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
-		return utils.ProblemDetailsUserNotFound()
+		return nil, utils.ProblemDetailsUserNotFound()
 	}
-
-	UESubsData := value.(*udr_context.UESubsData)
-	UESubsData.Mtx.Lock()
-	defer UESubsData.Mtx.Unlock()
-
 	if _, ok = UESubsData.SdmSubscriptions[subsId]; !ok {
-		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
+		return nil, utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
 	SdmSubscription.SetSubscriptionId(subsId)
+	if err := putSdmSubscription(ctx, ueId, subsId, &SdmSubscription); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return nil, utils.ProblemDetailsSystemFailure(err.Error())
+	}
 	UESubsData.SdmSubscriptions[subsId] = &SdmSubscription
 
-	return nil
+	return &SdmSubscription, nil
 }
 
-func HandleCreateSdmSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateSdmSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateSdmSubscriptions")
 
 	SdmSubscription := request.Body.(models.SdmSubscription)
+	clampSubscriptionExpiry(&SdmSubscription, time.Now())
 	collName := SUBSCDATA_CTXDATA_AMF_NON3GPPACCESS
 	ueId := request.Params["ueId"]
 
-	locationHeader, SdmSubscription := CreateSdmSubscriptionsProcedure(SdmSubscription, collName, ueId)
+	locationHeader, SdmSubscription, problemDetails := CreateSdmSubscriptionsProcedure(ctx, SdmSubscription, collName, ueId)
+	if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("create", SDMSubscriptions, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
 
 	headers := http.Header{}
 	headers.Set("Location", locationHeader)
@@ -2877,42 +3008,33 @@ func HandleCreateSdmSubscriptions(request *httpwrapper.Request) *httpwrapper.Res
 	return httpwrapper.NewResponse(http.StatusCreated, headers, SdmSubscription)
 }
 
-func CreateSdmSubscriptionsProcedure(SdmSubscription models.SdmSubscription,
+func CreateSdmSubscriptionsProcedure(ctx context.Context, SdmSubscription models.SdmSubscription,
 	collName string, ueId string,
-) (string, models.SdmSubscription) {
+) (string, models.SdmSubscription, *models.ProblemDetails) {
 	udrSelf := udr_context.UDR_Self()
 
-	// LoadOrStore keeps concurrent creators for the same ueId on one instance;
-	// a Load/Store pair would let two goroutines install competing values.
-	value, _ := udrSelf.UESubsCollection.LoadOrStore(ueId, new(udr_context.UESubsData))
-	UESubsData := value.(*udr_context.UESubsData)
-
-	// Allocated before taking the lock: the generator is shared across every
-	// UE, so it must not be serialised behind a per-UE lock.
-	newSubscriptionID := strconv.FormatInt(udrSelf.SdmSubscriptionIDGenerator.Add(1), 10)
+	newSubscriptionID := udrSelf.NewSubscriptionID()
 	SdmSubscription.SetSubscriptionId(newSubscriptionID)
 
-	UESubsData.Mtx.Lock()
-	defer UESubsData.Mtx.Unlock()
-	if UESubsData.SdmSubscriptions == nil {
-		UESubsData.SdmSubscriptions = make(map[string]*models.SdmSubscription)
+	if err := putSdmSubscription(ctx, ueId, newSubscriptionID, &SdmSubscription); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return "", SdmSubscription, utils.ProblemDetailsSystemFailure(err.Error())
 	}
-	UESubsData.SdmSubscriptions[newSubscriptionID] = &SdmSubscription
 
 	/* Contains the URI of the newly created resource, according
 	   to the structure: {apiRoot}/subscription-data/{ueId}/context-data/sdm-subscriptions/{subsId}' */
 	locationHeader := fmt.Sprintf("%s/subscription-data/%s/context-data/sdm-subscriptions/%s",
 		udrSelf.GetIPv4GroupUri(udr_context.NUDR_DR), ueId, newSubscriptionID)
 
-	return locationHeader, SdmSubscription
+	return locationHeader, SdmSubscription, nil
 }
 
-func HandleQuerysdmsubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQuerysdmsubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle Querysdmsubscriptions")
 
 	ueId := request.Params["ueId"]
 
-	response, problemDetails := QuerysdmsubscriptionsProcedure(ueId)
+	response, problemDetails := QuerysdmsubscriptionsProcedure(ctx, ueId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", SDMSubscriptions, "SUCCESS")
@@ -2927,26 +3049,20 @@ func HandleQuerysdmsubscriptions(request *httpwrapper.Request) *httpwrapper.Resp
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QuerysdmsubscriptionsProcedure(ueId string) (*[]models.SdmSubscription, *models.ProblemDetails) {
-	udrSelf := udr_context.UDR_Self()
-
-	value, ok := udrSelf.UESubsCollection.Load(ueId)
+func QuerysdmsubscriptionsProcedure(ctx context.Context, ueId string) (*[]models.SdmSubscription, *models.ProblemDetails) {
+	UESubsData, ok := loadUESubsData(ctx, ueId)
 	if !ok {
 		return nil, utils.ProblemDetailsUserNotFound()
 	}
-
-	UESubsData := value.(*udr_context.UESubsData)
 	var sdmSubscriptionSlice []models.SdmSubscription
 
-	UESubsData.Mtx.RLock()
 	for _, v := range UESubsData.SdmSubscriptions {
 		sdmSubscriptionSlice = append(sdmSubscriptionSlice, *v)
 	}
-	UESubsData.Mtx.RUnlock()
 	return &sdmSubscriptionSlice, nil
 }
 
-func HandleQuerySmData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQuerySmData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QuerySmData")
 
 	collName := "subscriptionData.provisionedData.smData"
@@ -2973,12 +3089,12 @@ func HandleQuerySmData(request *httpwrapper.Request) *httpwrapper.Response {
 	}
 
 	dnn := request.Query.Get("dnn")
-	response := QuerySmDataProcedure(collName, ueId, servingPlmnId, singleNssai, dnn)
+	response := QuerySmDataProcedure(ctx, collName, ueId, servingPlmnId, singleNssai, dnn)
 	stats.IncrementUdrSubscriptionDataStats("get", SMData, "SUCCESS")
 	return httpwrapper.NewResponse(http.StatusOK, nil, response)
 }
 
-func QuerySmDataProcedure(collName string, ueId string, servingPlmnId string,
+func QuerySmDataProcedure(ctx context.Context, collName string, ueId string, servingPlmnId string,
 	singleNssai models.Snssai, dnn string,
 ) *models.SmSubsData {
 	filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
@@ -2993,7 +3109,7 @@ func QuerySmDataProcedure(collName string, ueId string, servingPlmnId string,
 		}
 	}
 
-	sessionManagementSubscriptionDatas, errGetMany := CommonDBClient.RestfulAPIGetMany(collName, filter)
+	sessionManagementSubscriptionDatas, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, collName, filter)
 	if errGetMany != nil {
 		logger.DataRepoLog.Warnln(errGetMany)
 	}
@@ -3089,7 +3205,7 @@ func addDotSafeKeyExistsFilter(filter bson.M, path, key string) {
 	}
 }
 
-func HandleCreateSmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateSmfContextNon3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateSmfContextNon3gpp")
 
 	SmfRegistration := request.Body.(models.SmfRegistration)
@@ -3100,7 +3216,7 @@ func HandleCreateSmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Re
 		logger.DataRepoLog.Warnln(err)
 	}
 
-	response, status := CreateSmfContextNon3gppProcedure(SmfRegistration, collName, ueId, pduSessionId)
+	response, status := CreateSmfContextNon3gppProcedure(ctx, SmfRegistration, collName, ueId, pduSessionId)
 
 	switch status {
 	case http.StatusCreated:
@@ -3116,7 +3232,7 @@ func HandleCreateSmfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Re
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func CreateSmfContextNon3gppProcedure(SmfRegistration models.SmfRegistration,
+func CreateSmfContextNon3gppProcedure(ctx context.Context, SmfRegistration models.SmfRegistration,
 	collName string, ueId string, pduSessionIdInt int64,
 ) (bson.M, int) {
 	putData := util.ToBsonM(SmfRegistration)
@@ -3124,7 +3240,7 @@ func CreateSmfContextNon3gppProcedure(SmfRegistration models.SmfRegistration,
 	putData["pduSessionId"] = int32(pduSessionIdInt)
 
 	filter := bson.M{"ueId": ueId, "pduSessionId": pduSessionIdInt}
-	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
@@ -3135,39 +3251,39 @@ func CreateSmfContextNon3gppProcedure(SmfRegistration models.SmfRegistration,
 	return putData, http.StatusOK
 }
 
-func HandleDeleteSmfContext(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleDeleteSmfContext(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle DeleteSmfContext")
 
 	collName := SUBSCDATA_CTXDATA_SMF_REGISTRATION
 	ueId := request.Params["ueId"]
 	pduSessionId := request.Params["pduSessionId"]
 
-	DeleteSmfContextProcedure(collName, ueId, pduSessionId)
+	DeleteSmfContextProcedure(ctx, collName, ueId, pduSessionId)
 	stats.IncrementUdrSubscriptionDataStats("delete", SMFRegistrations, "SUCCESS")
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func DeleteSmfContextProcedure(collName string, ueId string, pduSessionId string) {
+func DeleteSmfContextProcedure(ctx context.Context, collName string, ueId string, pduSessionId string) {
 	pduSessionIdInt, err := strconv.ParseInt(pduSessionId, 10, 32)
 	if err != nil {
 		logger.DataRepoLog.Error(err)
 	}
 	filter := bson.M{"ueId": ueId, "pduSessionId": pduSessionIdInt}
 
-	errDelOne := CommonDBClient.RestfulAPIDeleteOne(collName, filter)
+	errDelOne := CommonDBClient.RestfulAPIDeleteOne(ctx, collName, filter)
 	if errDelOne != nil {
 		logger.DataRepoLog.Warnln(errDelOne)
 	}
 }
 
-func HandleQuerySmfRegistration(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQuerySmfRegistration(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QuerySmfRegistration")
 
 	ueId := request.Params["ueId"]
 	pduSessionId := request.Params["pduSessionId"]
 	collName := SUBSCDATA_CTXDATA_SMF_REGISTRATION
 
-	response, problemDetails := QuerySmfRegistrationProcedure(collName, ueId, pduSessionId)
+	response, problemDetails := QuerySmfRegistrationProcedure(ctx, collName, ueId, pduSessionId)
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", SMFRegistrations, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusOK, nil, response)
@@ -3181,7 +3297,7 @@ func HandleQuerySmfRegistration(request *httpwrapper.Request) *httpwrapper.Respo
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QuerySmfRegistrationProcedure(collName string, ueId string,
+func QuerySmfRegistrationProcedure(ctx context.Context, collName string, ueId string,
 	pduSessionId string,
 ) (*map[string]interface{}, *models.ProblemDetails) {
 	pduSessionIdInt, err := strconv.ParseInt(pduSessionId, 10, 32)
@@ -3191,7 +3307,7 @@ func QuerySmfRegistrationProcedure(collName string, ueId string,
 
 	filter := bson.M{"ueId": ueId, "pduSessionId": pduSessionIdInt}
 
-	smfRegistration, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	smfRegistration, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -3202,12 +3318,12 @@ func QuerySmfRegistrationProcedure(collName string, ueId string,
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleQuerySmfRegList(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQuerySmfRegList(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QuerySmfRegList")
 
 	collName := SUBSCDATA_CTXDATA_SMF_REGISTRATION
 	ueId := request.Params["ueId"]
-	response := QuerySmfRegListProcedure(collName, ueId)
+	response := QuerySmfRegListProcedure(ctx, collName, ueId)
 
 	stats.IncrementUdrSubscriptionDataStats("get", SMFRegistrations, "SUCCESS")
 	if response == nil {
@@ -3216,9 +3332,9 @@ func HandleQuerySmfRegList(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(http.StatusOK, nil, response)
 }
 
-func QuerySmfRegListProcedure(collName string, ueId string) *[]map[string]interface{} {
+func QuerySmfRegListProcedure(ctx context.Context, collName string, ueId string) *[]map[string]interface{} {
 	filter := bson.M{"ueId": ueId}
-	smfRegList, errGetMany := CommonDBClient.RestfulAPIGetMany(collName, filter)
+	smfRegList, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, collName, filter)
 	if errGetMany != nil {
 		logger.DataRepoLog.Warnln(errGetMany)
 	}
@@ -3230,13 +3346,13 @@ func QuerySmfRegListProcedure(collName string, ueId string) *[]map[string]interf
 	return nil
 }
 
-func HandleQuerySmfSelectData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQuerySmfSelectData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QuerySmfSelectData")
 
 	collName := "subscriptionData.provisionedData.smfSelectionSubscriptionData"
 	ueId := request.Params["ueId"]
 	servingPlmnId := request.Params["servingPlmnId"]
-	response, problemDetails := QuerySmfSelectDataProcedure(collName, ueId, servingPlmnId)
+	response, problemDetails := QuerySmfSelectDataProcedure(ctx, collName, ueId, servingPlmnId)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("get", ProvisionedData, "SUCCESS")
@@ -3246,11 +3362,11 @@ func HandleQuerySmfSelectData(request *httpwrapper.Request) *httpwrapper.Respons
 	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
 }
 
-func QuerySmfSelectDataProcedure(collName string, ueId string,
+func QuerySmfSelectDataProcedure(ctx context.Context, collName string, ueId string,
 	servingPlmnId string,
 ) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
-	smfSelectionSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	smfSelectionSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -3261,55 +3377,55 @@ func QuerySmfSelectDataProcedure(collName string, ueId string,
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleCreateSmsfContext3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateSmsfContext3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateSmsfContext3gpp")
 
 	SmsfRegistration := request.Body.(models.SmsfRegistration)
 	collName := SUBSCDATA_CTXDATA_SMSF_3GPPACCESS
 	ueId := request.Params["ueId"]
 
-	CreateSmsfContext3gppProcedure(collName, ueId, SmsfRegistration)
+	CreateSmsfContext3gppProcedure(ctx, collName, ueId, SmsfRegistration)
 	stats.IncrementUdrSubscriptionDataStats("create", SMSF3GPPAccess, "SUCCESS")
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func CreateSmsfContext3gppProcedure(collName string, ueId string, SmsfRegistration models.SmsfRegistration) {
+func CreateSmsfContext3gppProcedure(ctx context.Context, collName string, ueId string, SmsfRegistration models.SmsfRegistration) {
 	putData := util.ToBsonM(SmsfRegistration)
 	putData["ueId"] = ueId
 	filter := bson.M{"ueId": ueId}
 
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
 }
 
-func HandleDeleteSmsfContext3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleDeleteSmsfContext3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle DeleteSmsfContext3gpp")
 
 	collName := SUBSCDATA_CTXDATA_SMSF_3GPPACCESS
 	ueId := request.Params["ueId"]
 
-	DeleteSmsfContext3gppProcedure(collName, ueId)
+	DeleteSmsfContext3gppProcedure(ctx, collName, ueId)
 	stats.IncrementUdrSubscriptionDataStats("delete", SMSF3GPPAccess, "SUCCESS")
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func DeleteSmsfContext3gppProcedure(collName string, ueId string) {
+func DeleteSmsfContext3gppProcedure(ctx context.Context, collName string, ueId string) {
 	filter := bson.M{"ueId": ueId}
-	errDelOne := CommonDBClient.RestfulAPIDeleteOne(collName, filter)
+	errDelOne := CommonDBClient.RestfulAPIDeleteOne(ctx, collName, filter)
 	if errDelOne != nil {
 		logger.DataRepoLog.Warnln(errDelOne)
 	}
 }
 
-func HandleQuerySmsfContext3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQuerySmsfContext3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QuerySmsfContext3gpp")
 
 	collName := SUBSCDATA_CTXDATA_SMSF_3GPPACCESS
 	ueId := request.Params["ueId"]
 
-	response, problemDetails := QuerySmsfContext3gppProcedure(collName, ueId)
+	response, problemDetails := QuerySmsfContext3gppProcedure(ctx, collName, ueId)
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", SMSF3GPPAccess, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusOK, nil, response)
@@ -3323,10 +3439,10 @@ func HandleQuerySmsfContext3gpp(request *httpwrapper.Request) *httpwrapper.Respo
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QuerySmsfContext3gppProcedure(collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+func QuerySmsfContext3gppProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	smsfRegistration, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	smsfRegistration, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -3337,55 +3453,55 @@ func QuerySmsfContext3gppProcedure(collName string, ueId string) (*map[string]in
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleCreateSmsfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleCreateSmsfContextNon3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle CreateSmsfContextNon3gpp")
 
 	SmsfRegistration := request.Body.(models.SmsfRegistration)
 	collName := SUBSCDATA_CTXDATA_SMSF_NON3GPPACCESS
 	ueId := request.Params["ueId"]
 
-	CreateSmsfContextNon3gppProcedure(SmsfRegistration, collName, ueId)
+	CreateSmsfContextNon3gppProcedure(ctx, SmsfRegistration, collName, ueId)
 	stats.IncrementUdrSubscriptionDataStats("create", SMSFNon3GPPAccess, "SUCCESS")
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func CreateSmsfContextNon3gppProcedure(SmsfRegistration models.SmsfRegistration, collName string, ueId string) {
+func CreateSmsfContextNon3gppProcedure(ctx context.Context, SmsfRegistration models.SmsfRegistration, collName string, ueId string) {
 	putData := util.ToBsonM(SmsfRegistration)
 	putData["ueId"] = ueId
 	filter := bson.M{"ueId": ueId}
 
-	_, errPutOne := CommonDBClient.RestfulAPIPutOne(collName, filter, putData)
+	_, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
 	if errPutOne != nil {
 		logger.DataRepoLog.Warnln(errPutOne)
 	}
 }
 
-func HandleDeleteSmsfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleDeleteSmsfContextNon3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle DeleteSmsfContextNon3gpp")
 
 	collName := SUBSCDATA_CTXDATA_SMSF_NON3GPPACCESS
 	ueId := request.Params["ueId"]
 
-	DeleteSmsfContextNon3gppProcedure(collName, ueId)
+	DeleteSmsfContextNon3gppProcedure(ctx, collName, ueId)
 	stats.IncrementUdrSubscriptionDataStats("delete", SMSFNon3GPPAccess, "SUCCESS")
 	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
 }
 
-func DeleteSmsfContextNon3gppProcedure(collName string, ueId string) {
+func DeleteSmsfContextNon3gppProcedure(ctx context.Context, collName string, ueId string) {
 	filter := bson.M{"ueId": ueId}
-	errDelOne := CommonDBClient.RestfulAPIDeleteOne(collName, filter)
+	errDelOne := CommonDBClient.RestfulAPIDeleteOne(ctx, collName, filter)
 	if errDelOne != nil {
 		logger.DataRepoLog.Warnln(errDelOne)
 	}
 }
 
-func HandleQuerySmsfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQuerySmsfContextNon3gpp(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QuerySmsfContextNon3gpp")
 
 	ueId := request.Params["ueId"]
 	collName := SUBSCDATA_CTXDATA_SMSF_NON3GPPACCESS
 
-	response, problemDetails := QuerySmsfContextNon3gppProcedure(collName, ueId)
+	response, problemDetails := QuerySmsfContextNon3gppProcedure(ctx, collName, ueId)
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", SMSFNon3GPPAccess, "SUCCESS")
 		return httpwrapper.NewResponse(http.StatusOK, nil, response)
@@ -3399,10 +3515,10 @@ func HandleQuerySmsfContextNon3gpp(request *httpwrapper.Request) *httpwrapper.Re
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QuerySmsfContextNon3gppProcedure(collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+func QuerySmsfContextNon3gppProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId}
 
-	smsfRegistration, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	smsfRegistration, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -3413,13 +3529,13 @@ func QuerySmsfContextNon3gppProcedure(collName string, ueId string) (*map[string
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleQuerySmsMngData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQuerySmsMngData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QuerySmsMngData")
 
 	collName := "subscriptionData.provisionedData.smsMngData"
 	ueId := request.Params["ueId"]
 	servingPlmnId := request.Params["servingPlmnId"]
-	response, problemDetails := QuerySmsMngDataProcedure(collName, ueId, servingPlmnId)
+	response, problemDetails := QuerySmsMngDataProcedure(ctx, collName, ueId, servingPlmnId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", SMSManagementData, "SUCCESS")
@@ -3434,11 +3550,11 @@ func HandleQuerySmsMngData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QuerySmsMngDataProcedure(collName string, ueId string,
+func QuerySmsMngDataProcedure(ctx context.Context, collName string, ueId string,
 	servingPlmnId string,
 ) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
-	smsManagementSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	smsManagementSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -3449,14 +3565,14 @@ func QuerySmsMngDataProcedure(collName string, ueId string,
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandleQuerySmsData(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleQuerySmsData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QuerySmsData")
 
 	ueId := request.Params["ueId"]
 	servingPlmnId := request.Params["servingPlmnId"]
 	collName := "subscriptionData.provisionedData.smsData"
 
-	response, problemDetails := QuerySmsDataProcedure(collName, ueId, servingPlmnId)
+	response, problemDetails := QuerySmsDataProcedure(ctx, collName, ueId, servingPlmnId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", SMSData, "SUCCESS")
@@ -3471,12 +3587,12 @@ func HandleQuerySmsData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QuerySmsDataProcedure(collName string, ueId string,
+func QuerySmsDataProcedure(ctx context.Context, collName string, ueId string,
 	servingPlmnId string,
 ) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
 
-	smsSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	smsSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -3487,12 +3603,17 @@ func QuerySmsDataProcedure(collName string, ueId string,
 	return nil, utils.ProblemDetailsUserNotFound()
 }
 
-func HandlePostSubscriptionDataSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandlePostSubscriptionDataSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle PostSubscriptionDataSubscriptions")
 
 	SubscriptionDataSubscriptions := request.Body.(models.SubscriptionDataSubscriptions)
+	clampSubscriptionExpiry(&SubscriptionDataSubscriptions, time.Now())
 
-	locationHeader := PostSubscriptionDataSubscriptionsProcedure(SubscriptionDataSubscriptions)
+	locationHeader, problemDetails := PostSubscriptionDataSubscriptionsProcedure(ctx, SubscriptionDataSubscriptions)
+	if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("create", SubsToNotify, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
 
 	headers := http.Header{}
 	headers.Set("Location", locationHeader)
@@ -3501,28 +3622,36 @@ func HandlePostSubscriptionDataSubscriptions(request *httpwrapper.Request) *http
 }
 
 func PostSubscriptionDataSubscriptionsProcedure(
+	ctx context.Context,
 	SubscriptionDataSubscriptions models.SubscriptionDataSubscriptions,
-) string {
+) (string, *models.ProblemDetails) {
 	udrSelf := udr_context.UDR_Self()
 
-	newSubscriptionID := strconv.Itoa(udrSelf.SubscriptionDataSubscriptionIDGenerator)
-	udrSelf.SubscriptionDataSubscriptions[newSubscriptionID] = &SubscriptionDataSubscriptions
-	udrSelf.SubscriptionDataSubscriptionIDGenerator++
+	newSubscriptionID := udrSelf.NewSubscriptionID()
+
+	if err := putSubscriptionDataSubscription(ctx, newSubscriptionID, &SubscriptionDataSubscriptions); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return "", utils.ProblemDetailsSystemFailure(err.Error())
+	}
+
+	if uri := SubscriptionDataSubscriptions.GetDataRestorationCallbackUri(); uri != "" {
+		putRestorationTarget(ctx, newSubscriptionID, uri)
+	}
 
 	/* Contains the URI of the newly created resource, according
 	   to the structure: {apiRoot}/subscription-data/subs-to-notify/{subsId} */
 	locationHeader := fmt.Sprintf("%s/subscription-data/subs-to-notify/%s",
 		udrSelf.GetIPv4GroupUri(udr_context.NUDR_DR), newSubscriptionID)
 
-	return locationHeader
+	return locationHeader, nil
 }
 
-func HandleRemovesubscriptionDataSubscriptions(request *httpwrapper.Request) *httpwrapper.Response {
+func HandleRemovesubscriptionDataSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle RemovesubscriptionDataSubscriptions")
 
 	subsId := request.Params["subsId"]
 
-	problemDetails := RemovesubscriptionDataSubscriptionsProcedure(subsId)
+	problemDetails := RemovesubscriptionDataSubscriptionsProcedure(ctx, subsId)
 
 	if problemDetails == nil {
 		stats.IncrementUdrSubscriptionDataStats("delete", SubsToNotify, "SUCCESS")
@@ -3533,24 +3662,132 @@ func HandleRemovesubscriptionDataSubscriptions(request *httpwrapper.Request) *ht
 	}
 }
 
-func RemovesubscriptionDataSubscriptionsProcedure(subsId string) *models.ProblemDetails {
-	udrSelf := udr_context.UDR_Self()
-	_, ok := udrSelf.SubscriptionDataSubscriptions[subsId]
+func RemovesubscriptionDataSubscriptionsProcedure(ctx context.Context, subsId string) *models.ProblemDetails {
+	subscriptionDataSubscriptions, ok := loadSubscriptionDataSubscription(ctx, subsId)
 	if !ok {
 		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
 	}
-	delete(udrSelf.SubscriptionDataSubscriptions, subsId)
+	if err := deleteSubscriptionDataSubscription(ctx, subsId); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsSystemFailure(err.Error())
+	}
+	if subscriptionDataSubscriptions.GetDataRestorationCallbackUri() != "" {
+		if err := CommonDBClient.RestfulAPIDeleteOne(ctx, SUBSCDATA_RESTORATION_TARGETS, bson.M{"subsId": subsId}); err != nil {
+			logger.DataRepoLog.Warnln(err)
+		}
+	}
 	return nil
 }
 
-func HandleQueryTraceData(request *httpwrapper.Request) *httpwrapper.Response {
+// putRestorationTarget records the restoration callback of a subscription
+// separately from the subscription itself, so that the NF can still be told
+// if the subscription is lost, e.g. when the DB is restored from a backup
+// taken before the subscription was created.
+func putRestorationTarget(ctx context.Context, subsId string, dataRestorationCallbackUri string) {
+	filter := bson.M{"subsId": subsId}
+	putData := bson.M{"subsId": subsId, "dataRestorationCallbackUri": dataRestorationCallbackUri}
+	if _, err := CommonDBClient.RestfulAPIPutOne(ctx, SUBSCDATA_RESTORATION_TARGETS, filter, putData); err != nil {
+		logger.DataRepoLog.Warnln(err)
+	}
+}
+
+// SendDataRestorationNotifications is called at startup. A restoration target
+// whose subscription is no longer stored belongs to a subscription that was
+// lost, so each distinct callback of such targets is told to re-create its
+// subscriptions and the stale targets are dropped.
+func SendDataRestorationNotifications(ctx context.Context) {
+	if CommonDBClient == nil {
+		return
+	}
+	targets, err := CommonDBClient.RestfulAPIGetMany(ctx, SUBSCDATA_RESTORATION_TARGETS, bson.M{})
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return
+	}
+	if len(targets) == 0 {
+		return
+	}
+	subscriptions, err := CommonDBClient.RestfulAPIGetMany(ctx, SUBSCDATA_SUBS_TO_NOTIFY, bson.M{})
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return
+	}
+	storedSubsIds := make(map[string]bool, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subsId, _ := subscription["subsId"].(string)
+		storedSubsIds[subsId] = true
+	}
+
+	var lostSubsIds []string
+	var uris []string
+	for _, target := range targets {
+		subsId, _ := target["subsId"].(string)
+		if storedSubsIds[subsId] {
+			continue
+		}
+		lostSubsIds = append(lostSubsIds, subsId)
+		uri, _ := target["dataRestorationCallbackUri"].(string)
+		if uri != "" && !slices.Contains(uris, uri) {
+			uris = append(uris, uri)
+		}
+	}
+	if len(lostSubsIds) == 0 {
+		return
+	}
+	logger.DataRepoLog.Infof("%d subscriptions lost, notifying %d restoration callbacks", len(lostSubsIds), len(uris))
+
+	dataRestorationNotification := models.NewDataRestorationNotification()
+	dataRestorationNotification.SetRecoveryTime(time.Now().UTC())
+	for _, uri := range uris {
+		callback.SendDataRestorationNotification(uri, *dataRestorationNotification)
+	}
+
+	filter := bson.M{"subsId": bson.M{"$in": lostSubsIds}}
+	if err := CommonDBClient.RestfulAPIDeleteMany(ctx, SUBSCDATA_RESTORATION_TARGETS, filter); err != nil {
+		logger.DataRepoLog.Warnln(err)
+	}
+}
+
+func HandleDataRestorationNotification(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DataRestorationNotification")
+
+	dataRestorationNotification := request.Body.(models.DataRestorationNotification)
+
+	DataRestorationNotificationProcedure(ctx, dataRestorationNotification)
+	stats.IncrementUdrSubscriptionDataStats("create", DataRestoration, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+}
+
+// DataRestorationNotificationProcedure relays a restoration notification from
+// a peer to the restoration callbacks of the stored subscriptions, so the NFs
+// behind them re-create whatever the peer lost.
+func DataRestorationNotificationProcedure(ctx context.Context, dataRestorationNotification models.DataRestorationNotification) {
+	targets, err := CommonDBClient.RestfulAPIGetMany(ctx, SUBSCDATA_RESTORATION_TARGETS, bson.M{})
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+	}
+
+	var uris []string
+	for _, target := range targets {
+		uri, _ := target["dataRestorationCallbackUri"].(string)
+		if uri != "" && !slices.Contains(uris, uri) {
+			uris = append(uris, uri)
+		}
+	}
+
+	for _, uri := range uris {
+		callback.SendDataRestorationNotification(uri, dataRestorationNotification)
+	}
+}
+
+func HandleQueryTraceData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
 	logger.DataRepoLog.Debugln("handle QueryTraceData")
 
 	collName := "subscriptionData.provisionedData.traceData"
 	ueId := request.Params["ueId"]
 	servingPlmnId := request.Params["servingPlmnId"]
 
-	response, problemDetails := QueryTraceDataProcedure(collName, ueId, servingPlmnId)
+	response, problemDetails := QueryTraceDataProcedure(ctx, collName, ueId, servingPlmnId)
 
 	if response != nil {
 		stats.IncrementUdrSubscriptionDataStats("get", TraceData, "SUCCESS")
@@ -3565,12 +3802,12 @@ func HandleQueryTraceData(request *httpwrapper.Request) *httpwrapper.Response {
 	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
 }
 
-func QueryTraceDataProcedure(collName string, ueId string,
+func QueryTraceDataProcedure(ctx context.Context, collName string, ueId string,
 	servingPlmnId string,
 ) (*map[string]interface{}, *models.ProblemDetails) {
 	filter := bson.M{"ueId": ueId, "servingPlmnId": servingPlmnId}
 
-	traceData, errGetOne := CommonDBClient.RestfulAPIGetOne(collName, filter)
+	traceData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
 	if errGetOne != nil {
 		logger.DataRepoLog.Warnln(errGetOne)
 	}
@@ -3580,3 +3817,1994 @@ func QueryTraceDataProcedure(collName string, ueId string,
 	}
 	return nil, utils.ProblemDetailsUserNotFound()
 }
+
+// provisionedDataFilter builds the lookup filter for a provisioned dataset.
+// Datasets whose resource path carries no serving PLMN (LCS privacy, LCS MO,
+// LCS subscription data) are stored once per UE, so servingPlmnId is only
+// matched when the caller has one.
+func provisionedDataFilter(ueId string, servingPlmnId string) bson.M {
+	filter := bson.M{"ueId": ueId}
+	if servingPlmnId != "" {
+		filter["servingPlmnId"] = servingPlmnId
+	}
+	return filter
+}
+
+func HandleQueryLcsPrivacyData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryLcsPrivacyData")
+
+	collName := SUBSCDATA_PROVDATA_LCS_PRIVACY
+	ueId := request.Params["ueId"]
+	servingPlmnId := request.Params["servingPlmnId"]
+
+	response, problemDetails := QueryLcsPrivacyDataProcedure(ctx, collName, ueId, servingPlmnId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", LCSPrivacyData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", LCSPrivacyData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", LCSPrivacyData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryLcsPrivacyDataProcedure(ctx context.Context, collName string, ueId string,
+	servingPlmnId string,
+) (*map[string]interface{}, *models.ProblemDetails) {
+	filter := provisionedDataFilter(ueId, servingPlmnId)
+
+	lcsPrivacyData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
+	if errGetOne != nil {
+		logger.DataRepoLog.Warnln(errGetOne)
+	}
+
+	if lcsPrivacyData != nil {
+		return &lcsPrivacyData, nil
+	}
+	return nil, utils.ProblemDetailsUserNotFound()
+}
+
+func HandleQueryLcsMoData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryLcsMoData")
+
+	collName := SUBSCDATA_PROVDATA_LCS_MO
+	ueId := request.Params["ueId"]
+	servingPlmnId := request.Params["servingPlmnId"]
+
+	response, problemDetails := QueryLcsMoDataProcedure(ctx, collName, ueId, servingPlmnId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", LCSMOData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", LCSMOData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", LCSMOData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryLcsMoDataProcedure(ctx context.Context, collName string, ueId string,
+	servingPlmnId string,
+) (*map[string]interface{}, *models.ProblemDetails) {
+	filter := provisionedDataFilter(ueId, servingPlmnId)
+
+	lcsMoData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
+	if errGetOne != nil {
+		logger.DataRepoLog.Warnln(errGetOne)
+	}
+
+	if lcsMoData != nil {
+		return &lcsMoData, nil
+	}
+	return nil, utils.ProblemDetailsUserNotFound()
+}
+
+func HandleQueryLcsBcaData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryLcsBcaData")
+
+	collName := SUBSCDATA_PROVDATA_LCS_BCA
+	ueId := request.Params["ueId"]
+	servingPlmnId := request.Params["servingPlmnId"]
+
+	response, problemDetails := QueryLcsBcaDataProcedure(ctx, collName, ueId, servingPlmnId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", LCSBCAData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", LCSBCAData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", LCSBCAData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryLcsBcaDataProcedure(ctx context.Context, collName string, ueId string,
+	servingPlmnId string,
+) (*map[string]interface{}, *models.ProblemDetails) {
+	filter := provisionedDataFilter(ueId, servingPlmnId)
+
+	lcsBcaData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
+	if errGetOne != nil {
+		logger.DataRepoLog.Warnln(errGetOne)
+	}
+
+	if lcsBcaData != nil {
+		return &lcsBcaData, nil
+	}
+	return nil, utils.ProblemDetailsUserNotFound()
+}
+
+func HandleQueryLcsSubscriptionData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryLcsSubscriptionData")
+
+	collName := SUBSCDATA_PROVDATA_LCS_SUBSCRIPTION
+	ueId := request.Params["ueId"]
+	servingPlmnId := request.Params["servingPlmnId"]
+
+	response, problemDetails := QueryLcsSubscriptionDataProcedure(ctx, collName, ueId, servingPlmnId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", LCSSubscriptionData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", LCSSubscriptionData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", LCSSubscriptionData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryLcsSubscriptionDataProcedure(ctx context.Context, collName string, ueId string,
+	servingPlmnId string,
+) (*map[string]interface{}, *models.ProblemDetails) {
+	filter := provisionedDataFilter(ueId, servingPlmnId)
+
+	lcsSubscriptionData, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
+	if errGetOne != nil {
+		logger.DataRepoLog.Warnln(errGetOne)
+	}
+
+	if lcsSubscriptionData != nil {
+		return &lcsSubscriptionData, nil
+	}
+	return nil, utils.ProblemDetailsUserNotFound()
+}
+
+func HandleQueryV2xData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryV2xData")
+
+	collName := SUBSCDATA_PROVDATA_V2X
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryV2xDataProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", V2XData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", V2XData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", V2XData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryV2xDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	v2xData, problemDetails := getDataFromDB(ctx, collName, provisionedDataFilter(ueId, ""))
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsUserNotFound()
+	}
+	return &v2xData, nil
+}
+
+func HandleQueryA2xData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryA2xData")
+
+	collName := SUBSCDATA_PROVDATA_A2X
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryA2xDataProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", A2XData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", A2XData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", A2XData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryA2xDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	a2xData, problemDetails := getDataFromDB(ctx, collName, provisionedDataFilter(ueId, ""))
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsUserNotFound()
+	}
+	return &a2xData, nil
+}
+
+func HandleQueryProseData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryProseData")
+
+	collName := SUBSCDATA_PROVDATA_PROSE
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryProseDataProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", ProSeData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", ProSeData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", ProSeData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryProseDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	proseData, problemDetails := getDataFromDB(ctx, collName, provisionedDataFilter(ueId, ""))
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsUserNotFound()
+	}
+	return &proseData, nil
+}
+
+func HandleQueryRangingSlPosData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryRangingSlPosData")
+
+	collName := SUBSCDATA_PROVDATA_RANGING_SLPOS
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryRangingSlPosDataProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", RangingSlPosData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", RangingSlPosData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", RangingSlPosData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryRangingSlPosDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	rangingSlPosData, problemDetails := getDataFromDB(ctx, collName, provisionedDataFilter(ueId, ""))
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsUserNotFound()
+	}
+	return &rangingSlPosData, nil
+}
+
+// querySidelinkDataSets loads the V2X, ProSe, A2X and ranging/sidelink
+// positioning datasets named in dataSetNames, keyed by their
+// ProvisionedDataSets attribute. Datasets that are not provisioned are left
+// out rather than failing the whole query.
+func querySidelinkDataSets(ctx context.Context, ueId string, dataSetNames []string) map[string]interface{} {
+	dataSets := make(map[string]interface{})
+	for _, dataSet := range sidelinkDataSets {
+		if !slices.Contains(dataSetNames, dataSet.dataSetName) {
+			continue
+		}
+		data, problemDetails := getDataFromDB(ctx, dataSet.collName, provisionedDataFilter(ueId, ""))
+		if problemDetails != nil {
+			continue
+		}
+		delete(data, "ueId")
+		delete(data, "servingPlmnId")
+		dataSets[dataSet.attribute] = data
+	}
+	return dataSets
+}
+
+// mergeProvisionedDataSets returns provisionedDataSets as a JSON object with
+// the given attributes added. The result stays a map: decoding it back into
+// models.ProvisionedDataSets would drop every dataset the generated model
+// has no field for, which includes the sidelink ones.
+func mergeProvisionedDataSets(provisionedDataSets *models.ProvisionedDataSets,
+	dataSets map[string]interface{},
+) (map[string]interface{}, error) {
+	raw, err := json.Marshal(provisionedDataSets)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]interface{})
+	if err = json.Unmarshal(raw, &merged); err != nil {
+		return nil, err
+	}
+	maps.Copy(merged, dataSets)
+	return merged, nil
+}
+
+// parsePduSessionId validates the pduSessionId path parameter, which
+// TS 29.571 restricts to an integer in the range 0-255.
+func parsePduSessionId(pduSessionId string) (int32, *models.ProblemDetails) {
+	pduSessionIdInt, err := strconv.ParseInt(pduSessionId, 10, 32)
+	if err != nil || pduSessionIdInt < 0 || pduSessionIdInt > 255 {
+		return 0, utils.ProblemDetailsMalformedRequestSyntax("invalid pduSessionId: " + pduSessionId)
+	}
+	return int32(pduSessionIdInt), nil
+}
+
+func HandleCreateOrReplaceSessionManagementData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateOrReplaceSessionManagementData")
+
+	pduSessionManagementData := request.Body.(models.PduSessionManagementData)
+	collName := EXPOSUREDATA_SESSION_MANAGEMENT
+	ueId := request.Params["ueId"]
+
+	pduSessionId, problemDetails := parsePduSessionId(request.Params["pduSessionId"])
+	if problemDetails != nil {
+		stats.IncrementUdrExposureDataStats("create", SessionManagementData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	response, status := CreateOrReplaceSessionManagementDataProcedure(ctx, pduSessionManagementData, collName, ueId, pduSessionId)
+	if status == http.StatusCreated || status == http.StatusNoContent {
+		PreHandleExposureDataChangeNotification(ueId, sessionManagementDataPath(ueId, pduSessionId), pduSessionManagementData)
+	}
+
+	switch status {
+	case http.StatusCreated:
+		stats.IncrementUdrExposureDataStats("create", SessionManagementData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusCreated, nil, response)
+	case http.StatusNoContent:
+		stats.IncrementUdrExposureDataStats("create", SessionManagementData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrExposureDataStats("create", SessionManagementData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func CreateOrReplaceSessionManagementDataProcedure(ctx context.Context, pduSessionManagementData models.PduSessionManagementData,
+	collName string, ueId string, pduSessionId int32,
+) (*models.PduSessionManagementData, int) {
+	putData := util.ToBsonM(pduSessionManagementData)
+	putData["ueId"] = ueId
+	putData["pduSessionId"] = pduSessionId
+
+	filter := bson.M{"ueId": ueId, "pduSessionId": pduSessionId}
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+
+	if !isExisted {
+		return &pduSessionManagementData, http.StatusCreated
+	}
+	return &pduSessionManagementData, http.StatusNoContent
+}
+
+func HandleDeleteSessionManagementData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteSessionManagementData")
+
+	collName := EXPOSUREDATA_SESSION_MANAGEMENT
+	ueId := request.Params["ueId"]
+
+	pduSessionId, problemDetails := parsePduSessionId(request.Params["pduSessionId"])
+	if problemDetails != nil {
+		stats.IncrementUdrExposureDataStats("delete", SessionManagementData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	deleted, err := DeleteSessionManagementDataProcedure(ctx, collName, ueId, pduSessionId)
+	if err != nil {
+		pd := utils.ProblemDetailsSystemFailure(err.Error())
+		stats.IncrementUdrExposureDataStats("delete", SessionManagementData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+	if deleted {
+		PreHandleExposureDataChangeNotification(ueId, sessionManagementDataPath(ueId, pduSessionId), nil)
+	}
+	stats.IncrementUdrExposureDataStats("delete", SessionManagementData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+}
+
+// DeleteSessionManagementDataProcedure removes the session management data
+// of pduSessionId. deleted is false if there was none, in which case there
+// is no change to notify.
+func DeleteSessionManagementDataProcedure(ctx context.Context, collName string, ueId string,
+	pduSessionId int32,
+) (deleted bool, err error) {
+	filter := bson.M{"ueId": ueId, "pduSessionId": pduSessionId}
+	existing, err := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return false, err
+	}
+	if existing == nil {
+		return false, nil
+	}
+	return true, deleteDataFromDB(ctx, collName, filter)
+}
+
+func HandleQuerySessionManagementData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QuerySessionManagementData")
+
+	collName := EXPOSUREDATA_SESSION_MANAGEMENT
+	ueId := request.Params["ueId"]
+
+	pduSessionId, problemDetails := parsePduSessionId(request.Params["pduSessionId"])
+	if problemDetails != nil {
+		stats.IncrementUdrExposureDataStats("get", SessionManagementData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	response, problemDetails := QuerySessionManagementDataProcedure(ctx, collName, ueId, pduSessionId)
+
+	if response != nil {
+		stats.IncrementUdrExposureDataStats("get", SessionManagementData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrExposureDataStats("get", SessionManagementData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrExposureDataStats("get", SessionManagementData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QuerySessionManagementDataProcedure(ctx context.Context, collName string, ueId string,
+	pduSessionId int32,
+) (*map[string]interface{}, *models.ProblemDetails) {
+	filter := bson.M{"ueId": ueId, "pduSessionId": pduSessionId}
+	sessionManagementData, problemDetails := getDataFromDB(ctx, collName, filter)
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+	return &sessionManagementData, nil
+}
+
+func sessionManagementDataPath(ueId string, pduSessionId int32) string {
+	return fmt.Sprintf("/exposure-data/%s/session-management-data/%d", ueId, pduSessionId)
+}
+
+func accessAndMobilityDataPath(ueId string) string {
+	return fmt.Sprintf("/exposure-data/%s/access-and-mobility-data", ueId)
+}
+
+func HandleCreateOrReplaceAccessAndMobilityData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateOrReplaceAccessAndMobilityData")
+
+	accessAndMobilityData := request.Body.(models.AccessAndMobilityData)
+	collName := EXPOSUREDATA_ACCESS_AND_MOBILITY
+	ueId := request.Params["ueId"]
+
+	response, status := CreateOrReplaceAccessAndMobilityDataProcedure(ctx, accessAndMobilityData, collName, ueId)
+	if status == http.StatusCreated || status == http.StatusNoContent {
+		PreHandleExposureDataChangeNotification(ueId, accessAndMobilityDataPath(ueId), accessAndMobilityData)
+	}
+
+	switch status {
+	case http.StatusCreated:
+		stats.IncrementUdrExposureDataStats("create", AccessAndMobilityData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusCreated, nil, response)
+	case http.StatusNoContent:
+		stats.IncrementUdrExposureDataStats("create", AccessAndMobilityData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrExposureDataStats("create", AccessAndMobilityData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func CreateOrReplaceAccessAndMobilityDataProcedure(ctx context.Context, accessAndMobilityData models.AccessAndMobilityData,
+	collName string, ueId string,
+) (bson.M, int) {
+	putData := util.ToBsonM(accessAndMobilityData)
+	putData["ueId"] = ueId
+
+	filter := bson.M{"ueId": ueId}
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+
+	if !isExisted {
+		return putData, http.StatusCreated
+	}
+	return putData, http.StatusNoContent
+}
+
+func HandleQueryAccessAndMobilityData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryAccessAndMobilityData")
+
+	collName := EXPOSUREDATA_ACCESS_AND_MOBILITY
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryAccessAndMobilityDataProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrExposureDataStats("get", AccessAndMobilityData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrExposureDataStats("get", AccessAndMobilityData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrExposureDataStats("get", AccessAndMobilityData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryAccessAndMobilityDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	accessAndMobilityData, problemDetails := getDataFromDB(ctx, collName, bson.M{"ueId": ueId})
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+	return &accessAndMobilityData, nil
+}
+
+func HandleUpdateAccessAndMobilityData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle UpdateAccessAndMobilityData")
+
+	accessAndMobilityData := request.Body.(models.AccessAndMobilityData)
+	collName := EXPOSUREDATA_ACCESS_AND_MOBILITY
+	ueId := request.Params["ueId"]
+
+	problemDetails := UpdateAccessAndMobilityDataProcedure(ctx, accessAndMobilityData, collName, ueId)
+
+	if problemDetails == nil {
+		stats.IncrementUdrExposureDataStats("update", AccessAndMobilityData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+	stats.IncrementUdrExposureDataStats("update", AccessAndMobilityData, "FAILURE")
+	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+}
+
+func UpdateAccessAndMobilityDataProcedure(ctx context.Context, accessAndMobilityData models.AccessAndMobilityData,
+	collName string, ueId string,
+) *models.ProblemDetails {
+	filter := bson.M{"ueId": ueId}
+	if _, problemDetails := getDataFromDB(ctx, collName, filter); problemDetails != nil {
+		return utils.ProblemDetailsDataNotFound()
+	}
+
+	patchData := util.ToBsonM(accessAndMobilityData)
+	patchData["ueId"] = ueId
+	if err := CommonDBClient.RestfulAPIMergePatch(ctx, collName, filter, patchData); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
+	}
+
+	merged, problemDetails := getDataFromDB(ctx, collName, filter)
+	if problemDetails != nil {
+		return nil
+	}
+	delete(merged, "ueId")
+	newAccessAndMobilityData := models.AccessAndMobilityData{}
+	if err := json.Unmarshal(util.MapToByte(merged), &newAccessAndMobilityData); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return nil
+	}
+	PreHandleExposureDataChangeNotification(ueId, accessAndMobilityDataPath(ueId), newAccessAndMobilityData)
+	return nil
+}
+
+func HandleDeleteAccessAndMobilityData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteAccessAndMobilityData")
+
+	collName := EXPOSUREDATA_ACCESS_AND_MOBILITY
+	ueId := request.Params["ueId"]
+
+	if err := DeleteAccessAndMobilityDataProcedure(ctx, collName, ueId); err != nil {
+		pd := utils.ProblemDetailsSystemFailure(err.Error())
+		stats.IncrementUdrExposureDataStats("delete", AccessAndMobilityData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+	PreHandleExposureDataChangeNotification(ueId, accessAndMobilityDataPath(ueId), nil)
+	stats.IncrementUdrExposureDataStats("delete", AccessAndMobilityData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+}
+
+func DeleteAccessAndMobilityDataProcedure(ctx context.Context, collName string, ueId string) error {
+	return deleteDataFromDB(ctx, collName, bson.M{"ueId": ueId})
+}
+
+func HandleCreateExposureDataSubscription(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateExposureDataSubscription")
+
+	exposureDataSubscription := request.Body.(models.ExposureDataSubscription)
+
+	locationHeader := CreateExposureDataSubscriptionProcedure(ctx, exposureDataSubscription)
+
+	headers := http.Header{}
+	headers.Set("Location", locationHeader)
+	stats.IncrementUdrExposureDataStats("create", SubsToNotify, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusCreated, headers, exposureDataSubscription)
+}
+
+func CreateExposureDataSubscriptionProcedure(ctx context.Context, exposureDataSubscription models.ExposureDataSubscription) string {
+	udrSelf := udr_context.UDR_Self()
+
+	udrSelf.ExposureDataSubsMtx.Lock()
+	newSubscriptionID := strconv.Itoa(udrSelf.ExposureDataSubscriptionIDGenerator)
+	udrSelf.ExposureDataSubscriptions[newSubscriptionID] = &exposureDataSubscription
+	udrSelf.ExposureDataSubscriptionIDGenerator++
+	udrSelf.ExposureDataSubsMtx.Unlock()
+
+	/* Contains the URI of the newly created resource, according
+	   to the structure: {apiRoot}/exposure-data/subs-to-notify/{subId} */
+	locationHeader := fmt.Sprintf("%s/exposure-data/subs-to-notify/%s", udrSelf.GetIPv4GroupUri(udr_context.NUDR_DR),
+		newSubscriptionID)
+
+	return locationHeader
+}
+
+func HandleReplaceExposureDataSubscription(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle ReplaceExposureDataSubscription")
+
+	subId := request.Params["subId"]
+	exposureDataSubscription := request.Body.(models.ExposureDataSubscription)
+
+	response, problemDetails := ReplaceExposureDataSubscriptionProcedure(ctx, subId, exposureDataSubscription)
+
+	if problemDetails == nil {
+		stats.IncrementUdrExposureDataStats("update", SubsToNotify, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	}
+	stats.IncrementUdrExposureDataStats("update", SubsToNotify, "FAILURE")
+	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+}
+
+func ReplaceExposureDataSubscriptionProcedure(ctx context.Context, subId string,
+	exposureDataSubscription models.ExposureDataSubscription,
+) (*models.ExposureDataSubscription, *models.ProblemDetails) {
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.ExposureDataSubsMtx.Lock()
+	defer udrSelf.ExposureDataSubsMtx.Unlock()
+
+	if _, ok := udrSelf.ExposureDataSubscriptions[subId]; !ok {
+		return nil, utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
+	}
+	udrSelf.ExposureDataSubscriptions[subId] = &exposureDataSubscription
+
+	return &exposureDataSubscription, nil
+}
+
+func HandleDeleteExposureDataSubscription(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteExposureDataSubscription")
+
+	subId := request.Params["subId"]
+
+	problemDetails := DeleteExposureDataSubscriptionProcedure(ctx, subId)
+
+	if problemDetails == nil {
+		stats.IncrementUdrExposureDataStats("delete", SubsToNotify, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+	stats.IncrementUdrExposureDataStats("delete", SubsToNotify, "FAILURE")
+	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+}
+
+func DeleteExposureDataSubscriptionProcedure(ctx context.Context, subId string) *models.ProblemDetails {
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.ExposureDataSubsMtx.Lock()
+	defer udrSelf.ExposureDataSubsMtx.Unlock()
+
+	if _, ok := udrSelf.ExposureDataSubscriptions[subId]; !ok {
+		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
+	}
+	delete(udrSelf.ExposureDataSubscriptions, subId)
+
+	return nil
+}
+
+func HandleQueryUeLocation(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryUeLocation")
+
+	collName := SUBSCDATA_CTXDATA_LOCATION
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryUeLocationProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", UELocation, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", UELocation, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", UELocation, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+// QueryUeLocationProcedure returns the stored location record of the UE. When
+// none has been provisioned the location is derived from the AMF
+// registrations, which carry the serving AMF and GUAMI per access type.
+func QueryUeLocationProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	filter := bson.M{"ueId": ueId}
+	locationInfo, problemDetails := getDataFromDB(ctx, collName, filter)
+	if problemDetails == nil {
+		delete(locationInfo, "ueId")
+		return &locationInfo, nil
+	}
+
+	var registrationLocationInfoList []interface{}
+	amfRegistrations := []struct {
+		collName   string
+		accessType string
+	}{
+		{SUBSCDATA_CTXDATA_AMF_3GPPACCESS, "3GPP_ACCESS"},
+		{SUBSCDATA_CTXDATA_AMF_NON3GPPACCESS, "NON_3GPP_ACCESS"},
+	}
+	for _, amfRegistration := range amfRegistrations {
+		registration, problemDetails := getDataFromDB(ctx, amfRegistration.collName, filter)
+		if problemDetails != nil {
+			continue
+		}
+		registrationLocationInfoList = append(registrationLocationInfoList,
+			registrationLocationInfoFromAmfRegistration(registration, amfRegistration.accessType))
+	}
+	if len(registrationLocationInfoList) == 0 {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+
+	locationInfo = map[string]interface{}{
+		"supi":                         ueId,
+		"registrationLocationInfoList": registrationLocationInfoList,
+	}
+	return &locationInfo, nil
+}
+
+// registrationLocationInfoFromAmfRegistration maps a stored AMF registration
+// document onto a RegistrationLocationInfo entry.
+func registrationLocationInfoFromAmfRegistration(registration map[string]interface{},
+	accessType string,
+) map[string]interface{} {
+	registrationLocationInfo := map[string]interface{}{
+		"amfInstanceId":  registration["amfInstanceId"],
+		"accessTypeList": []string{accessType},
+	}
+	if guami, ok := registration["guami"].(map[string]interface{}); ok {
+		registrationLocationInfo["guami"] = guami
+		if plmnId, ok := guami["plmnId"]; ok {
+			registrationLocationInfo["plmnId"] = plmnId
+		}
+	}
+	if vgmlcAddress, ok := registration["vgmlcAddress"]; ok {
+		registrationLocationInfo["vgmlcAddress"] = vgmlcAddress
+	}
+	return registrationLocationInfo
+}
+
+func HandleQueryRoamingInformation(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryRoamingInformation")
+
+	collName := SUBSCDATA_CTXDATA_ROAMING_INFORMATION
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryRoamingInformationProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", RoamingInformation, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", RoamingInformation, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", RoamingInformation, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryRoamingInformationProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	roamingInformation, problemDetails := getDataFromDB(ctx, collName, bson.M{"ueId": ueId})
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+	delete(roamingInformation, "ueId")
+	return &roamingInformation, nil
+}
+
+func HandleUpdateRoamingInformation(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle UpdateRoamingInformation")
+
+	roamingInfoUpdate := request.Body.(models.RoamingInfoUpdate)
+	collName := SUBSCDATA_CTXDATA_ROAMING_INFORMATION
+	ueId := request.Params["ueId"]
+
+	response, status := UpdateRoamingInformationProcedure(ctx, roamingInfoUpdate, collName, ueId)
+
+	switch status {
+	case http.StatusCreated:
+		stats.IncrementUdrSubscriptionDataStats("update", RoamingInformation, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusCreated, nil, response)
+	case http.StatusNoContent:
+		stats.IncrementUdrSubscriptionDataStats("update", RoamingInformation, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("update", RoamingInformation, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func UpdateRoamingInformationProcedure(ctx context.Context, roamingInfoUpdate models.RoamingInfoUpdate,
+	collName string, ueId string,
+) (*models.RoamingInfoUpdate, int) {
+	putData := util.ToBsonM(roamingInfoUpdate)
+	putData["ueId"] = ueId
+
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, bson.M{"ueId": ueId}, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+
+	if !isExisted {
+		return &roamingInfoUpdate, http.StatusCreated
+	}
+	return &roamingInfoUpdate, http.StatusNoContent
+}
+
+func HandleQueryPeiInformation(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryPeiInformation")
+
+	collName := SUBSCDATA_CTXDATA_PEI_INFO
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryPeiInformationProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", PEIInformation, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", PEIInformation, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", PEIInformation, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryPeiInformationProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	peiInfo, problemDetails := getDataFromDB(ctx, collName, bson.M{"ueId": ueId})
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+	delete(peiInfo, "ueId")
+	return &peiInfo, nil
+}
+
+func HandleCreateOrUpdatePeiInformation(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateOrUpdatePeiInformation")
+
+	peiInfo := request.Body.(models.PeiInfo)
+	collName := SUBSCDATA_CTXDATA_PEI_INFO
+	ueId := request.Params["ueId"]
+
+	response, status := CreateOrUpdatePeiInformationProcedure(ctx, peiInfo, collName, ueId)
+
+	switch status {
+	case http.StatusCreated:
+		stats.IncrementUdrSubscriptionDataStats("create", PEIInformation, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusCreated, nil, response)
+	case http.StatusNoContent:
+		stats.IncrementUdrSubscriptionDataStats("create", PEIInformation, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("create", PEIInformation, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func CreateOrUpdatePeiInformationProcedure(ctx context.Context, peiInfo models.PeiInfo,
+	collName string, ueId string,
+) (*models.PeiInfo, int) {
+	putData := util.ToBsonM(peiInfo)
+	putData["ueId"] = ueId
+
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, bson.M{"ueId": ueId}, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+
+	if !isExisted {
+		return &peiInfo, http.StatusCreated
+	}
+	return &peiInfo, http.StatusNoContent
+}
+
+func HandleQueryTimeSyncSubscriptionData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryTimeSyncSubscriptionData")
+
+	collName := SUBSCDATA_PROVDATA_TIME_SYNC
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryTimeSyncSubscriptionDataProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", TimeSyncData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", TimeSyncData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", TimeSyncData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryTimeSyncSubscriptionDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	timeSyncData, problemDetails := getDataFromDB(ctx, collName, bson.M{"ueId": ueId})
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+	delete(timeSyncData, "ueId")
+	return &timeSyncData, nil
+}
+
+func HandleCreateOrReplaceTimeSyncSubscriptionData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateOrReplaceTimeSyncSubscriptionData")
+
+	timeSyncSubscriptionData := request.Body.(models.TimeSyncSubscriptionData)
+	collName := SUBSCDATA_PROVDATA_TIME_SYNC
+	ueId := request.Params["ueId"]
+
+	response, status := CreateOrReplaceTimeSyncSubscriptionDataProcedure(ctx, timeSyncSubscriptionData, collName, ueId)
+
+	switch status {
+	case http.StatusCreated:
+		stats.IncrementUdrSubscriptionDataStats("create", TimeSyncData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusCreated, nil, response)
+	case http.StatusNoContent:
+		stats.IncrementUdrSubscriptionDataStats("create", TimeSyncData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("create", TimeSyncData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func CreateOrReplaceTimeSyncSubscriptionDataProcedure(ctx context.Context, timeSyncSubscriptionData models.TimeSyncSubscriptionData,
+	collName string, ueId string,
+) (*models.TimeSyncSubscriptionData, int) {
+	putData := util.ToBsonM(timeSyncSubscriptionData)
+	putData["ueId"] = ueId
+
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, bson.M{"ueId": ueId}, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+
+	if !isExisted {
+		return &timeSyncSubscriptionData, http.StatusCreated
+	}
+	return &timeSyncSubscriptionData, http.StatusNoContent
+}
+
+func HandleDeleteTimeSyncSubscriptionData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteTimeSyncSubscriptionData")
+
+	collName := SUBSCDATA_PROVDATA_TIME_SYNC
+	ueId := request.Params["ueId"]
+
+	if err := deleteDataFromDB(ctx, collName, bson.M{"ueId": ueId}); err != nil {
+		pd := utils.ProblemDetailsSystemFailure(err.Error())
+		stats.IncrementUdrSubscriptionDataStats("delete", TimeSyncData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+	stats.IncrementUdrSubscriptionDataStats("delete", TimeSyncData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+}
+
+func HandleQueryUserConsentData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryUserConsentData")
+
+	collName := SUBSCDATA_PROVDATA_USER_CONSENT
+	ueId := request.Params["ueId"]
+	ucPurpose := request.Query.Get("uc-purpose")
+
+	response, problemDetails := QueryUserConsentDataProcedure(ctx, collName, ueId, ucPurpose)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", UserConsentData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", UserConsentData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", UserConsentData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+// QueryUserConsentDataProcedure returns the user consent data of the UE. When
+// ucPurpose is given only the consent recorded for that purpose is returned,
+// and a UE without consent for it is reported as not found.
+func QueryUserConsentDataProcedure(ctx context.Context, collName string, ueId string,
+	ucPurpose string,
+) (*map[string]interface{}, *models.ProblemDetails) {
+	ucData, problemDetails := getDataFromDB(ctx, collName, bson.M{"ueId": ueId})
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+	delete(ucData, "ueId")
+	if ucPurpose == "" {
+		return &ucData, nil
+	}
+
+	userConsentPerPurposeList, _ := ucData["userConsentPerPurposeList"].(map[string]interface{})
+	userConsent, ok := userConsentPerPurposeList[ucPurpose]
+	if !ok {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+	ucData["userConsentPerPurposeList"] = map[string]interface{}{ucPurpose: userConsent}
+	return &ucData, nil
+}
+
+func HandleCreateOrReplaceUserConsentData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateOrReplaceUserConsentData")
+
+	ucSubscriptionData := request.Body.(models.UcSubscriptionData)
+	collName := SUBSCDATA_PROVDATA_USER_CONSENT
+	ueId := request.Params["ueId"]
+
+	response, status := CreateOrReplaceUserConsentDataProcedure(ctx, ucSubscriptionData, collName, ueId)
+
+	switch status {
+	case http.StatusCreated:
+		stats.IncrementUdrSubscriptionDataStats("create", UserConsentData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusCreated, nil, response)
+	case http.StatusNoContent:
+		stats.IncrementUdrSubscriptionDataStats("create", UserConsentData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("create", UserConsentData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func CreateOrReplaceUserConsentDataProcedure(ctx context.Context, ucSubscriptionData models.UcSubscriptionData,
+	collName string, ueId string,
+) (*models.UcSubscriptionData, int) {
+	putData := util.ToBsonM(ucSubscriptionData)
+	putData["ueId"] = ueId
+
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, bson.M{"ueId": ueId}, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+
+	if !isExisted {
+		return &ucSubscriptionData, http.StatusCreated
+	}
+	return &ucSubscriptionData, http.StatusNoContent
+}
+
+func HandleDeleteUserConsentData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteUserConsentData")
+
+	collName := SUBSCDATA_PROVDATA_USER_CONSENT
+	ueId := request.Params["ueId"]
+
+	if err := deleteDataFromDB(ctx, collName, bson.M{"ueId": ueId}); err != nil {
+		pd := utils.ProblemDetailsSystemFailure(err.Error())
+		stats.IncrementUdrSubscriptionDataStats("delete", UserConsentData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+	stats.IncrementUdrSubscriptionDataStats("delete", UserConsentData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+}
+
+func HandleQueryCoverageRestrictionData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QueryCoverageRestrictionData")
+
+	collName := SUBSCDATA_PROVDATA_COVERAGE_RESTRICTION
+	ueId := request.Params["ueId"]
+
+	response, problemDetails := QueryCoverageRestrictionDataProcedure(ctx, collName, ueId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", CoverageRestrictionData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", CoverageRestrictionData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", CoverageRestrictionData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func QueryCoverageRestrictionDataProcedure(ctx context.Context, collName string, ueId string) (*map[string]interface{}, *models.ProblemDetails) {
+	coverageRestrictionData, problemDetails := getDataFromDB(ctx, collName, bson.M{"ueId": ueId})
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+	delete(coverageRestrictionData, "ueId")
+	return &coverageRestrictionData, nil
+}
+
+func HandleCreateOrReplaceCoverageRestrictionData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateOrReplaceCoverageRestrictionData")
+
+	enhancedCoverageRestrictionData := request.Body.(models.EnhancedCoverageRestrictionData)
+	collName := SUBSCDATA_PROVDATA_COVERAGE_RESTRICTION
+	ueId := request.Params["ueId"]
+
+	response, status := CreateOrReplaceCoverageRestrictionDataProcedure(ctx, enhancedCoverageRestrictionData, collName, ueId)
+
+	switch status {
+	case http.StatusCreated:
+		stats.IncrementUdrSubscriptionDataStats("create", CoverageRestrictionData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusCreated, nil, response)
+	case http.StatusNoContent:
+		stats.IncrementUdrSubscriptionDataStats("create", CoverageRestrictionData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("create", CoverageRestrictionData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func CreateOrReplaceCoverageRestrictionDataProcedure(ctx context.Context, enhancedCoverageRestrictionData models.EnhancedCoverageRestrictionData,
+	collName string, ueId string,
+) (*models.EnhancedCoverageRestrictionData, int) {
+	putData := util.ToBsonM(enhancedCoverageRestrictionData)
+	putData["ueId"] = ueId
+
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, bson.M{"ueId": ueId}, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+
+	if !isExisted {
+		return &enhancedCoverageRestrictionData, http.StatusCreated
+	}
+	return &enhancedCoverageRestrictionData, http.StatusNoContent
+}
+
+func HandleDeleteCoverageRestrictionData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteCoverageRestrictionData")
+
+	collName := SUBSCDATA_PROVDATA_COVERAGE_RESTRICTION
+	ueId := request.Params["ueId"]
+
+	if err := deleteDataFromDB(ctx, collName, bson.M{"ueId": ueId}); err != nil {
+		pd := utils.ProblemDetailsSystemFailure(err.Error())
+		stats.IncrementUdrSubscriptionDataStats("delete", CoverageRestrictionData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+	stats.IncrementUdrSubscriptionDataStats("delete", CoverageRestrictionData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+}
+
+// groupMembershipFilter matches the membership document of a group by either
+// its internal or its external identifier, which is what the ueGroupId of
+// the group EE subscription resources may carry.
+func groupMembershipFilter(groupId string) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"internalGroupId": groupId},
+		bson.M{"externalGroupId": groupId},
+	}}
+}
+
+// groupMemberUeIds returns the UE IDs recorded as members of the group, or
+// nil if the group has no membership document.
+func groupMemberUeIds(ctx context.Context, groupId string) []string {
+	membership, problemDetails := getDataFromDB(ctx, SUBSCDATA_GROUPDATA_MEMBERSHIP, groupMembershipFilter(groupId))
+	if problemDetails != nil {
+		return nil
+	}
+	return membershipUeIds(membership)
+}
+
+func membershipUeIds(membership map[string]interface{}) []string {
+	var ueIds []string
+	switch members := membership["ueIds"].(type) {
+	case []interface{}:
+		for _, member := range members {
+			if ueId, ok := member.(string); ok {
+				ueIds = append(ueIds, ueId)
+			}
+		}
+	case bson.A:
+		for _, member := range members {
+			if ueId, ok := member.(string); ok {
+				ueIds = append(ueIds, ueId)
+			}
+		}
+	case []string:
+		ueIds = append(ueIds, members...)
+	}
+	return ueIds
+}
+
+func addMemberEeSubscription(ctx context.Context, ueId string, subsId string, eeSubscription *models.EeSubscription) {
+	UESubsData := loadOrCreateUESubsData(ctx, ueId)
+	if UESubsData.EeSubscriptionCollection == nil {
+		UESubsData.EeSubscriptionCollection = make(map[string]*udr_context.EeSubscriptionCollection)
+	}
+	eeSubscriptionCollection, ok := UESubsData.EeSubscriptionCollection[subsId]
+	if !ok {
+		eeSubscriptionCollection = new(udr_context.EeSubscriptionCollection)
+		UESubsData.EeSubscriptionCollection[subsId] = eeSubscriptionCollection
+	}
+	eeSubscriptionCollection.EeSubscriptions = eeSubscription
+	if err := putEeSubscription(ctx, ueId, subsId, eeSubscriptionCollection); err != nil {
+		logger.DataRepoLog.Warnf("persist EE subscription %s of member %s failed: %+v", subsId, ueId, err)
+	}
+}
+
+func removeMemberEeSubscription(ctx context.Context, ueId string, subsId string) {
+	if err := deleteEeSubscription(ctx, ueId, subsId); err != nil {
+		logger.DataRepoLog.Warnf("delete EE subscription %s of member %s failed: %+v", subsId, ueId, err)
+	}
+}
+
+func HandleGetGroupIdentifiers(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle GetGroupIdentifiers")
+
+	collName := SUBSCDATA_GROUPDATA_MEMBERSHIP
+	extGroupId := request.Query.Get("ext-group-id")
+	intGroupId := request.Query.Get("int-group-id")
+	ueIdInd := request.Query.Get("ue-id-ind") == "true"
+
+	response, problemDetails := GetGroupIdentifiersProcedure(ctx, collName, extGroupId, intGroupId, ueIdInd)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", GroupIdentifiers, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", GroupIdentifiers, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", GroupIdentifiers, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+// GetGroupIdentifiersProcedure translates an external group ID into the
+// internal one or vice versa. Exactly one of extGroupId and intGroupId must
+// be given. With ueIdInd set the member UEs are listed as well.
+func GetGroupIdentifiersProcedure(ctx context.Context, collName string, extGroupId string, intGroupId string,
+	ueIdInd bool,
+) (*map[string]interface{}, *models.ProblemDetails) {
+	var filter bson.M
+	switch {
+	case extGroupId != "" && intGroupId == "":
+		filter = bson.M{"externalGroupId": extGroupId}
+	case intGroupId != "" && extGroupId == "":
+		filter = bson.M{"internalGroupId": intGroupId}
+	default:
+		return nil, utils.ProblemDetailsMalformedRequestSyntax("exactly one of ext-group-id and int-group-id is required")
+	}
+
+	membership, problemDetails := getDataFromDB(ctx, collName, filter)
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+
+	groupIdentifiers := map[string]interface{}{}
+	if externalGroupId, ok := membership["externalGroupId"]; ok {
+		groupIdentifiers["extGroupId"] = externalGroupId
+	}
+	if internalGroupId, ok := membership["internalGroupId"]; ok {
+		groupIdentifiers["intGroupId"] = internalGroupId
+	}
+	if ueIdInd {
+		ueIdList := []map[string]interface{}{}
+		for _, ueId := range membershipUeIds(membership) {
+			ueIdList = append(ueIdList, map[string]interface{}{"supi": ueId})
+		}
+		groupIdentifiers["ueIdList"] = ueIdList
+	}
+	return &groupIdentifiers, nil
+}
+
+// sharedDataReferences lists, per provisioned collection, the attributes
+// through which a UE's subscription data points at shared data.
+var sharedDataReferences = []struct {
+	collName   string
+	attributes []string
+}{
+	{"subscriptionData.provisionedData.amData", []string{"sharedAmDataIds"}},
+	{"subscriptionData.provisionedData.smData", []string{"sharedDnnConfigurationsId", "sharedTraceDataId"}},
+	{"subscriptionData.provisionedData.smfSelectionSubscriptionData", []string{"sharedSnssaiInfosId"}},
+}
+
+func sharedDataResourceUri(sharedDataId string) string {
+	return fmt.Sprintf("%s/subscription-data/shared-data/%s",
+		udr_context.UDR_Self().GetIPv4GroupUri(udr_context.NUDR_DR), sharedDataId)
+}
+
+func HandleGetIndividualSharedData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle GetIndividualSharedData")
+
+	collName := SUBSCDATA_SHAREDDATA
+	sharedDataId := request.Params["sharedDataId"]
+
+	response, problemDetails := GetIndividualSharedDataProcedure(ctx, collName, sharedDataId)
+
+	if response != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", SharedData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrSubscriptionDataStats("get", SharedData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("get", SharedData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func GetIndividualSharedDataProcedure(ctx context.Context, collName string, sharedDataId string) (*map[string]interface{},
+	*models.ProblemDetails,
+) {
+	sharedData, problemDetails := getDataFromDB(ctx, collName, bson.M{"sharedDataId": sharedDataId})
+	if problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+	return &sharedData, nil
+}
+
+func HandleCreateOrReplaceSharedData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateOrReplaceSharedData")
+
+	sharedData := request.Body.(models.SharedData)
+	collName := SUBSCDATA_SHAREDDATA
+	sharedDataId := request.Params["sharedDataId"]
+
+	response, status := CreateOrReplaceSharedDataProcedure(ctx, sharedData, collName, sharedDataId)
+
+	switch status {
+	case http.StatusCreated:
+		stats.IncrementUdrSubscriptionDataStats("create", SharedData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusCreated, nil, response)
+	case http.StatusNoContent:
+		stats.IncrementUdrSubscriptionDataStats("create", SharedData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrSubscriptionDataStats("create", SharedData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func CreateOrReplaceSharedDataProcedure(ctx context.Context, sharedData models.SharedData,
+	collName string, sharedDataId string,
+) (bson.M, int) {
+	filter := bson.M{"sharedDataId": sharedDataId}
+	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
+	if errGetOne != nil {
+		logger.DataRepoLog.Warnln(errGetOne)
+	}
+
+	putData := util.ToBsonM(sharedData)
+	putData["sharedDataId"] = sharedDataId
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, collName, filter, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+
+	if !isExisted {
+		return putData, http.StatusCreated
+	}
+	notifySharedDataChange(sharedDataId, origValue, putData)
+	return putData, http.StatusNoContent
+}
+
+func HandleUpdateSharedData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle UpdateSharedData")
+
+	patchItem := request.Body.([]models.PatchItem)
+	collName := SUBSCDATA_SHAREDDATA
+	sharedDataId := request.Params["sharedDataId"]
+
+	problemDetails := UpdateSharedDataProcedure(ctx, collName, sharedDataId, patchItem)
+
+	if problemDetails == nil {
+		stats.IncrementUdrSubscriptionDataStats("update", SharedData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+	stats.IncrementUdrSubscriptionDataStats("update", SharedData, "FAILURE")
+	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+}
+
+func UpdateSharedDataProcedure(ctx context.Context, collName string, sharedDataId string,
+	patchItem []models.PatchItem,
+) *models.ProblemDetails {
+	filter := bson.M{"sharedDataId": sharedDataId}
+	origValue, problemDetails := getDataFromDB(ctx, collName, filter)
+	if problemDetails != nil {
+		return utils.ProblemDetailsDataNotFound()
+	}
+
+	patchJSON, err := json.Marshal(patchItem)
+	if err != nil {
+		logger.DataRepoLog.Errorln(err)
+		return utils.ProblemDetailsMalformedRequestSyntax(err.Error())
+	}
+	if err = CommonDBClient.RestfulAPIJSONPatch(ctx, collName, filter, patchJSON); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
+	}
+
+	newValue, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
+	if errGetOne != nil {
+		logger.DataRepoLog.Warnln(errGetOne)
+	}
+	notifySharedDataChange(sharedDataId, origValue, newValue)
+	return nil
+}
+
+func HandleDeleteSharedData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteSharedData")
+
+	collName := SUBSCDATA_SHAREDDATA
+	sharedDataId := request.Params["sharedDataId"]
+
+	if err := DeleteSharedDataProcedure(ctx, collName, sharedDataId); err != nil {
+		pd := utils.ProblemDetailsSystemFailure(err.Error())
+		stats.IncrementUdrSubscriptionDataStats("delete", SharedData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+	stats.IncrementUdrSubscriptionDataStats("delete", SharedData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+}
+
+func DeleteSharedDataProcedure(ctx context.Context, collName string, sharedDataId string) error {
+	filter := bson.M{"sharedDataId": sharedDataId}
+	origValue, errGetOne := CommonDBClient.RestfulAPIGetOne(ctx, collName, filter)
+	if errGetOne != nil {
+		logger.DataRepoLog.Warnln(errGetOne)
+	}
+	if err := deleteDataFromDB(ctx, collName, filter); err != nil {
+		return err
+	}
+	if origValue != nil {
+		notifySharedDataChange(sharedDataId, origValue, nil)
+	}
+	return nil
+}
+
+func HandleQuerySharedDataReferences(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle QuerySharedDataReferences")
+
+	sharedDataId := request.Params["sharedDataId"]
+
+	ueIds := SharedDataReferencingUeIds(ctx, sharedDataId)
+	stats.IncrementUdrSubscriptionDataStats("get", SharedData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusOK, nil, map[string]interface{}{"ueIds": ueIds})
+}
+
+// SharedDataReferencingUeIds returns the UEs whose provisioned data refers
+// to sharedDataId, each UE listed once.
+func SharedDataReferencingUeIds(ctx context.Context, sharedDataId string) []string {
+	ueIds := []string{}
+	seen := make(map[string]bool)
+	for _, reference := range sharedDataReferences {
+		var conditions bson.A
+		for _, attribute := range reference.attributes {
+			conditions = append(conditions, bson.M{attribute: sharedDataId})
+		}
+		documents, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, reference.collName, bson.M{"$or": conditions})
+		if errGetMany != nil {
+			logger.DataRepoLog.Warnln(errGetMany)
+			continue
+		}
+		for _, document := range documents {
+			ueId, ok := document["ueId"].(string)
+			if !ok || seen[ueId] {
+				continue
+			}
+			seen[ueId] = true
+			ueIds = append(ueIds, ueId)
+		}
+	}
+	return ueIds
+}
+
+// sharedDataNotifyBatch bounds the UEs whose subscriptions are read in one
+// query when a shared data change is reported.
+const sharedDataNotifyBatch = 500
+
+// notifySharedDataChange reports a change of shared data to the SDM
+// subscribers of every UE referencing it. Shared data may be referenced by
+// many UEs, so the fan-out runs as a single task on the notification pool
+// rather than in the request, reading the subscribers in batches.
+func notifySharedDataChange(sharedDataId string, origValue interface{}, newValue interface{}) {
+	changes := changeItems(origValue, newValue)
+	if len(changes) == 0 {
+		return
+	}
+	notifyItems := []models.NotifyItem{*models.NewNotifyItem(sharedDataResourceUri(sharedDataId), changes)}
+
+	callback.Run("shared data change notification of "+sharedDataId, func(ctx context.Context) {
+		for ueIds := range slices.Chunk(SharedDataReferencingUeIds(ctx, sharedDataId), sharedDataNotifyBatch) {
+			refreshSubscriptionDataSubscriptions(ctx, ueIds...)
+			for _, ueId := range ueIds {
+				callback.SendOnDataChangeNotify(ueId, notifyItems)
+			}
+		}
+	})
+}
+
+// queryValues returns the values of a string array query parameter, which
+// clients send either as repeated parameters or as one comma-separated list.
+func queryValues(query map[string][]string, key string) []string {
+	var values []string
+	for _, value := range query[key] {
+		for _, v := range strings.Split(value, ",") {
+			if v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+func HandleReadIPTVConfigurationData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle ReadIPTVConfigurationData")
+
+	allDatas, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, APPDATA_IPTVCONFIGDATA_DB_COLLECTION_NAME, bson.M{})
+	if errGetMany != nil {
+		logger.DataRepoLog.Warnln(errGetMany)
+		pd := utils.ProblemDetailsSystemFailure(errGetMany.Error())
+		stats.IncrementUdrApplicationDataStats("get", IPTVConfigData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+
+	matchedDatas := filterDataByString("configurationId", queryValues(request.Query, "config-ids"), allDatas)
+	matchedDatas = filterDataByString("dnn", queryValues(request.Query, "dnns"), matchedDatas)
+	matchedDatas = filterDataByString("supi", queryValues(request.Query, "supis"), matchedDatas)
+	matchedDatas = filterDataByString("interGroupId", queryValues(request.Query, "inter-group-ids"), matchedDatas)
+	matchedDatas = filterDataBySnssai(request.Query["snssais"], matchedDatas)
+	response := []map[string]interface{}{}
+	for _, d := range matchedDatas {
+		// Delete "_id" entry which is auto-inserted by MongoDB
+		delete(d, "_id")
+		// Delete "configurationId" entry which is added by us
+		delete(d, "configurationId")
+		response = append(response, d)
+	}
+
+	stats.IncrementUdrApplicationDataStats("get", IPTVConfigData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusOK, nil, response)
+}
+
+func HandleCreateOrReplaceIPTVConfigurationData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateOrReplaceIPTVConfigurationData")
+
+	data := request.Body.(models.IptvConfigData)
+	configurationId := request.Params["configurationId"]
+
+	response, status := CreateOrReplaceIPTVConfigurationDataProcedure(ctx, configurationId, data)
+
+	switch status {
+	case http.StatusCreated, http.StatusOK:
+		PreHandleApplicationDataChangeNotification(ApplicationDataIndIptv, "/application-data/iptvConfigData/"+configurationId, response)
+		stats.IncrementUdrApplicationDataStats("create", IPTVConfigData, "SUCCESS")
+		return httpwrapper.NewResponse(status, nil, response)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrApplicationDataStats("create", IPTVConfigData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func CreateOrReplaceIPTVConfigurationDataProcedure(ctx context.Context, configurationId string, data models.IptvConfigData) (bson.M, int) {
+	filter := bson.M{"configurationId": configurationId}
+	putData := util.ToBsonM(data)
+
+	// Add "configurationId" entry to DB
+	putData["configurationId"] = configurationId
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, APPDATA_IPTVCONFIGDATA_DB_COLLECTION_NAME, filter, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+	// Roll back to origin data before return
+	delete(putData, "configurationId")
+
+	if isExisted {
+		return putData, http.StatusOK
+	}
+	return putData, http.StatusCreated
+}
+
+func HandleUpdateIPTVConfigurationData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle UpdateIPTVConfigurationData")
+
+	patch := request.Body.(models.IptvConfigDataPatch)
+	configurationId := request.Params["configurationId"]
+
+	response, problemDetails := UpdateIPTVConfigurationDataProcedure(ctx, configurationId, patch)
+
+	if response != nil {
+		PreHandleApplicationDataChangeNotification(ApplicationDataIndIptv, "/application-data/iptvConfigData/"+configurationId, response)
+		stats.IncrementUdrApplicationDataStats("update", IPTVConfigData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrApplicationDataStats("update", IPTVConfigData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrApplicationDataStats("update", IPTVConfigData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func UpdateIPTVConfigurationDataProcedure(ctx context.Context, configurationId string, patch models.IptvConfigDataPatch) (map[string]interface{},
+	*models.ProblemDetails,
+) {
+	filter := bson.M{"configurationId": configurationId}
+	if _, problemDetails := getDataFromDB(ctx, APPDATA_IPTVCONFIGDATA_DB_COLLECTION_NAME, filter); problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+
+	if err := CommonDBClient.RestfulAPIMergePatch(ctx, APPDATA_IPTVCONFIGDATA_DB_COLLECTION_NAME, filter, util.ToBsonM(patch)); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return nil, utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
+	}
+
+	newData, problemDetails := getDataFromDB(ctx, APPDATA_IPTVCONFIGDATA_DB_COLLECTION_NAME, filter)
+	if problemDetails != nil {
+		return nil, problemDetails
+	}
+	delete(newData, "configurationId")
+	return newData, nil
+}
+
+func HandleDeleteIPTVConfigurationData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteIPTVConfigurationData")
+
+	configurationId := request.Params["configurationId"]
+
+	if err := deleteDataFromDB(ctx, APPDATA_IPTVCONFIGDATA_DB_COLLECTION_NAME, bson.M{"configurationId": configurationId}); err != nil {
+		pd := utils.ProblemDetailsSystemFailure(err.Error())
+		stats.IncrementUdrApplicationDataStats("delete", IPTVConfigData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+	PreHandleApplicationDataChangeNotification(ApplicationDataIndIptv, "/application-data/iptvConfigData/"+configurationId, nil)
+	stats.IncrementUdrApplicationDataStats("delete", IPTVConfigData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+}
+
+func HandleReadServiceParameterData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle ReadServiceParameterData")
+
+	allDatas, errGetMany := CommonDBClient.RestfulAPIGetMany(ctx, APPDATA_SVCPARAMDATA_DB_COLLECTION_NAME, bson.M{})
+	if errGetMany != nil {
+		logger.DataRepoLog.Warnln(errGetMany)
+		pd := utils.ProblemDetailsSystemFailure(errGetMany.Error())
+		stats.IncrementUdrApplicationDataStats("get", ServiceParamData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+
+	matchedDatas := filterDataByString("serviceParamId", queryValues(request.Query, "service-param-ids"), allDatas)
+	matchedDatas = filterDataByString("dnn", queryValues(request.Query, "dnns"), matchedDatas)
+	matchedDatas = filterDataByString("supi", queryValues(request.Query, "supis"), matchedDatas)
+	matchedDatas = filterDataByString("interGroupId", queryValues(request.Query, "internal-group-ids"), matchedDatas)
+	matchedDatas = filterDataBySnssai(request.Query["snssais"], matchedDatas)
+	response := []map[string]interface{}{}
+	for _, d := range matchedDatas {
+		// Delete "_id" entry which is auto-inserted by MongoDB
+		delete(d, "_id")
+		// Delete "serviceParamId" entry which is added by us
+		delete(d, "serviceParamId")
+		response = append(response, d)
+	}
+
+	stats.IncrementUdrApplicationDataStats("get", ServiceParamData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusOK, nil, response)
+}
+
+func HandleCreateOrReplaceServiceParameterData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateOrReplaceServiceParameterData")
+
+	data := request.Body.(models.ServiceParameterData)
+	serviceParamId := request.Params["serviceParamId"]
+
+	response, status := CreateOrReplaceServiceParameterDataProcedure(ctx, serviceParamId, data)
+
+	switch status {
+	case http.StatusCreated, http.StatusOK:
+		PreHandleApplicationDataChangeNotification(ApplicationDataIndSvcParam, "/application-data/serviceParamData/"+serviceParamId, response)
+		stats.IncrementUdrApplicationDataStats("create", ServiceParamData, "SUCCESS")
+		return httpwrapper.NewResponse(status, nil, response)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrApplicationDataStats("create", ServiceParamData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func CreateOrReplaceServiceParameterDataProcedure(ctx context.Context, serviceParamId string, data models.ServiceParameterData) (bson.M, int) {
+	filter := bson.M{"serviceParamId": serviceParamId}
+	putData := util.ToBsonM(data)
+
+	// Add "serviceParamId" entry to DB
+	putData["serviceParamId"] = serviceParamId
+	isExisted, errPutOne := CommonDBClient.RestfulAPIPutOne(ctx, APPDATA_SVCPARAMDATA_DB_COLLECTION_NAME, filter, putData)
+	if errPutOne != nil {
+		logger.DataRepoLog.Warnln(errPutOne)
+		return nil, http.StatusInternalServerError
+	}
+	// Roll back to origin data before return
+	delete(putData, "serviceParamId")
+
+	if isExisted {
+		return putData, http.StatusOK
+	}
+	return putData, http.StatusCreated
+}
+
+func HandleUpdateServiceParameterData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle UpdateServiceParameterData")
+
+	patch := request.Body.(models.ServiceParameterDataPatch)
+	serviceParamId := request.Params["serviceParamId"]
+
+	response, problemDetails := UpdateServiceParameterDataProcedure(ctx, serviceParamId, patch)
+
+	if response != nil {
+		PreHandleApplicationDataChangeNotification(ApplicationDataIndSvcParam, "/application-data/serviceParamData/"+serviceParamId, response)
+		stats.IncrementUdrApplicationDataStats("update", ServiceParamData, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	} else if problemDetails != nil {
+		stats.IncrementUdrApplicationDataStats("update", ServiceParamData, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	pd := utils.ProblemDetailsUnspecified()
+	stats.IncrementUdrApplicationDataStats("update", ServiceParamData, "FAILURE")
+	return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+}
+
+func UpdateServiceParameterDataProcedure(ctx context.Context, serviceParamId string, patch models.ServiceParameterDataPatch) (map[string]interface{},
+	*models.ProblemDetails,
+) {
+	filter := bson.M{"serviceParamId": serviceParamId}
+	if _, problemDetails := getDataFromDB(ctx, APPDATA_SVCPARAMDATA_DB_COLLECTION_NAME, filter); problemDetails != nil {
+		return nil, utils.ProblemDetailsDataNotFound()
+	}
+
+	if err := CommonDBClient.RestfulAPIMergePatch(ctx, APPDATA_SVCPARAMDATA_DB_COLLECTION_NAME, filter, util.ToBsonM(patch)); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return nil, utils.ProblemDetailsWithCause("Modify not allowed", http.StatusForbidden, "", utils.CauseModifyNotAllowed)
+	}
+
+	newData, problemDetails := getDataFromDB(ctx, APPDATA_SVCPARAMDATA_DB_COLLECTION_NAME, filter)
+	if problemDetails != nil {
+		return nil, problemDetails
+	}
+	delete(newData, "serviceParamId")
+	return newData, nil
+}
+
+func HandleDeleteServiceParameterData(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteServiceParameterData")
+
+	serviceParamId := request.Params["serviceParamId"]
+
+	if err := deleteDataFromDB(ctx, APPDATA_SVCPARAMDATA_DB_COLLECTION_NAME, bson.M{"serviceParamId": serviceParamId}); err != nil {
+		pd := utils.ProblemDetailsSystemFailure(err.Error())
+		stats.IncrementUdrApplicationDataStats("delete", ServiceParamData, "FAILURE")
+		return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+	}
+	PreHandleApplicationDataChangeNotification(ApplicationDataIndSvcParam, "/application-data/serviceParamData/"+serviceParamId, nil)
+	stats.IncrementUdrApplicationDataStats("delete", ServiceParamData, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+}
+
+func HandleCreateApplicationDataSubscription(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle CreateApplicationDataSubscription")
+
+	applicationDataSubs := request.Body.(models.ApplicationDataSubs)
+
+	locationHeader, problemDetails := CreateApplicationDataSubscriptionProcedure(ctx, applicationDataSubs)
+	if problemDetails != nil {
+		stats.IncrementUdrApplicationDataStats("create", SubsToNotify, "FAILURE")
+		return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+	}
+
+	headers := http.Header{}
+	headers.Set("Location", locationHeader)
+	stats.IncrementUdrApplicationDataStats("create", SubsToNotify, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusCreated, headers, applicationDataSubs)
+}
+
+// CreateApplicationDataSubscriptionProcedure stores applicationDataSubs and
+// returns its URI. Subscriptions to data that is never notified are rejected.
+func CreateApplicationDataSubscriptionProcedure(ctx context.Context, applicationDataSubs models.ApplicationDataSubs) (string, *models.ProblemDetails) {
+	if problemDetails := unsupportedApplicationDataProblem(applicationDataSubs); problemDetails != nil {
+		return "", problemDetails
+	}
+	udrSelf := udr_context.UDR_Self()
+
+	udrSelf.ApplicationDataSubsMtx.Lock()
+	newSubscriptionID := strconv.Itoa(udrSelf.ApplicationDataSubscriptionIDGenerator)
+	udrSelf.ApplicationDataSubscriptions[newSubscriptionID] = &applicationDataSubs
+	udrSelf.ApplicationDataSubscriptionIDGenerator++
+	udrSelf.ApplicationDataSubsMtx.Unlock()
+
+	/* Contains the URI of the newly created resource, according
+	   to the structure: {apiRoot}/application-data/subs-to-notify/{subsId} */
+	locationHeader := fmt.Sprintf("%s/application-data/subs-to-notify/%s", udrSelf.GetIPv4GroupUri(udr_context.NUDR_DR),
+		newSubscriptionID)
+
+	return locationHeader, nil
+}
+
+// unsupportedApplicationDataProblem rejects dataFilters for data this UDR
+// does not store, such as BDT policy data, which would never be notified.
+func unsupportedApplicationDataProblem(applicationDataSubs models.ApplicationDataSubs) *models.ProblemDetails {
+	if dataInd := unsupportedApplicationDataInd(applicationDataSubs); dataInd != "" {
+		return utils.ProblemDetailsMalformedRequestSyntax(
+			fmt.Sprintf("dataFilters: dataInd %s is not supported", dataInd))
+	}
+	return nil
+}
+
+func HandleReadApplicationDataSubscriptions(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle ReadApplicationDataSubscriptions")
+
+	var dataFilter map[string]interface{}
+	if value := request.Query.Get("data-filter"); value != "" {
+		if err := json.Unmarshal([]byte(value), &dataFilter); err != nil {
+			pd := utils.ProblemDetailsMalformedRequestSyntax("[Query] data-filter: " + err.Error())
+			stats.IncrementUdrApplicationDataStats("get", SubsToNotify, "FAILURE")
+			return httpwrapper.NewResponse(int(pd.GetStatus()), nil, pd)
+		}
+	}
+
+	response := ReadApplicationDataSubscriptionsProcedure(ctx, dataFilter)
+	stats.IncrementUdrApplicationDataStats("get", SubsToNotify, "SUCCESS")
+	return httpwrapper.NewResponse(http.StatusOK, nil, response)
+}
+
+// ReadApplicationDataSubscriptionsProcedure returns the subscriptions having
+// a dataFilter for the dataInd of dataFilter, or all of them when dataFilter
+// is nil.
+func ReadApplicationDataSubscriptionsProcedure(ctx context.Context, dataFilter map[string]interface{}) []models.ApplicationDataSubs {
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.ApplicationDataSubsMtx.RLock()
+	defer udrSelf.ApplicationDataSubsMtx.RUnlock()
+
+	dataInd, _ := dataFilter["dataInd"].(string)
+	response := []models.ApplicationDataSubs{}
+	for _, applicationDataSubs := range udrSelf.ApplicationDataSubscriptions {
+		if dataFilter == nil || applicationDataSubsMatches(*applicationDataSubs, dataInd, nil) {
+			response = append(response, *applicationDataSubs)
+		}
+	}
+	return response
+}
+
+func HandleReadApplicationDataSubscription(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle ReadApplicationDataSubscription")
+
+	subsId := request.Params["subsId"]
+
+	response, problemDetails := ReadApplicationDataSubscriptionProcedure(ctx, subsId)
+
+	if problemDetails == nil {
+		stats.IncrementUdrApplicationDataStats("get", SubsToNotify, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	}
+	stats.IncrementUdrApplicationDataStats("get", SubsToNotify, "FAILURE")
+	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+}
+
+func ReadApplicationDataSubscriptionProcedure(ctx context.Context, subsId string) (*models.ApplicationDataSubs, *models.ProblemDetails) {
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.ApplicationDataSubsMtx.RLock()
+	defer udrSelf.ApplicationDataSubsMtx.RUnlock()
+
+	applicationDataSubs, ok := udrSelf.ApplicationDataSubscriptions[subsId]
+	if !ok {
+		return nil, utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
+	}
+	return applicationDataSubs, nil
+}
+
+func HandleReplaceApplicationDataSubscription(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle ReplaceApplicationDataSubscription")
+
+	subsId := request.Params["subsId"]
+	applicationDataSubs := request.Body.(models.ApplicationDataSubs)
+
+	response, problemDetails := ReplaceApplicationDataSubscriptionProcedure(ctx, subsId, applicationDataSubs)
+
+	if problemDetails == nil {
+		stats.IncrementUdrApplicationDataStats("update", SubsToNotify, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusOK, nil, response)
+	}
+	stats.IncrementUdrApplicationDataStats("update", SubsToNotify, "FAILURE")
+	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+}
+
+func ReplaceApplicationDataSubscriptionProcedure(ctx context.Context, subsId string,
+	applicationDataSubs models.ApplicationDataSubs,
+) (*models.ApplicationDataSubs, *models.ProblemDetails) {
+	if problemDetails := unsupportedApplicationDataProblem(applicationDataSubs); problemDetails != nil {
+		return nil, problemDetails
+	}
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.ApplicationDataSubsMtx.Lock()
+	defer udrSelf.ApplicationDataSubsMtx.Unlock()
+
+	if _, ok := udrSelf.ApplicationDataSubscriptions[subsId]; !ok {
+		return nil, utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
+	}
+	udrSelf.ApplicationDataSubscriptions[subsId] = &applicationDataSubs
+
+	return &applicationDataSubs, nil
+}
+
+func HandleDeleteApplicationDataSubscription(ctx context.Context, request *httpwrapper.Request) *httpwrapper.Response {
+	logger.DataRepoLog.Debugln("handle DeleteApplicationDataSubscription")
+
+	subsId := request.Params["subsId"]
+
+	problemDetails := DeleteApplicationDataSubscriptionProcedure(ctx, subsId)
+
+	if problemDetails == nil {
+		stats.IncrementUdrApplicationDataStats("delete", SubsToNotify, "SUCCESS")
+		return httpwrapper.NewResponse(http.StatusNoContent, nil, map[string]interface{}{})
+	}
+	stats.IncrementUdrApplicationDataStats("delete", SubsToNotify, "FAILURE")
+	return httpwrapper.NewResponse(int(problemDetails.GetStatus()), nil, problemDetails)
+}
+
+func DeleteApplicationDataSubscriptionProcedure(ctx context.Context, subsId string) *models.ProblemDetails {
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.ApplicationDataSubsMtx.Lock()
+	defer udrSelf.ApplicationDataSubsMtx.Unlock()
+
+	if _, ok := udrSelf.ApplicationDataSubscriptions[subsId]; !ok {
+		return utils.ProblemDetailsWithCause("Subscription not found", http.StatusNotFound, "", utils.CauseSubscriptionNotFound)
+	}
+	delete(udrSelf.ApplicationDataSubscriptions, subsId)
+
+	return nil
+}
@@ -4,12 +4,18 @@
 package producer
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/omec-project/openapi/v2/models"
-	udr_context "github.com/omec-project/udr/context"
+	"github.com/omec-project/util/httpwrapper"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -166,6 +172,306 @@ func TestAddDotSafeKeyExistsFilterMergesExistingExpr(t *testing.T) {
 	}
 }
 
+func TestProvisionedDataFilterMatchesServingPlmnOnlyWhenGiven(t *testing.T) {
+	filter := provisionedDataFilter("imsi-001010000000001", "")
+	if _, ok := filter["servingPlmnId"]; ok {
+		t.Fatalf("expected no servingPlmnId predicate for PLMN-less lookup, got %#v", filter)
+	}
+
+	filter = provisionedDataFilter("imsi-001010000000001", "00101")
+	if got, ok := filter["servingPlmnId"].(string); !ok || got != "00101" {
+		t.Fatalf("expected servingPlmnId predicate 00101, got %#v", filter["servingPlmnId"])
+	}
+	if got, ok := filter["ueId"].(string); !ok || got != "imsi-001010000000001" {
+		t.Fatalf("expected ueId predicate, got %#v", filter["ueId"])
+	}
+}
+
+func TestQuerySidelinkDataSetsOnlyReturnsRequestedDataSets(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := &stubDB{result: map[string]any{
+		"ueId":      "imsi-001010000000001",
+		"v2xNrAuth": "AUTHORIZED",
+	}}
+	CommonDBClient = db
+
+	dataSets := querySidelinkDataSets(context.Background(), "imsi-001010000000001", []string{"AM", "V2X", "A2X"})
+	if len(dataSets) != 2 {
+		t.Fatalf("expected v2xData and a2xData only, got %#v", dataSets)
+	}
+	v2xData, ok := dataSets["v2xData"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected v2xData in result, got %#v", dataSets)
+	}
+	if _, ok := v2xData["ueId"]; ok {
+		t.Fatalf("expected ueId to be stripped from v2xData, got %#v", v2xData)
+	}
+	if _, ok := dataSets["proseData"]; ok {
+		t.Fatal("expected proseData to be omitted when not requested")
+	}
+	if got := db.getCallCount(); got != 2 {
+		t.Fatalf("expected 2 database lookups, got %d", got)
+	}
+
+	if dataSets = querySidelinkDataSets(context.Background(), "imsi-001010000000001", nil); len(dataSets) != 0 {
+		t.Fatalf("expected no sidelink datasets without dataset-names, got %#v", dataSets)
+	}
+}
+
+func TestQueryProvisionedDataProcedureReturnsSidelinkDataSets(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := newMemDB()
+	CommonDBClient = db
+
+	const ueId = "imsi-001010000000001"
+	db.collections["subscriptionData.provisionedData.amData"] = []map[string]any{
+		{"ueId": ueId, "servingPlmnId": "00101", "gpsis": []string{"msisdn-0900000000"}},
+	}
+	db.collections[SUBSCDATA_PROVDATA_V2X] = []map[string]any{{"ueId": ueId, "nrV2xServicesAuth": "AUTHORIZED"}}
+	db.collections[SUBSCDATA_PROVDATA_PROSE] = []map[string]any{{"ueId": ueId, "proseServiceAuth": "AUTHORIZED"}}
+
+	response, pd := QueryProvisionedDataProcedure(context.Background(), ueId, "00101", []string{"AM", "V2X", "PROSE"})
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	for _, attribute := range []string{"amData", "v2xData", "proseData"} {
+		if _, ok := response[attribute]; !ok {
+			t.Errorf("expected %s in the response, got %#v", attribute, response)
+		}
+	}
+
+	// Sidelink data alone is enough for the UE to be found.
+	delete(db.collections, "subscriptionData.provisionedData.amData")
+	response, pd = QueryProvisionedDataProcedure(context.Background(), ueId, "00101", []string{"V2X"})
+	if pd != nil || response["v2xData"] == nil {
+		t.Fatalf("expected only v2xData, got %#v, %+v", response, pd)
+	}
+}
+
+func TestParsePduSessionIdRejectsOutOfRangeValues(t *testing.T) {
+	if got, pd := parsePduSessionId("5"); pd != nil || got != 5 {
+		t.Fatalf("expected pduSessionId 5, got %d (%v)", got, pd)
+	}
+	for _, pduSessionId := range []string{"", "abc", "-1", "256"} {
+		if _, pd := parsePduSessionId(pduSessionId); pd == nil {
+			t.Fatalf("expected pduSessionId %q to be rejected", pduSessionId)
+		}
+	}
+}
+
+func TestRegistrationLocationInfoFromAmfRegistration(t *testing.T) {
+	plmnId := map[string]interface{}{"mcc": "001", "mnc": "01"}
+	registration := map[string]interface{}{
+		"amfInstanceId": "c2ff5a9e-8d1f-4b0a-9c3e-2f1e5b6d7a80",
+		"guami":         map[string]interface{}{"plmnId": plmnId, "amfId": "cafe00"},
+	}
+
+	info := registrationLocationInfoFromAmfRegistration(registration, "3GPP_ACCESS")
+	if got := info["amfInstanceId"]; got != registration["amfInstanceId"] {
+		t.Fatalf("expected amfInstanceId to be copied, got %#v", got)
+	}
+	if got, ok := info["plmnId"].(map[string]interface{}); !ok || got["mcc"] != "001" {
+		t.Fatalf("expected plmnId taken from the GUAMI, got %#v", info["plmnId"])
+	}
+	if got, ok := info["accessTypeList"].([]string); !ok || len(got) != 1 || got[0] != "3GPP_ACCESS" {
+		t.Fatalf("expected accessTypeList [3GPP_ACCESS], got %#v", info["accessTypeList"])
+	}
+	if _, ok := info["vgmlcAddress"]; ok {
+		t.Fatal("expected no vgmlcAddress when the registration has none")
+	}
+}
+
+func TestQueryUserConsentDataProcedureFiltersByPurpose(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	CommonDBClient = &stubDB{result: map[string]any{
+		"ueId": "imsi-001010000000001",
+		"userConsentPerPurposeList": map[string]interface{}{
+			"ANALYTICS":      "CONSENT_GIVEN",
+			"MODEL_TRAINING": "CONSENT_NOT_GIVEN",
+		},
+	}}
+
+	ucData, pd := QueryUserConsentDataProcedure(context.Background(), SUBSCDATA_PROVDATA_USER_CONSENT, "imsi-001010000000001", "ANALYTICS")
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	perPurpose, ok := (*ucData)["userConsentPerPurposeList"].(map[string]interface{})
+	if !ok || len(perPurpose) != 1 || perPurpose["ANALYTICS"] != "CONSENT_GIVEN" {
+		t.Fatalf("expected only the ANALYTICS consent, got %#v", (*ucData)["userConsentPerPurposeList"])
+	}
+
+	if _, pd = QueryUserConsentDataProcedure(context.Background(), SUBSCDATA_PROVDATA_USER_CONSENT, "imsi-001010000000001", "NSED"); pd == nil {
+		t.Fatal("expected not found for a purpose without recorded consent")
+	}
+
+	ucData, pd = QueryUserConsentDataProcedure(context.Background(), SUBSCDATA_PROVDATA_USER_CONSENT, "imsi-001010000000001", "")
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	if perPurpose, _ = (*ucData)["userConsentPerPurposeList"].(map[string]interface{}); len(perPurpose) != 2 {
+		t.Fatalf("expected all purposes without uc-purpose, got %#v", perPurpose)
+	}
+}
+
+func TestGetGroupIdentifiersProcedure(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	CommonDBClient = &stubDB{result: map[string]any{
+		"externalGroupId": "fleet@example.com",
+		"internalGroupId": "00101-01-0001",
+		"ueIds":           bson.A{"imsi-001010000000001", "imsi-001010000000002"},
+	}}
+
+	if _, pd := GetGroupIdentifiersProcedure(context.Background(), SUBSCDATA_GROUPDATA_MEMBERSHIP, "", "", false); pd == nil {
+		t.Fatal("expected a request without group ID to be rejected")
+	}
+	if _, pd := GetGroupIdentifiersProcedure(context.Background(), SUBSCDATA_GROUPDATA_MEMBERSHIP, "fleet@example.com", "00101-01-0001", false); pd == nil {
+		t.Fatal("expected a request with both group IDs to be rejected")
+	}
+
+	groupIdentifiers, pd := GetGroupIdentifiersProcedure(context.Background(), SUBSCDATA_GROUPDATA_MEMBERSHIP, "fleet@example.com", "", false)
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	if got := (*groupIdentifiers)["intGroupId"]; got != "00101-01-0001" {
+		t.Fatalf("expected intGroupId 00101-01-0001, got %#v", got)
+	}
+	if _, ok := (*groupIdentifiers)["ueIdList"]; ok {
+		t.Fatal("expected no ueIdList without ue-id-ind")
+	}
+
+	groupIdentifiers, pd = GetGroupIdentifiersProcedure(context.Background(), SUBSCDATA_GROUPDATA_MEMBERSHIP, "", "00101-01-0001", true)
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	if ueIdList, ok := (*groupIdentifiers)["ueIdList"].([]map[string]interface{}); !ok || len(ueIdList) != 2 {
+		t.Fatalf("expected two members in ueIdList, got %#v", (*groupIdentifiers)["ueIdList"])
+	}
+}
+
+func TestEeGroupSubscriptionIsExpandedToMembers(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := newMemDB()
+	CommonDBClient = db
+	members := []string{"imsi-001010000000101", "imsi-001010000000102"}
+	db.collections[SUBSCDATA_GROUPDATA_MEMBERSHIP] = []map[string]any{{
+		"internalGroupId": "00101-01-0101",
+		"ueIds":           bson.A{members[0], members[1]},
+	}}
+
+	CreateEeGroupSubscriptionsProcedure(context.Background(), "00101-01-0101", models.EeSubscription{})
+	UEGroupSubsData, ok := loadUEGroupSubsData(context.Background(), "00101-01-0101")
+	if !ok {
+		t.Fatal("expected group subscription data to be stored")
+	}
+	var subsId string
+	for id := range UEGroupSubsData.EeSubscriptions {
+		subsId = id
+	}
+
+	for _, ueId := range members {
+		UESubsData, ok := loadUESubsData(context.Background(), ueId)
+		if !ok {
+			t.Fatalf("expected member %s to receive the group subscription", ueId)
+		}
+		if _, ok := UESubsData.EeSubscriptionCollection[subsId]; !ok {
+			t.Fatalf("expected member %s to hold subscription %s", ueId, subsId)
+		}
+	}
+
+	if pd := RemoveEeGroupSubscriptionsProcedure(context.Background(), "00101-01-0101", subsId); pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	for _, ueId := range members {
+		if _, ok := loadUESubsData(context.Background(), ueId); ok {
+			t.Fatalf("expected subscription %s to be removed from member %s", subsId, ueId)
+		}
+	}
+}
+
+// getManyDB serves RestfulAPIGetMany from per-collection fixtures.
+type getManyDB struct {
+	stubDB
+	documents map[string][]map[string]any
+}
+
+func (s *getManyDB) RestfulAPIGetMany(ctx context.Context, collName string, _ bson.M) ([]map[string]any, error) {
+	return s.documents[collName], nil
+}
+
+func TestSharedDataReferencingUeIdsListsEachUeOnce(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	CommonDBClient = &getManyDB{documents: map[string][]map[string]any{
+		"subscriptionData.provisionedData.amData": {
+			{"ueId": "imsi-001010000000001"},
+			{"ueId": "imsi-001010000000002"},
+		},
+		"subscriptionData.provisionedData.smData": {
+			{"ueId": "imsi-001010000000001"},
+			{"ueId": "imsi-001010000000003"},
+		},
+	}}
+
+	ueIds := SharedDataReferencingUeIds(context.Background(), "iot-profile")
+	want := []string{"imsi-001010000000001", "imsi-001010000000002", "imsi-001010000000003"}
+	if fmt.Sprint(ueIds) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, ueIds)
+	}
+}
+
+func TestFilterDataByStringSkipsDocumentsWithoutAttribute(t *testing.T) {
+	datas := []map[string]interface{}{
+		{"interGroupId": "group-a", "dnn": "iptv"},
+		{"supi": "imsi-001010000000001", "dnn": "iptv"},
+	}
+
+	matched := filterDataByString("supi", queryValues(map[string][]string{
+		"supis": {"imsi-001010000000001,imsi-001010000000002"},
+	}, "supis"), datas)
+	if len(matched) != 1 || matched[0]["supi"] != "imsi-001010000000001" {
+		t.Fatalf("expected only the SUPI-specific document, got %#v", matched)
+	}
+
+	if matched = filterDataBySnssai([]string{`{"sst":1}`}, datas); len(matched) != 0 {
+		t.Fatalf("expected documents without snssai not to match, got %#v", matched)
+	}
+}
+
+func TestReadIPTVAndServiceParameterDataFilterByIds(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	CommonDBClient = &getManyDB{documents: map[string][]map[string]any{
+		APPDATA_IPTVCONFIGDATA_DB_COLLECTION_NAME: {
+			{"configurationId": "config-1", "dnn": "iptv"},
+			{"configurationId": "config-2", "dnn": "iptv"},
+			{"configurationId": "config-3", "dnn": "iptv"},
+		},
+		APPDATA_SVCPARAMDATA_DB_COLLECTION_NAME: {
+			{"serviceParamId": "param-1", "dnn": "internet"},
+			{"serviceParamId": "param-2", "dnn": "internet"},
+		},
+	}}
+
+	rsp := HandleReadIPTVConfigurationData(context.Background(), &httpwrapper.Request{
+		Query: url.Values{"config-ids": {"config-1,config-3"}},
+	})
+	if datas, ok := rsp.Body.([]map[string]interface{}); rsp.Status != http.StatusOK || !ok || len(datas) != 2 {
+		t.Errorf("expected the 2 requested IPTV configurations, got %d %#v", rsp.Status, rsp.Body)
+	}
+
+	rsp = HandleReadServiceParameterData(context.Background(), &httpwrapper.Request{
+		Query: url.Values{"service-param-ids": {"param-2"}},
+	})
+	if datas, ok := rsp.Body.([]map[string]interface{}); rsp.Status != http.StatusOK || !ok || len(datas) != 1 {
+		t.Errorf("expected the requested service parameter, got %d %#v", rsp.Status, rsp.Body)
+	}
+}
+
 // TestCreateSdmSubscriptionsProcedureIsConcurrencySafe reproduces the crash
 // seen on a live core once registration concurrency rose: the UDM creates an
 // SDM subscription per registration, and unsynchronised access to
@@ -177,22 +483,9 @@ func TestCreateSdmSubscriptionsProcedureIsConcurrencySafe(t *testing.T) {
 		perUeRequests = 64
 	)
 
-	// UESubsCollection is a process-wide singleton, so leaving these entries
-	// behind would make a second run in the same process (go test -count=2, or
-	// any later test reusing these IDs) see the accumulated subscriptions and
-	// fail the count assertion.
-	udrSelf := udr_context.UDR_Self()
-	ueIds := make([]string, 0, ueCount)
-	for u := range ueCount {
-		ueIds = append(ueIds, fmt.Sprintf("imsi-20893010000%04d", u))
-	}
-	clearUeSubs := func() {
-		for _, id := range ueIds {
-			udrSelf.UESubsCollection.Delete(id)
-		}
-	}
-	clearUeSubs()
-	t.Cleanup(clearUeSubs)
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	CommonDBClient = newMemDB()
 
 	var wg sync.WaitGroup
 	for u := range ueCount {
@@ -201,7 +494,7 @@ func TestCreateSdmSubscriptionsProcedureIsConcurrencySafe(t *testing.T) {
 			wg.Add(1)
 			go func(ueId string) {
 				defer wg.Done()
-				CreateSdmSubscriptionsProcedure(models.SdmSubscription{}, "subscriptionData.contextData.sdmSubscriptions", ueId)
+				CreateSdmSubscriptionsProcedure(context.Background(), models.SdmSubscription{}, "subscriptionData.contextData.sdmSubscriptions", ueId)
 			}(ueId)
 		}
 	}
@@ -212,22 +505,162 @@ func TestCreateSdmSubscriptionsProcedureIsConcurrencySafe(t *testing.T) {
 	seen := make(map[string]string)
 	for u := range ueCount {
 		ueId := fmt.Sprintf("imsi-20893010000%04d", u)
-		value, ok := udrSelf.UESubsCollection.Load(ueId)
-		if !ok {
-			t.Fatalf("no UESubsData stored for %s", ueId)
+		subscriptions, pd := QuerysdmsubscriptionsProcedure(context.Background(), ueId)
+		if pd != nil {
+			t.Fatalf("no subscriptions stored for %s: %+v", ueId, pd)
 		}
-		subs := value.(*udr_context.UESubsData)
-		subs.Mtx.RLock()
-		got := len(subs.SdmSubscriptions)
-		for id := range subs.SdmSubscriptions {
+		got := len(*subscriptions)
+		for _, subscription := range *subscriptions {
+			id := subscription.GetSubscriptionId()
 			if prev, dup := seen[id]; dup {
 				t.Errorf("subscription ID %s reused by %s and %s", id, prev, ueId)
 			}
 			seen[id] = ueId
 		}
-		subs.Mtx.RUnlock()
 		if got != perUeRequests {
 			t.Errorf("%s: got %d subscriptions, want %d", ueId, got, perUeRequests)
 		}
 	}
 }
+
+func TestApplicationDataFilterMatches(t *testing.T) {
+	data := map[string]interface{}{
+		"afAppId":      "app1",
+		"dnn":          "internet",
+		"snssai":       map[string]interface{}{"sst": float64(1), "sd": "010203"},
+		"interGroupId": "group1",
+	}
+
+	cases := []struct {
+		name       string
+		dataFilter map[string]interface{}
+		data       map[string]interface{}
+		want       bool
+	}{
+		{"other dataInd", map[string]interface{}{"dataInd": "PFD"}, data, false},
+		{"dataInd only", map[string]interface{}{"dataInd": "IPTV"}, data, true},
+		{"matching dnn and appId", map[string]interface{}{
+			"dataInd": "IPTV",
+			"dnns":    []interface{}{"ims", "internet"},
+			"appIds":  []interface{}{"app1"},
+		}, data, true},
+		{"other dnn", map[string]interface{}{"dataInd": "IPTV", "dnns": []interface{}{"ims"}}, data, false},
+		{"matching snssai", map[string]interface{}{
+			"dataInd": "IPTV",
+			"snssais": []interface{}{map[string]interface{}{"sst": float64(1), "sd": "010203"}},
+		}, data, true},
+		{"other snssai", map[string]interface{}{
+			"dataInd": "IPTV",
+			"snssais": []interface{}{map[string]interface{}{"sst": float64(2)}},
+		}, data, false},
+		{"supi filter without supi", map[string]interface{}{
+			"dataInd": "IPTV",
+			"supis":   []interface{}{"imsi-208930000000001"},
+		}, data, false},
+		{"deleted resource", map[string]interface{}{"dataInd": "IPTV", "dnns": []interface{}{"ims"}}, nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := applicationDataFilterMatches(tc.dataFilter, "IPTV", tc.data); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplicationDataSubscriptionsToBdtDataAreRejected(t *testing.T) {
+	var applicationDataSubs models.ApplicationDataSubs
+	if err := json.Unmarshal([]byte(`{"notificationUri": "http://nef.example/notify",
+		"dataFilters": [{"dataInd": "PFD"}, {"dataInd": "BDT"}]}`), &applicationDataSubs); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, pd := CreateApplicationDataSubscriptionProcedure(context.Background(), applicationDataSubs); pd == nil ||
+		pd.GetStatus() != http.StatusBadRequest {
+		t.Fatalf("expected a BDT subscription to be rejected, got %+v", pd)
+	}
+	if _, pd := ReplaceApplicationDataSubscriptionProcedure(context.Background(), "1", applicationDataSubs); pd == nil ||
+		pd.GetStatus() != http.StatusBadRequest {
+		t.Fatalf("expected a BDT subscription to be rejected on replace, got %+v", pd)
+	}
+}
+
+// deleteManyDB records the collections passed to RestfulAPIDeleteMany.
+type deleteManyDB struct {
+	getManyDB
+	deleted []string
+}
+
+func (s *deleteManyDB) RestfulAPIDeleteMany(ctx context.Context, collName string, _ bson.M) error {
+	s.deleted = append(s.deleted, collName)
+	return nil
+}
+
+func TestSendDataRestorationNotificationsNotifiesEachCallbackOnce(t *testing.T) {
+	hits := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits <- r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := &deleteManyDB{getManyDB: getManyDB{documents: map[string][]map[string]any{
+		SUBSCDATA_RESTORATION_TARGETS: {
+			{"subsId": "1", "dataRestorationCallbackUri": server.URL + "/udm-1"},
+			{"subsId": "2", "dataRestorationCallbackUri": server.URL + "/udm-1"},
+			{"subsId": "3", "dataRestorationCallbackUri": server.URL + "/udm-2"},
+		},
+	}}}
+	CommonDBClient = db
+
+	SendDataRestorationNotifications(context.Background())
+
+	got := map[string]int{}
+	for range 2 {
+		select {
+		case path := <-hits:
+			got[path]++
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for notifications, got %v", got)
+		}
+	}
+	select {
+	case path := <-hits:
+		t.Fatalf("unexpected extra notification to %s", path)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if got["/udm-1"] != 1 || got["/udm-2"] != 1 {
+		t.Errorf("expected one notification per callback, got %v", got)
+	}
+	if len(db.deleted) != 1 || db.deleted[0] != SUBSCDATA_RESTORATION_TARGETS {
+		t.Errorf("expected restoration targets to be dropped, got %v", db.deleted)
+	}
+}
+
+func TestSessionManagementDataDeleteReportsWhetherDataExisted(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	CommonDBClient = newMemDB()
+
+	const ueId = "imsi-001010000000207"
+	rsp := HandleCreateOrReplaceSessionManagementData(context.Background(), &httpwrapper.Request{
+		Params: map[string]string{"ueId": ueId, "pduSessionId": "5"},
+		Body:   models.PduSessionManagementData{},
+	})
+	if _, ok := rsp.Body.(*models.PduSessionManagementData); rsp.Status != http.StatusCreated || !ok {
+		t.Fatalf("expected 201 with the stored model, got %d %#v", rsp.Status, rsp.Body)
+	}
+
+	for _, want := range []bool{true, false} {
+		deleted, err := DeleteSessionManagementDataProcedure(context.Background(), EXPOSUREDATA_SESSION_MANAGEMENT, ueId, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted != want {
+			t.Errorf("expected deleted = %v, got %v", want, deleted)
+		}
+	}
+}
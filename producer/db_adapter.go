@@ -5,27 +5,33 @@
 package producer
 
 import (
+	"context"
 	"time"
 
 	"github.com/omec-project/udr/logger"
 	"github.com/omec-project/util/mongoapi"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
+// DBInterface is the store behind the producer. Every method takes a context
+// bounding its database round-trips, normally that of the request served, so
+// that a slow database gives up once the request's deadline has passed.
 type DBInterface interface {
-	RestfulAPIGetOne(collName string, filter bson.M) (map[string]interface{}, error)
-	RestfulAPIGetMany(collName string, filter bson.M) ([]map[string]interface{}, error)
-	RestfulAPIPutOneTimeout(collName string, filter bson.M, putData map[string]interface{}, timeout int32, timeField string) bool
-	RestfulAPIPutOne(collName string, filter bson.M, putData map[string]interface{}) (bool, error)
-	RestfulAPIPutOneNotUpdate(collName string, filter bson.M, putData map[string]interface{}) (bool, error)
-	RestfulAPIPutMany(collName string, filterArray []bson.M, putDataArray []map[string]interface{}) error
-	RestfulAPIDeleteOne(collName string, filter bson.M) error
-	RestfulAPIDeleteMany(collName string, filter bson.M) error
-	RestfulAPIMergePatch(collName string, filter bson.M, patchData map[string]interface{}) error
-	RestfulAPIJSONPatch(collName string, filter bson.M, patchJSON []byte) error
-	RestfulAPIJSONPatchExtend(collName string, filter bson.M, patchJSON []byte, dataName string) error
-	RestfulAPIPost(collName string, filter bson.M, postData map[string]interface{}) (bool, error)
-	RestfulAPIPostMany(collName string, filter bson.M, postDataArray []interface{}) error
+	RestfulAPIGetOne(ctx context.Context, collName string, filter bson.M) (map[string]interface{}, error)
+	RestfulAPIGetMany(ctx context.Context, collName string, filter bson.M) ([]map[string]interface{}, error)
+	RestfulAPIPutOneTimeout(ctx context.Context, collName string, filter bson.M, putData map[string]interface{}, timeout int32, timeField string) bool
+	RestfulAPIPutOne(ctx context.Context, collName string, filter bson.M, putData map[string]interface{}) (bool, error)
+	RestfulAPIPutOneNotUpdate(ctx context.Context, collName string, filter bson.M, putData map[string]interface{}) (bool, error)
+	RestfulAPIPutMany(ctx context.Context, collName string, filterArray []bson.M, putDataArray []map[string]interface{}) error
+	RestfulAPIDeleteOne(ctx context.Context, collName string, filter bson.M) error
+	RestfulAPIDeleteMany(ctx context.Context, collName string, filter bson.M) error
+	RestfulAPIMergePatch(ctx context.Context, collName string, filter bson.M, patchData map[string]interface{}) error
+	RestfulAPIJSONPatch(ctx context.Context, collName string, filter bson.M, patchJSON []byte) error
+	RestfulAPIJSONPatchExtend(ctx context.Context, collName string, filter bson.M, patchJSON []byte, dataName string) error
+	RestfulAPIPost(ctx context.Context, collName string, filter bson.M, postData map[string]interface{}) (bool, error)
+	RestfulAPIPostMany(ctx context.Context, collName string, filter bson.M, postDataArray []interface{}) error
+	RestfulAPIBulkWrite(ctx context.Context, collName string, operations []BulkWriteOperation, ordered bool) ([]BulkWriteItemResult, error)
 }
 
 var (
@@ -37,16 +43,65 @@ var (
 func setCommonDBClient(url string, dbname string) error {
 	mClient, errConnect := mongoapi.NewMongoClient(url, dbname)
 	if mClient != nil && mClient.Client != nil {
-		CommonDBClient = newCachedDBClient(mClient)
+		createGroupMembershipIndexes(mClient)
+		createSubscriptionExpiryIndexes(mClient)
+		createSharedDataReferenceIndexes(mClient)
+		cached := newCachedDBClient(&mongoDBClient{mClient})
+		cached.bus = newMongoInvalidationBus(mClient)
+		CommonDBClient = cached
+		commonMongoClient = mClient
 	}
 	return errConnect
 }
 
+// createGroupMembershipIndexes indexes the group membership collection on
+// both group identifiers, since group-identifiers translation looks groups
+// up in either direction.
+func createGroupMembershipIndexes(mClient *mongoapi.MongoClient) {
+	for _, keyField := range []string{"internalGroupId", "externalGroupId"} {
+		if _, err := mClient.CreateIndex(SUBSCDATA_GROUPDATA_MEMBERSHIP, keyField); err != nil {
+			logger.DataRepoLog.Warnf("create index on %s.%s failed: %+v", SUBSCDATA_GROUPDATA_MEMBERSHIP, keyField, err)
+		}
+	}
+}
+
+// createSubscriptionExpiryIndexes indexes the subscription collections on
+// their expiry, which the subscription reaper looks them up by.
+func createSubscriptionExpiryIndexes(mClient *mongoapi.MongoClient) {
+	for _, collName := range subscriptionCollections {
+		createIndex(mClient, collName, subscriptionExpiryField)
+	}
+}
+
+// createSharedDataReferenceIndexes indexes the provisioned data on the
+// attributes referring to shared data, which a shared data change looks the
+// referencing UEs up by.
+func createSharedDataReferenceIndexes(mClient *mongoapi.MongoClient) {
+	for _, reference := range sharedDataReferences {
+		for _, attribute := range reference.attributes {
+			createIndex(mClient, reference.collName, attribute)
+		}
+	}
+}
+
+// createIndex creates a non-unique index on keyField of collName, unlike
+// MongoClient.CreateIndex.
+func createIndex(mClient *mongoapi.MongoClient, collName string, keyField string) {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	index := mongo.IndexModel{Keys: bson.D{{Key: keyField, Value: 1}}}
+	if _, err := mClient.GetCollection(collName).Indexes().CreateOne(ctx, index); err != nil {
+		logger.DataRepoLog.Warnf("create index on %s.%s failed: %+v", collName, keyField, err)
+	}
+}
+
 // Set AuthDBClient
 func setAuthDBClient(authurl string, authkeysdbname string) error {
 	mClient, errConnect := mongoapi.NewMongoClient(authurl, authkeysdbname)
 	if mClient != nil && mClient.Client != nil {
-		AuthDBClient = newCachedDBClient(mClient)
+		cached := newCachedDBClient(&mongoDBClient{mClient})
+		cached.bus = newMongoInvalidationBus(mClient)
+		AuthDBClient = cached
 	}
 	return errConnect
 }
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// BulkWriteOperationType is the kind of write of one BulkWriteOperation.
+type BulkWriteOperationType string
+
+const (
+	// BulkWritePut sets Data on the document matching Filter, inserting it
+	// when there is none, as RestfulAPIPutOne does.
+	BulkWritePut BulkWriteOperationType = "put"
+	// BulkWriteDelete deletes the document matching Filter, as
+	// RestfulAPIDeleteOne does.
+	BulkWriteDelete BulkWriteOperationType = "delete"
+)
+
+// BulkWriteOperation is one write of a RestfulAPIBulkWrite call.
+type BulkWriteOperation struct {
+	Type   BulkWriteOperationType
+	Filter bson.M
+	// Data is the document of a BulkWritePut.
+	Data map[string]interface{}
+}
+
+// BulkWriteItemStatus is the outcome of one BulkWriteOperation.
+type BulkWriteItemStatus string
+
+const (
+	BulkWriteApplied BulkWriteItemStatus = "applied"
+	BulkWriteFailed  BulkWriteItemStatus = "failed"
+	// BulkWriteSkipped operations were not attempted, because an earlier
+	// operation of an ordered write failed.
+	BulkWriteSkipped BulkWriteItemStatus = "skipped"
+)
+
+// BulkWriteItemResult reports the outcome of the operation at the same index
+// in the request.
+type BulkWriteItemResult struct {
+	Status BulkWriteItemStatus
+	// Created is set when a BulkWritePut inserted a new document.
+	Created bool
+	Err     error
+}
+
+// RestfulAPIBulkWrite applies operations to collName in one round-trip per
+// server batch. An ordered write stops at the first failed operation and
+// skips the rest; an unordered one attempts them all. Invalid operations are
+// rejected before anything is written. Otherwise the error is only set when
+// the outcome is unknown, as on a lost connection, or not durable, as on a
+// write concern error.
+func (c *mongoDBClient) RestfulAPIBulkWrite(ctx context.Context, collName string, operations []BulkWriteOperation, ordered bool) ([]BulkWriteItemResult, error) {
+	if len(operations) == 0 {
+		return []BulkWriteItemResult{}, nil
+	}
+	models := make([]mongo.WriteModel, len(operations))
+	for i, operation := range operations {
+		if len(operation.Filter) == 0 {
+			return nil, fmt.Errorf("bulk write operation %d has no filter", i)
+		}
+		switch operation.Type {
+		case BulkWritePut:
+			if len(operation.Data) == 0 {
+				return nil, fmt.Errorf("bulk write operation %d has no data", i)
+			}
+			models[i] = mongo.NewUpdateOneModel().
+				SetFilter(operation.Filter).
+				SetUpdate(bson.M{"$set": operation.Data}).
+				SetUpsert(true)
+		case BulkWriteDelete:
+			models[i] = mongo.NewDeleteOneModel().SetFilter(operation.Filter)
+		default:
+			return nil, fmt.Errorf("bulk write operation %d has unknown type %q", i, operation.Type)
+		}
+	}
+
+	result, err := c.GetCollection(collName).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(ordered))
+	var bulkErr mongo.BulkWriteException
+	if err != nil && !errors.As(err, &bulkErr) {
+		return nil, err
+	}
+	return bulkWriteResults(operations, ordered, result, bulkErr), bulkWriteConcernError(bulkErr)
+}
+
+// bulkWriteResults reports every operation as applied unless the server
+// reported it failed or, for an ordered write, it came after a failure.
+func bulkWriteResults(operations []BulkWriteOperation, ordered bool, result *mongo.BulkWriteResult,
+	bulkErr mongo.BulkWriteException,
+) []BulkWriteItemResult {
+	results := make([]BulkWriteItemResult, len(operations))
+	firstFailed := len(operations)
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Index < 0 || writeErr.Index >= len(operations) {
+			continue
+		}
+		results[writeErr.Index] = BulkWriteItemResult{Status: BulkWriteFailed, Err: writeErr}
+		firstFailed = min(firstFailed, writeErr.Index)
+	}
+	for i := range results {
+		switch {
+		case results[i].Status == BulkWriteFailed:
+		case ordered && i > firstFailed:
+			results[i].Status = BulkWriteSkipped
+		default:
+			results[i].Status = BulkWriteApplied
+			if result != nil {
+				_, results[i].Created = result.UpsertedIDs[int64(i)]
+			}
+		}
+	}
+	return results
+}
+
+func bulkWriteConcernError(bulkErr mongo.BulkWriteException) error {
+	if bulkErr.WriteConcernError == nil {
+		return nil
+	}
+	return bulkErr.WriteConcernError
+}
+
+// RestfulAPIBulkWrite invalidates the scope of every operation, failed and
+// skipped ones included, since the outcome of a failed write may be unknown.
+func (c *cachedDBClient) RestfulAPIBulkWrite(ctx context.Context, collName string, operations []BulkWriteOperation, ordered bool) ([]BulkWriteItemResult, error) {
+	results, err := c.DBInterface.RestfulAPIBulkWrite(ctx, collName, operations, ordered)
+	filters := make([]bson.M, len(operations))
+	for i, operation := range operations {
+		filters[i] = operation.Filter
+	}
+	c.writtenMany(collName, filters)
+	return results, err
+}
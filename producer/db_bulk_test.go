@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestBulkWriteResults(t *testing.T) {
+	operations := []BulkWriteOperation{
+		{Type: BulkWritePut, Filter: bson.M{"ueId": "imsi-001010000000501"}, Data: map[string]any{"foo": "bar"}},
+		{Type: BulkWritePut, Filter: bson.M{"ueId": "imsi-001010000000502"}, Data: map[string]any{"foo": "bar"}},
+		{Type: BulkWriteDelete, Filter: bson.M{"ueId": "imsi-001010000000503"}},
+	}
+	result := &mongo.BulkWriteResult{UpsertedIDs: map[int64]any{0: bson.NewObjectID()}}
+	bulkErr := mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+		{WriteError: mongo.WriteError{Index: 1, Code: 11000, Message: "duplicate key"}},
+	}}
+
+	for _, tc := range []struct {
+		ordered bool
+		want    []BulkWriteItemStatus
+	}{
+		{ordered: true, want: []BulkWriteItemStatus{BulkWriteApplied, BulkWriteFailed, BulkWriteSkipped}},
+		{ordered: false, want: []BulkWriteItemStatus{BulkWriteApplied, BulkWriteFailed, BulkWriteApplied}},
+	} {
+		results := bulkWriteResults(operations, tc.ordered, result, bulkErr)
+		var got []BulkWriteItemStatus
+		for _, item := range results {
+			got = append(got, item.Status)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("ordered=%v: got statuses %v, want %v", tc.ordered, got, tc.want)
+		}
+		if !results[0].Created || results[2].Created {
+			t.Errorf("ordered=%v: expected only the first operation to be reported as created", tc.ordered)
+		}
+		if results[1].Err == nil {
+			t.Errorf("ordered=%v: expected the failed operation to carry its error", tc.ordered)
+		}
+	}
+}
+
+// recordingBus records the scopes published through it, one batch per
+// Publish.
+type recordingBus struct {
+	published [][]string
+}
+
+func (b *recordingBus) Publish(scopes []string) error {
+	b.published = append(b.published, scopes)
+	return nil
+}
+
+func (b *recordingBus) Subscribe(context.Context, func(scope string), func()) {}
+
+func TestBulkWriteInvalidatesEveryTouchedScope(t *testing.T) {
+	c := newCachedDBClient(&stubDB{result: map[string]any{"foo": "bar"}})
+	bus := &recordingBus{}
+	c.bus = bus
+
+	const ueId1 = "imsi-001010000000511"
+	const ueId2 = "imsi-001010000000512"
+	const untouched = "imsi-001010000000513"
+	for _, ueId := range []string{ueId1, ueId2, untouched} {
+		if _, err := c.RestfulAPIGetOne(context.Background(), testColl, bson.M{"ueId": ueId, "servingPlmnId": "00101"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	operations := []BulkWriteOperation{
+		{Type: BulkWritePut, Filter: bson.M{"ueId": ueId1, "servingPlmnId": "00101"}, Data: map[string]any{"foo": "baz"}},
+		{Type: BulkWritePut, Filter: bson.M{"ueId": ueId1, "servingPlmnId": "00102"}, Data: map[string]any{"foo": "baz"}},
+		{Type: BulkWriteDelete, Filter: bson.M{"ueId": ueId2, "servingPlmnId": "00101"}},
+	}
+	results, err := c.RestfulAPIBulkWrite(context.Background(), testColl, operations, true)
+	if err != nil || len(results) != len(operations) {
+		t.Fatalf("RestfulAPIBulkWrite() = %v, %v", results, err)
+	}
+
+	c.mu.Lock()
+	_, kept := c.entries[cacheKey(testColl, bson.M{"ueId": untouched, "servingPlmnId": "00101"})]
+	size := len(c.entries)
+	c.mu.Unlock()
+	if size != 1 || !kept {
+		t.Errorf("expected only the untouched UE to stay cached, got %d entries", size)
+	}
+	if want := []string{testColl + "\x00" + ueId1, testColl + "\x00" + ueId2}; len(bus.published) != 1 ||
+		!slices.Equal(bus.published[0], want) {
+		t.Errorf("expected each touched scope to be published once in one batch, got %q", bus.published)
+	}
+}
+
+func TestBulkWriteOfManyUesPublishesTheCollection(t *testing.T) {
+	c := newCachedDBClient(&stubDB{})
+	bus := &recordingBus{}
+	c.bus = bus
+
+	operations := make([]BulkWriteOperation, cacheInvalidationMaxScopes+1)
+	for i := range operations {
+		operations[i] = BulkWriteOperation{
+			Type:   BulkWritePut,
+			Filter: bson.M{"ueId": fmt.Sprintf("imsi-0010100%08d", i)},
+			Data:   map[string]any{"foo": "bar"},
+		}
+	}
+	if _, err := c.RestfulAPIBulkWrite(context.Background(), testColl, operations, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(bus.published) != 1 || !slices.Equal(bus.published[0], []string{testColl + "\x00"}) {
+		t.Errorf("expected a single collection-wide scope to be published, got %d batches", len(bus.published))
+	}
+}
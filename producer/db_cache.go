@@ -4,131 +4,621 @@
 package producer
 
 import (
+	"container/list"
+	"context"
+	"encoding/json"
 	"maps"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	udr_context "github.com/omec-project/udr/context"
+	"github.com/omec-project/udr/logger"
+	stats "github.com/omec-project/udr/metrics"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"golang.org/x/sync/singleflight"
 )
 
-// cacheTTL is the maximum age of a cached subscriber-data entry.
+// cacheTTL is the default maximum age of a cached subscriber-data entry.
 // Provisioned data changes only when simapp pushes updates; 30 s is
 // conservative enough to be safe while still eliminating repeated MongoDB
 // round-trips within a single UE registration flow.
 const cacheTTL = 30 * time.Second
 
-// cacheableCollections is the set of MongoDB collections whose
-// RestfulAPIGetOne results are safe to cache. These are provisioned /
+const (
+	// cacheMaxEntries bounds each cache by default. Entries are a few KB, so
+	// this keeps a cache in the hundreds of MB however many SIMs are
+	// provisioned.
+	cacheMaxEntries = 100000
+	// cacheSweepInterval is how often expired entries are dropped by default.
+	cacheSweepInterval = time.Minute
+	// cacheNegativeTTL is how long a lookup that found nothing is remembered
+	// by default. It is short, so a newly provisioned SIM that is not
+	// reported by a change stream is still found within seconds.
+	cacheNegativeTTL = 5 * time.Second
+	// cacheLoadTimeout bounds a read shared by concurrent lookups, which runs
+	// detached from the deadline of the request that started it.
+	cacheLoadTimeout = 30 * time.Second
+)
+
+// cacheableCollections is the default set of MongoDB collections whose
+// RestfulAPIGetOne results are cached. These are provisioned /
 // authentication subscription data that is written by simapp before UEs
 // connect and then read many times per registration.
 var cacheableCollections = map[string]bool{
 	"subscriptionData.provisionedData.amData":                        true,
+	"subscriptionData.provisionedData.smData":                        true,
 	"subscriptionData.provisionedData.smfSelectionSubscriptionData":  true,
 	"subscriptionData.provisionedData.smsData":                       true,
 	"subscriptionData.provisionedData.smsMngData":                    true,
 	"subscriptionData.provisionedData.traceData":                     true,
+	"subscriptionData.provisionedData.lcsPrivacyData":                true,
+	"subscriptionData.provisionedData.lcsMoData":                     true,
+	"subscriptionData.provisionedData.lcsBcaData":                    true,
+	"subscriptionData.provisionedData.lcsSubscriptionData":           true,
+	"subscriptionData.provisionedData.v2xData":                       true,
+	"subscriptionData.provisionedData.a2xData":                       true,
+	"subscriptionData.provisionedData.proseData":                     true,
+	"subscriptionData.provisionedData.rangingSlPosData":              true,
 	"subscriptionData.authenticationData.authenticationSubscription": true,
+	"subscriptionData.sharedData":                                    true,
+}
+
+// cachePolicy is what a cachedDBClient caches and for how long.
+type cachePolicy struct {
+	maxEntries    int
+	sweepInterval time.Duration
+	negativeTTL   time.Duration
+	// ttls holds the TTL of every cached collection.
+	ttls map[string]time.Duration
+}
+
+// newCachePolicy applies the cache configuration of udrSelf over the
+// defaults.
+func newCachePolicy(udrSelf *udr_context.UDRContext) cachePolicy {
+	policy := cachePolicy{
+		maxEntries:    cacheMaxEntries,
+		sweepInterval: cacheSweepInterval,
+		negativeTTL:   cacheNegativeTTL,
+		ttls:          make(map[string]time.Duration),
+	}
+	if udrSelf.CacheMaxEntries > 0 {
+		policy.maxEntries = udrSelf.CacheMaxEntries
+	}
+	if udrSelf.CacheSweepInterval > 0 {
+		policy.sweepInterval = udrSelf.CacheSweepInterval
+	}
+	if udrSelf.CacheNegativeTTL != 0 {
+		policy.negativeTTL = udrSelf.CacheNegativeTTL
+	}
+	defaultTTL := cacheTTL
+	if udrSelf.CacheTTL > 0 {
+		defaultTTL = udrSelf.CacheTTL
+	}
+	if udrSelf.CacheCollections == nil {
+		for collName := range cacheableCollections {
+			policy.ttls[collName] = defaultTTL
+		}
+		return policy
+	}
+	for collName, ttl := range udrSelf.CacheCollections {
+		if ttl <= 0 {
+			ttl = defaultTTL
+		}
+		policy.ttls[collName] = ttl
+	}
+	return policy
 }
 
 type cacheEntry struct {
-	data   map[string]any
+	collName      string
+	scope         string
+	ueId          string
+	servingPlmnId string
+	// value is the document or the list of documents read, nil or empty when
+	// nothing matched.
+	value  any
 	expiry time.Time
+	// element is the entry's place in the LRU list, whose values are keys.
+	element *list.Element
 }
 
-// cachedDBClient wraps a DBInterface, caching RestfulAPIGetOne for the
-// provisioned collections listed in cacheableCollections. Write operations
-// invalidate the relevant cache entry so callers always see consistent data
-// when they write-then-read within the same process.
+// cachedDBClient wraps a DBInterface, caching RestfulAPIGetOne and
+// RestfulAPIGetMany for the collections of its policy. Write operations
+// invalidate the entries of the UE or shared data set they touch so callers
+// always see consistent data when they write-then-read within the same
+// process, and publish that scope on bus, when set, so that the other
+// replicas drop it too. Once the cache is full, the least recently used
+// entry is evicted. Lookups that find nothing are cached for negativeTTL, and
+// concurrent misses on a key share a single DB read.
 type cachedDBClient struct {
 	DBInterface
-	mu      sync.RWMutex
-	entries map[string]cacheEntry
+	bus     cacheInvalidationBus
+	policy  cachePolicy
+	loads   singleflight.Group
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	scopes  map[string]map[string]struct{} // keys cached per scope
+	lru     *list.List                     // most recently used first
+	// inflight tracks the scopes being read. A read that raced with an
+	// invalidation of its scope does not store what it read, as that may
+	// predate the write.
+	inflight map[string]*inflightScope
+}
+
+// inflightScope counts the invalidations of a scope while reads of it are
+// in flight.
+type inflightScope struct {
+	waiting    int // lookups waiting on a read of the scope
+	generation uint64
 }
 
 func newCachedDBClient(inner DBInterface) *cachedDBClient {
 	return &cachedDBClient{
 		DBInterface: inner,
-		entries:     make(map[string]cacheEntry),
+		policy:      newCachePolicy(udr_context.UDR_Self()),
+		entries:     make(map[string]*cacheEntry),
+		scopes:      make(map[string]map[string]struct{}),
+		lru:         list.New(),
+		inflight:    make(map[string]*inflightScope),
 	}
 }
 
-// cacheKey builds a stable key from the filter's ueId and servingPlmnId fields,
-// which are the only fields used by cacheable collections.
-func cacheKey(collName string, filter bson.M) string {
-	ueId, _ := filter["ueId"].(string)
-	plmnId, _ := filter["servingPlmnId"].(string)
-	return collName + "\x00" + ueId + "\x00" + plmnId
+// cacheScopeFields identify the UE or shared data set a filter selects.
+var cacheScopeFields = []string{"ueId", "sharedDataId"}
+
+// cacheScope returns what a write matching filter invalidates: the entries
+// of the UE or shared data set named by field, or every entry of collName
+// when the filter names neither and field is "".
+func cacheScope(collName string, filter bson.M) (scope string, field string) {
+	for _, field := range cacheScopeFields {
+		if id, ok := filter[field].(string); ok && id != "" {
+			return collName + "\x00" + id, field
+		}
+	}
+	return collName + "\x00", ""
 }
 
-func (c *cachedDBClient) RestfulAPIGetOne(collName string, filter bson.M) (map[string]any, error) {
-	if !cacheableCollections[collName] {
-		return c.DBInterface.RestfulAPIGetOne(collName, filter)
+// cacheKey builds a stable key from the filter: its scope followed by the
+// remaining conditions, such as servingPlmnId or the S-NSSAI and DNN of an
+// smData query, in canonical form. Filters without a scope span many UEs and
+// are not cached, so their key is "".
+func cacheKey(collName string, filter bson.M) string {
+	scope, field := cacheScope(collName, filter)
+	if field == "" {
+		return ""
+	}
+	conditions := maps.Clone(filter)
+	delete(conditions, field)
+	if len(conditions) == 0 {
+		return scope
+	}
+	// encoding/json sorts map keys, so equal filters encode alike.
+	encoded, err := json.Marshal(conditions)
+	if err != nil {
+		return ""
 	}
+	return scope + "\x00" + string(encoded)
+}
+
+// getManyKeySuffix keeps RestfulAPIGetMany results apart from the
+// RestfulAPIGetOne result for the same filter.
+const getManyKeySuffix = "\x00many"
+
+func (c *cachedDBClient) caches(collName string) bool {
+	_, ok := c.policy.ttls[collName]
+	return ok
+}
 
+func (c *cachedDBClient) RestfulAPIGetOne(ctx context.Context, collName string, filter bson.M) (map[string]any, error) {
+	value, err := c.lookup(ctx, collName, filter, "", func(ctx context.Context) (any, error) {
+		document, err := c.DBInterface.RestfulAPIGetOne(ctx, collName, filter)
+		if document == nil {
+			return nil, err
+		}
+		return document, err
+	})
+	document, _ := value.(map[string]any)
+	return document, err
+}
+
+func (c *cachedDBClient) RestfulAPIGetMany(ctx context.Context, collName string, filter bson.M) ([]map[string]any, error) {
+	value, err := c.lookup(ctx, collName, filter, getManyKeySuffix, func(ctx context.Context) (any, error) {
+		return c.DBInterface.RestfulAPIGetMany(ctx, collName, filter)
+	})
+	documents, _ := value.([]map[string]any)
+	return documents, err
+}
+
+// lookup serves what read returns for filter from the cache. On a miss, read
+// runs once for all concurrent callers and its result is cached. Each caller
+// waits for it no longer than its own ctx allows.
+func (c *cachedDBClient) lookup(ctx context.Context, collName string, filter bson.M, keySuffix string,
+	read func(ctx context.Context) (any, error),
+) (any, error) {
+	ttl, cacheable := c.policy.ttls[collName]
 	key := cacheKey(collName, filter)
-	now := time.Now()
+	if !cacheable || key == "" {
+		return read(ctx)
+	}
+	key += keySuffix
+	scope, _ := cacheScope(collName, filter)
+
+	c.mu.Lock()
+	if entry, hit := c.entries[key]; hit && time.Now().Before(entry.expiry) {
+		c.lru.MoveToFront(entry.element)
+		value := cloneCacheValue(entry.value)
+		c.mu.Unlock()
+		stats.IncrementUdrCacheHits(collName)
+		return value, nil
+	}
+	inflight := c.inflight[scope]
+	if inflight == nil {
+		inflight = &inflightScope{}
+		c.inflight[scope] = inflight
+	}
+	inflight.waiting++
+	generation := inflight.generation
+	c.mu.Unlock()
+	stats.IncrementUdrCacheMisses(collName)
 
-	c.mu.RLock()
-	entry, hit := c.entries[key]
-	c.mu.RUnlock()
+	// Callers only share a read started in the same generation of the scope,
+	// so none is handed a document read before an invalidation it has
+	// already seen.
+	flightKey := key + "\x00" + strconv.FormatUint(generation, 10)
+	loads := c.loads.DoChan(flightKey, func() (any, error) {
+		// The read is shared, so the caller that happened to start it must
+		// not cut it short for the others by going away.
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheLoadTimeout)
+		defer cancel()
+		return c.load(loadCtx, collName, filter, scope, key, ttl, generation, read)
+	})
+	select {
+	case <-ctx.Done():
+		// The read goes on for the other callers, so its scope stays tracked
+		// until it is over.
+		go func() {
+			<-loads
+			c.doneWaiting(scope)
+		}()
+		return nil, ctx.Err()
+	case result := <-loads:
+		c.doneWaiting(scope)
+		return cloneCacheValue(result.Val), result.Err
+	}
+}
 
-	if hit && now.Before(entry.expiry) {
-		return maps.Clone(entry.data), nil
+// doneWaiting stops tracking scope once no lookup waits on a read of it.
+func (c *cachedDBClient) doneWaiting(scope string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if inflight := c.inflight[scope]; inflight != nil {
+		if inflight.waiting--; inflight.waiting == 0 {
+			delete(c.inflight, scope)
+		}
 	}
+}
+
+// staleInflight makes the reads in flight of the scopes matching match drop
+// what they read. c.mu must be held.
+func (c *cachedDBClient) staleInflight(match func(scope string) bool) {
+	for scope, inflight := range c.inflight {
+		if match(scope) {
+			inflight.generation++
+		}
+	}
+}
 
-	data, err := c.DBInterface.RestfulAPIGetOne(collName, filter)
-	if err != nil || data == nil {
-		return data, err
+// load calls read and caches its result, or the absence of one, unless scope
+// was invalidated since generation.
+func (c *cachedDBClient) load(ctx context.Context, collName string, filter bson.M, scope string, key string,
+	ttl time.Duration, generation uint64, read func(ctx context.Context) (any, error),
+) (any, error) {
+	value, err := read(ctx)
+	if err != nil {
+		return value, err
+	}
+	if cacheValueMissing(value) {
+		if c.policy.negativeTTL <= 0 {
+			return value, nil
+		}
+		ttl = c.policy.negativeTTL
 	}
 
+	ueId, _ := filter["ueId"].(string)
+	servingPlmnId, _ := filter["servingPlmnId"].(string)
 	c.mu.Lock()
-	c.entries[key] = cacheEntry{data: maps.Clone(data), expiry: now.Add(cacheTTL)}
+	if inflight := c.inflight[scope]; inflight != nil && inflight.generation == generation {
+		c.store(key, &cacheEntry{
+			collName:      collName,
+			scope:         scope,
+			ueId:          ueId,
+			servingPlmnId: servingPlmnId,
+			value:         cloneCacheValue(value),
+			expiry:        time.Now().Add(ttl),
+		})
+	}
 	c.mu.Unlock()
 
-	return data, nil
+	return value, nil
+}
+
+// cloneCacheValue copies a cached value so that callers cannot modify the
+// cached copy, and the other way round.
+func cloneCacheValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return maps.Clone(v)
+	case []map[string]any:
+		if v == nil {
+			return v
+		}
+		documents := make([]map[string]any, len(v))
+		for i, document := range v {
+			documents[i] = maps.Clone(document)
+		}
+		return documents
+	}
+	return value
+}
+
+func cacheValueMissing(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		return v == nil
+	case []map[string]any:
+		return len(v) == 0
+	}
+	return value == nil
 }
 
+// store caches entry under key and evicts the least recently used entries
+// beyond maxEntries. c.mu must be held.
+func (c *cachedDBClient) store(key string, entry *cacheEntry) {
+	if cached, ok := c.entries[key]; ok {
+		cached.value, cached.expiry = entry.value, entry.expiry
+		c.lru.MoveToFront(cached.element)
+		return
+	}
+	entry.element = c.lru.PushFront(key)
+	c.entries[key] = entry
+	if c.scopes[entry.scope] == nil {
+		c.scopes[entry.scope] = make(map[string]struct{})
+	}
+	c.scopes[entry.scope][key] = struct{}{}
+	stats.AddUdrCacheEntries(entry.collName, 1)
+	for c.lru.Len() > c.policy.maxEntries {
+		evicted := c.remove(c.lru.Back().Value.(string))
+		stats.IncrementUdrCacheEvictions(evicted.collName, "lru")
+	}
+}
+
+// remove drops the entry cached under key and returns it, or nil if there
+// was none. c.mu must be held.
+func (c *cachedDBClient) remove(key string) *cacheEntry {
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.lru.Remove(entry.element)
+	delete(c.entries, key)
+	delete(c.scopes[entry.scope], key)
+	if len(c.scopes[entry.scope]) == 0 {
+		delete(c.scopes, entry.scope)
+	}
+	stats.AddUdrCacheEntries(entry.collName, -1)
+	return entry
+}
+
+// removeMatching drops the entries for which match returns true, counting
+// them as invalidated, and returns how many it dropped. c.mu must be held.
+func (c *cachedDBClient) removeMatching(match func(entry *cacheEntry) bool) int {
+	removed := 0
+	for key, entry := range c.entries {
+		if match(entry) {
+			c.remove(key)
+			stats.IncrementUdrCacheInvalidations(entry.collName)
+			removed++
+		}
+	}
+	return removed
+}
+
+// sweep drops the entries expired at now, which are otherwise only replaced
+// when read again or evicted.
+func (c *cachedDBClient) sweep(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiry) {
+			c.remove(key)
+			stats.IncrementUdrCacheEvictions(entry.collName, "expired")
+		}
+	}
+}
+
+// invalidate drops the entries a change to the documents matching filter
+// may have made stale.
 func (c *cachedDBClient) invalidate(collName string, filter bson.M) {
-	if !cacheableCollections[collName] {
+	if !c.caches(collName) {
+		return
+	}
+	scope, _ := cacheScope(collName, filter)
+	c.dropScope(scope)
+}
+
+// dropScope drops the entries of scope, as returned by cacheScope, or every
+// entry for cacheFlushScope.
+func (c *cachedDBClient) dropScope(scope string) {
+	if scope == cacheFlushScope {
+		c.flush()
 		return
 	}
-	key := cacheKey(collName, filter)
 	c.mu.Lock()
-	delete(c.entries, key)
+	defer c.mu.Unlock()
+	if collName, id, _ := strings.Cut(scope, "\x00"); id == "" {
+		c.removeMatching(func(entry *cacheEntry) bool { return entry.collName == collName })
+		c.staleInflight(func(inflight string) bool { return strings.HasPrefix(inflight, scope) })
+		return
+	}
+	for key := range c.scopes[scope] {
+		if entry := c.remove(key); entry != nil {
+			stats.IncrementUdrCacheInvalidations(entry.collName)
+		}
+	}
+	if inflight := c.inflight[scope]; inflight != nil {
+		inflight.generation++
+	}
+}
+
+func (c *cachedDBClient) flush() {
+	c.mu.Lock()
+	c.removeMatching(func(*cacheEntry) bool { return true })
+	c.staleInflight(func(string) bool { return true })
 	c.mu.Unlock()
 }
 
-func (c *cachedDBClient) RestfulAPIPutOne(collName string, filter bson.M, putData map[string]any) (bool, error) {
-	c.invalidate(collName, filter)
-	return c.DBInterface.RestfulAPIPutOne(collName, filter, putData)
+// flushEverywhere drops every entry here and on the other replicas, for when
+// writes may have been missed.
+func (c *cachedDBClient) flushEverywhere() {
+	c.flush()
+	if c.bus == nil {
+		return
+	}
+	if err := c.bus.Publish([]string{cacheFlushScope}); err != nil {
+		logger.DataRepoLog.Warnf("publish cache flush failed: %+v", err)
+	}
+}
+
+// written invalidates the entries a write may have changed, here and on the
+// other replicas. It runs after the write, so that no replica can re-cache
+// the previous value once they are dropped.
+func (c *cachedDBClient) written(collName string, filter bson.M) {
+	c.writtenMany(collName, []bson.M{filter})
+}
+
+// writtenMany is written for the filters of a batch, invalidating each scope
+// they touched once and publishing them together. A batch touching more than
+// cacheInvalidationMaxScopes scopes has the other replicas drop the whole
+// collection rather than publish every one.
+func (c *cachedDBClient) writtenMany(collName string, filters []bson.M) {
+	if !c.caches(collName) {
+		return
+	}
+	var scopes []string
+	seen := make(map[string]struct{})
+	for _, filter := range filters {
+		scope, _ := cacheScope(collName, filter)
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		c.dropScope(scope)
+		scopes = append(scopes, scope)
+	}
+	if c.bus == nil || len(scopes) == 0 {
+		return
+	}
+	if len(scopes) > cacheInvalidationMaxScopes {
+		scopes = []string{collName + "\x00"}
+	}
+	if err := c.bus.Publish(scopes); err != nil {
+		logger.DataRepoLog.Warnf("publish cache invalidation for %s failed: %+v", collName, err)
+	}
+}
+
+// cachedDBClients returns the caches in front of the common and auth
+// databases.
+func cachedDBClients() []*cachedDBClient {
+	var clients []*cachedDBClient
+	for _, client := range []DBInterface{CommonDBClient, AuthDBClient} {
+		if cached, ok := client.(*cachedDBClient); ok {
+			clients = append(clients, cached)
+		}
+	}
+	return clients
+}
+
+// StartCacheSweeper drops expired cache entries every sweep interval until
+// ctx is cancelled.
+func StartCacheSweeper(ctx context.Context) {
+	ticker := time.NewTicker(newCachePolicy(udr_context.UDR_Self()).sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, cached := range cachedDBClients() {
+				cached.sweep(now)
+			}
+		}
+	}
+}
+
+func (c *cachedDBClient) RestfulAPIPutOne(ctx context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
+	ok, err := c.DBInterface.RestfulAPIPutOne(ctx, collName, filter, putData)
+	c.written(collName, filter)
+	return ok, err
+}
+
+func (c *cachedDBClient) RestfulAPIPutOneTimeout(ctx context.Context, collName string, filter bson.M, putData map[string]any, timeout int32, timeField string) bool {
+	ok := c.DBInterface.RestfulAPIPutOneTimeout(ctx, collName, filter, putData, timeout, timeField)
+	c.written(collName, filter)
+	return ok
+}
+
+func (c *cachedDBClient) RestfulAPIPutOneNotUpdate(ctx context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
+	ok, err := c.DBInterface.RestfulAPIPutOneNotUpdate(ctx, collName, filter, putData)
+	c.written(collName, filter)
+	return ok, err
+}
+
+func (c *cachedDBClient) RestfulAPIDeleteOne(ctx context.Context, collName string, filter bson.M) error {
+	err := c.DBInterface.RestfulAPIDeleteOne(ctx, collName, filter)
+	c.written(collName, filter)
+	return err
+}
+
+func (c *cachedDBClient) RestfulAPIJSONPatch(ctx context.Context, collName string, filter bson.M, patchJSON []byte) error {
+	err := c.DBInterface.RestfulAPIJSONPatch(ctx, collName, filter, patchJSON)
+	c.written(collName, filter)
+	return err
 }
 
-func (c *cachedDBClient) RestfulAPIPutOneTimeout(collName string, filter bson.M, putData map[string]any, timeout int32, timeField string) bool {
-	c.invalidate(collName, filter)
-	return c.DBInterface.RestfulAPIPutOneTimeout(collName, filter, putData, timeout, timeField)
+func (c *cachedDBClient) RestfulAPIMergePatch(ctx context.Context, collName string, filter bson.M, patchData map[string]any) error {
+	err := c.DBInterface.RestfulAPIMergePatch(ctx, collName, filter, patchData)
+	c.written(collName, filter)
+	return err
 }
 
-func (c *cachedDBClient) RestfulAPIPutOneNotUpdate(collName string, filter bson.M, putData map[string]any) (bool, error) {
-	c.invalidate(collName, filter)
-	return c.DBInterface.RestfulAPIPutOneNotUpdate(collName, filter, putData)
+func (c *cachedDBClient) RestfulAPIPost(ctx context.Context, collName string, filter bson.M, postData map[string]any) (bool, error) {
+	ok, err := c.DBInterface.RestfulAPIPost(ctx, collName, filter, postData)
+	c.written(collName, filter)
+	return ok, err
 }
 
-func (c *cachedDBClient) RestfulAPIDeleteOne(collName string, filter bson.M) error {
-	c.invalidate(collName, filter)
-	return c.DBInterface.RestfulAPIDeleteOne(collName, filter)
+func (c *cachedDBClient) RestfulAPIPutMany(ctx context.Context, collName string, filterArray []bson.M, putDataArray []map[string]any) error {
+	err := c.DBInterface.RestfulAPIPutMany(ctx, collName, filterArray, putDataArray)
+	c.writtenMany(collName, filterArray)
+	return err
 }
 
-func (c *cachedDBClient) RestfulAPIJSONPatch(collName string, filter bson.M, patchJSON []byte) error {
-	c.invalidate(collName, filter)
-	return c.DBInterface.RestfulAPIJSONPatch(collName, filter, patchJSON)
+func (c *cachedDBClient) RestfulAPIDeleteMany(ctx context.Context, collName string, filter bson.M) error {
+	err := c.DBInterface.RestfulAPIDeleteMany(ctx, collName, filter)
+	c.written(collName, filter)
+	return err
 }
 
-func (c *cachedDBClient) RestfulAPIMergePatch(collName string, filter bson.M, patchData map[string]any) error {
-	c.invalidate(collName, filter)
-	return c.DBInterface.RestfulAPIMergePatch(collName, filter, patchData)
+func (c *cachedDBClient) RestfulAPIJSONPatchExtend(ctx context.Context, collName string, filter bson.M, patchJSON []byte, dataName string) error {
+	err := c.DBInterface.RestfulAPIJSONPatchExtend(ctx, collName, filter, patchJSON, dataName)
+	c.written(collName, filter)
+	return err
 }
 
-func (c *cachedDBClient) RestfulAPIPost(collName string, filter bson.M, postData map[string]any) (bool, error) {
-	c.invalidate(collName, filter)
-	return c.DBInterface.RestfulAPIPost(collName, filter, postData)
+func (c *cachedDBClient) RestfulAPIPostMany(ctx context.Context, collName string, filter bson.M, postDataArray []any) error {
+	err := c.DBInterface.RestfulAPIPostMany(ctx, collName, filter, postDataArray)
+	c.written(collName, filter)
+	return err
 }
@@ -4,11 +4,15 @@
 package producer
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"maps"
 	"sync"
 	"testing"
 	"time"
 
+	udr_context "github.com/omec-project/udr/context"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -27,7 +31,7 @@ func (s *stubDB) getCallCount() int {
 	return s.callsGet
 }
 
-func (s *stubDB) RestfulAPIGetOne(_ string, _ bson.M) (map[string]any, error) {
+func (s *stubDB) RestfulAPIGetOne(_ context.Context, _ string, _ bson.M) (map[string]any, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.callsGet++
@@ -40,34 +44,50 @@ func (s *stubDB) RestfulAPIGetOne(_ string, _ bson.M) (map[string]any, error) {
 	return out, s.err
 }
 
-func (s *stubDB) RestfulAPIGetMany(_ string, _ bson.M) ([]map[string]any, error) {
+func (s *stubDB) RestfulAPIGetMany(_ context.Context, _ string, _ bson.M) ([]map[string]any, error) {
 	return nil, nil
 }
 
-func (s *stubDB) RestfulAPIPutOneTimeout(_ string, _ bson.M, _ map[string]any, _ int32, _ string) bool {
+func (s *stubDB) RestfulAPIPutOneTimeout(_ context.Context, _ string, _ bson.M, _ map[string]any, _ int32, _ string) bool {
 	return true
 }
 
-func (s *stubDB) RestfulAPIPutOne(_ string, _ bson.M, _ map[string]any) (bool, error) {
+func (s *stubDB) RestfulAPIPutOne(_ context.Context, _ string, _ bson.M, _ map[string]any) (bool, error) {
 	return true, nil
 }
 
-func (s *stubDB) RestfulAPIPutOneNotUpdate(_ string, _ bson.M, _ map[string]any) (bool, error) {
+func (s *stubDB) RestfulAPIPutOneNotUpdate(_ context.Context, _ string, _ bson.M, _ map[string]any) (bool, error) {
 	return true, nil
 }
-func (s *stubDB) RestfulAPIPutMany(_ string, _ []bson.M, _ []map[string]any) error { return nil }
-func (s *stubDB) RestfulAPIDeleteOne(_ string, _ bson.M) error                     { return nil }
-func (s *stubDB) RestfulAPIDeleteMany(_ string, _ bson.M) error                    { return nil }
-func (s *stubDB) RestfulAPIMergePatch(_ string, _ bson.M, _ map[string]any) error  { return nil }
-func (s *stubDB) RestfulAPIJSONPatch(_ string, _ bson.M, _ []byte) error           { return nil }
-func (s *stubDB) RestfulAPIJSONPatchExtend(_ string, _ bson.M, _ []byte, _ string) error {
+func (s *stubDB) RestfulAPIPutMany(_ context.Context, _ string, _ []bson.M, _ []map[string]any) error {
+	return nil
+}
+func (s *stubDB) RestfulAPIDeleteOne(_ context.Context, _ string, _ bson.M) error  { return nil }
+func (s *stubDB) RestfulAPIDeleteMany(_ context.Context, _ string, _ bson.M) error { return nil }
+func (s *stubDB) RestfulAPIMergePatch(_ context.Context, _ string, _ bson.M, _ map[string]any) error {
+	return nil
+}
+func (s *stubDB) RestfulAPIJSONPatch(_ context.Context, _ string, _ bson.M, _ []byte) error {
+	return nil
+}
+func (s *stubDB) RestfulAPIJSONPatchExtend(_ context.Context, _ string, _ bson.M, _ []byte, _ string) error {
 	return nil
 }
 
-func (s *stubDB) RestfulAPIPost(_ string, _ bson.M, _ map[string]any) (bool, error) {
+func (s *stubDB) RestfulAPIPost(_ context.Context, _ string, _ bson.M, _ map[string]any) (bool, error) {
 	return true, nil
 }
-func (s *stubDB) RestfulAPIPostMany(_ string, _ bson.M, _ []interface{}) error { return nil }
+func (s *stubDB) RestfulAPIPostMany(_ context.Context, _ string, _ bson.M, _ []interface{}) error {
+	return nil
+}
+
+func (s *stubDB) RestfulAPIBulkWrite(_ context.Context, _ string, operations []BulkWriteOperation, _ bool) ([]BulkWriteItemResult, error) {
+	results := make([]BulkWriteItemResult, len(operations))
+	for i := range results {
+		results[i].Status = BulkWriteApplied
+	}
+	return results, nil
+}
 
 const testColl = "subscriptionData.provisionedData.amData"
 
@@ -77,11 +97,11 @@ func TestCacheHitAvoidsDatabaseCall(t *testing.T) {
 	db := &stubDB{result: map[string]any{"foo": "bar"}}
 	c := newCachedDBClient(db)
 
-	first, err := c.RestfulAPIGetOne(testColl, testFilter)
+	first, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter)
 	if err != nil || first == nil {
 		t.Fatalf("unexpected error on first call: %v", err)
 	}
-	_, err = c.RestfulAPIGetOne(testColl, testFilter)
+	_, err = c.RestfulAPIGetOne(context.Background(), testColl, testFilter)
 	if err != nil {
 		t.Fatalf("unexpected error on second call: %v", err)
 	}
@@ -102,7 +122,7 @@ func TestCacheExpiry(t *testing.T) {
 	c := newCachedDBClient(db)
 
 	// Populate the cache.
-	if _, err := c.RestfulAPIGetOne(testColl, testFilter); err != nil {
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
 		t.Fatal(err)
 	}
 
@@ -115,7 +135,7 @@ func TestCacheExpiry(t *testing.T) {
 	c.mu.Unlock()
 
 	// Should hit DB again.
-	if _, err := c.RestfulAPIGetOne(testColl, testFilter); err != nil {
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
 		t.Fatal(err)
 	}
 
@@ -128,15 +148,15 @@ func TestCacheInvalidatedOnPutOne(t *testing.T) {
 	db := &stubDB{result: map[string]any{"foo": "bar"}}
 	c := newCachedDBClient(db)
 
-	if _, err := c.RestfulAPIGetOne(testColl, testFilter); err != nil {
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
 		t.Fatal(err)
 	}
 
-	if _, err := c.RestfulAPIPutOne(testColl, testFilter, map[string]any{"foo": "new"}); err != nil {
+	if _, err := c.RestfulAPIPutOne(context.Background(), testColl, testFilter, map[string]any{"foo": "new"}); err != nil {
 		t.Fatal(err)
 	}
 
-	if _, err := c.RestfulAPIGetOne(testColl, testFilter); err != nil {
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
 		t.Fatal(err)
 	}
 
@@ -149,15 +169,15 @@ func TestCacheInvalidatedOnJSONPatch(t *testing.T) {
 	db := &stubDB{result: map[string]any{"foo": "bar"}}
 	c := newCachedDBClient(db)
 
-	if _, err := c.RestfulAPIGetOne(testColl, testFilter); err != nil {
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
 		t.Fatal(err)
 	}
 
-	if err := c.RestfulAPIJSONPatch(testColl, testFilter, []byte(`[]`)); err != nil {
+	if err := c.RestfulAPIJSONPatch(context.Background(), testColl, testFilter, []byte(`[]`)); err != nil {
 		t.Fatal(err)
 	}
 
-	if _, err := c.RestfulAPIGetOne(testColl, testFilter); err != nil {
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
 		t.Fatal(err)
 	}
 
@@ -170,14 +190,14 @@ func TestCacheReturnedMapIsolatedFromCachedCopy(t *testing.T) {
 	db := &stubDB{result: map[string]any{"key": "original"}}
 	c := newCachedDBClient(db)
 
-	first, err := c.RestfulAPIGetOne(testColl, testFilter)
+	first, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter)
 	if err != nil {
 		t.Fatal(err)
 	}
 	// Mutate the returned map.
 	first["key"] = "mutated"
 
-	second, err := c.RestfulAPIGetOne(testColl, testFilter)
+	second, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -191,7 +211,7 @@ func TestNonCacheableCollectionBypassesCache(t *testing.T) {
 	c := newCachedDBClient(db)
 
 	for range 3 {
-		if _, err := c.RestfulAPIGetOne("someOtherCollection", testFilter); err != nil {
+		if _, err := c.RestfulAPIGetOne(context.Background(), "someOtherCollection", testFilter); err != nil {
 			t.Fatal(err)
 		}
 	}
@@ -206,14 +226,14 @@ func TestCacheConcurrentReads(t *testing.T) {
 	c := newCachedDBClient(db)
 
 	// Prime the cache.
-	if _, err := c.RestfulAPIGetOne(testColl, testFilter); err != nil {
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
 		t.Fatal(err)
 	}
 
 	var wg sync.WaitGroup
 	for range 50 {
 		wg.Go(func() {
-			res, err := c.RestfulAPIGetOne(testColl, testFilter)
+			res, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter)
 			if err != nil || res == nil {
 				t.Errorf("concurrent read failed: err=%v res=%v", err, res)
 			}
@@ -221,3 +241,361 @@ func TestCacheConcurrentReads(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	db := &stubDB{result: map[string]any{"foo": "bar"}}
+	c := newCachedDBClient(db)
+	c.policy.maxEntries = 2
+
+	filters := []bson.M{
+		{"ueId": "imsi-001010000000001"},
+		{"ueId": "imsi-001010000000002"},
+		{"ueId": "imsi-001010000000003"},
+	}
+	for _, filter := range filters[:2] {
+		if _, err := c.RestfulAPIGetOne(context.Background(), testColl, filter); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Touch the first entry so that the second is the least recently used.
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, filters[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, filters[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	c.mu.Lock()
+	_, first := c.entries[cacheKey(testColl, filters[0])]
+	_, second := c.entries[cacheKey(testColl, filters[1])]
+	size := len(c.entries)
+	c.mu.Unlock()
+	if size != 2 || !first || second {
+		t.Errorf("expected the least recently used entry to be evicted, got %d entries (first %v, second %v)",
+			size, first, second)
+	}
+}
+
+func TestCachePolicyFromContext(t *testing.T) {
+	udrSelf := udr_context.UDR_Self()
+	t.Cleanup(func() {
+		udrSelf.CacheMaxEntries = 0
+		udrSelf.CacheTTL = 0
+		udrSelf.CacheSweepInterval = 0
+		udrSelf.CacheCollections = nil
+	})
+
+	policy := newCachePolicy(udrSelf)
+	if policy.maxEntries != cacheMaxEntries || policy.sweepInterval != cacheSweepInterval ||
+		len(policy.ttls) != len(cacheableCollections) || policy.ttls[testColl] != cacheTTL {
+		t.Errorf("unexpected default policy %+v", policy)
+	}
+
+	const authColl = "subscriptionData.authenticationData.authenticationSubscription"
+	udrSelf.CacheMaxEntries = 10
+	udrSelf.CacheTTL = time.Minute
+	udrSelf.CacheSweepInterval = 5 * time.Second
+	udrSelf.CacheCollections = map[string]time.Duration{testColl: 0, authColl: 5 * time.Second}
+	policy = newCachePolicy(udrSelf)
+	want := map[string]time.Duration{testColl: time.Minute, authColl: 5 * time.Second}
+	if policy.maxEntries != 10 || policy.sweepInterval != 5*time.Second || !maps.Equal(policy.ttls, want) {
+		t.Errorf("unexpected configured policy %+v", policy)
+	}
+
+	// Caching can be turned off altogether.
+	udrSelf.CacheCollections = map[string]time.Duration{}
+	db := &stubDB{result: map[string]any{"foo": "bar"}}
+	c := newCachedDBClient(db)
+	for range 2 {
+		if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := db.getCallCount(); got != 2 {
+		t.Errorf("expected every read to hit the DB with caching disabled, got %d calls", got)
+	}
+}
+
+func TestCacheSweepDropsExpiredEntries(t *testing.T) {
+	db := &stubDB{result: map[string]any{"foo": "bar"}}
+	c := newCachedDBClient(db)
+	const authColl = "subscriptionData.authenticationData.authenticationSubscription"
+	c.policy.ttls[authColl] = time.Hour
+
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RestfulAPIGetOne(context.Background(), authColl, testFilter); err != nil {
+		t.Fatal(err)
+	}
+
+	c.sweep(time.Now().Add(cacheTTL))
+
+	c.mu.Lock()
+	_, short := c.entries[cacheKey(testColl, testFilter)]
+	_, long := c.entries[cacheKey(authColl, testFilter)]
+	size := c.lru.Len()
+	c.mu.Unlock()
+	if short || !long || size != 1 {
+		t.Errorf("expected only the expired entry to be swept, got short %v, long %v, %d in LRU", short, long, size)
+	}
+}
+
+// blockingDB holds every RestfulAPIGetOne until release is closed.
+type blockingDB struct {
+	stubDB
+	release chan struct{}
+}
+
+func (b *blockingDB) RestfulAPIGetOne(ctx context.Context, collName string, filter bson.M) (map[string]any, error) {
+	<-b.release
+	return b.stubDB.RestfulAPIGetOne(ctx, collName, filter)
+}
+
+func TestCacheConcurrentMissesShareOneRead(t *testing.T) {
+	db := &blockingDB{stubDB: stubDB{result: map[string]any{"foo": "bar"}}, release: make(chan struct{})}
+	c := newCachedDBClient(db)
+
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Go(func() {
+			res, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter)
+			if err != nil || res["foo"] != "bar" {
+				t.Errorf("concurrent read failed: err=%v res=%v", err, res)
+			}
+		})
+	}
+	// Give every reader time to miss and join the read in flight.
+	time.Sleep(100 * time.Millisecond)
+	close(db.release)
+	wg.Wait()
+
+	if got := db.getCallCount(); got != 1 {
+		t.Errorf("expected concurrent misses to share 1 DB call, got %d", got)
+	}
+}
+
+func TestCacheInvalidationOfAnotherUeKeepsReadsShared(t *testing.T) {
+	db := &blockingDB{stubDB: stubDB{result: map[string]any{"foo": "bar"}}, release: make(chan struct{})}
+	c := newCachedDBClient(db)
+	otherScope, _ := cacheScope(testColl, bson.M{"ueId": "imsi-001010000000099"})
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
+				t.Error(err)
+			}
+		})
+		// Writes to another UE, here or on another replica, interleave with
+		// the misses.
+		if i%5 == 0 {
+			time.Sleep(5 * time.Millisecond)
+			c.dropScope(otherScope)
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(db.release)
+	wg.Wait()
+
+	if got := db.getCallCount(); got != 1 {
+		t.Errorf("expected the misses to share 1 DB call, got %d", got)
+	}
+	if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
+		t.Fatal(err)
+	}
+	if got := db.getCallCount(); got != 1 {
+		t.Errorf("expected the shared read to be cached, got %d DB calls", got)
+	}
+}
+
+func TestCacheInvalidationDropsReadOfSameUe(t *testing.T) {
+	db := &blockingDB{stubDB: stubDB{result: map[string]any{"foo": "bar"}}, release: make(chan struct{})}
+	c := newCachedDBClient(db)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
+			t.Error(err)
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	scope, _ := cacheScope(testColl, testFilter)
+	c.dropScope(scope)
+	close(db.release)
+	<-done
+
+	c.mu.Lock()
+	size, tracked := len(c.entries), len(c.inflight)
+	c.mu.Unlock()
+	if size != 0 || tracked != 0 {
+		t.Errorf("expected the raced read not to be cached, got %d entries and %d scopes in flight", size, tracked)
+	}
+}
+
+func TestCacheReaderGivesUpAtItsDeadline(t *testing.T) {
+	db := &blockingDB{stubDB: stubDB{result: map[string]any{"foo": "bar"}}, release: make(chan struct{})}
+	c := newCachedDBClient(db)
+
+	// A reader whose deadline passes stops waiting for the shared read,
+	// which still completes for the readers that can wait.
+	results := make(chan error, 1)
+	go func() {
+		res, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter)
+		if err == nil && res["foo"] != "bar" {
+			err = fmt.Errorf("unexpected document %v", res)
+		}
+		results <- err
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.RestfulAPIGetOne(ctx, testColl, testFilter); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+
+	close(db.release)
+	if err := <-results; err != nil {
+		t.Error(err)
+	}
+	if got := db.getCallCount(); got != 1 {
+		t.Errorf("expected both readers to share 1 DB call, got %d", got)
+	}
+}
+
+func TestCacheRemembersMissingDocuments(t *testing.T) {
+	db := &stubDB{}
+	c := newCachedDBClient(db)
+
+	for range 3 {
+		if res, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil || res != nil {
+			t.Fatalf("expected no document, got %v, %v", res, err)
+		}
+	}
+	if got := db.getCallCount(); got != 1 {
+		t.Errorf("expected the missing document to be looked up once, got %d calls", got)
+	}
+
+	// Provisioning the document through the UDR forgets its absence.
+	if _, err := c.RestfulAPIPutOne(context.Background(), testColl, testFilter, map[string]any{"foo": "bar"}); err != nil {
+		t.Fatal(err)
+	}
+	db.mu.Lock()
+	db.result = map[string]any{"foo": "bar"}
+	db.mu.Unlock()
+	if res, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil || res["foo"] != "bar" {
+		t.Errorf("expected the provisioned document, got %v, %v", res, err)
+	}
+
+	// With negative caching disabled every miss goes to the DB.
+	db = &stubDB{}
+	c = newCachedDBClient(db)
+	c.policy.negativeTTL = -1
+	for range 2 {
+		if _, err := c.RestfulAPIGetOne(context.Background(), testColl, testFilter); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := db.getCallCount(); got != 2 {
+		t.Errorf("expected 2 DB calls without negative caching, got %d", got)
+	}
+}
+
+// countingManyDB answers RestfulAPIGetMany with one document describing the
+// filter, counting the calls.
+type countingManyDB struct {
+	stubDB
+	callsGetMany int
+}
+
+func (s *countingManyDB) RestfulAPIGetMany(_ context.Context, _ string, filter bson.M) ([]map[string]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callsGetMany++
+	return []map[string]any{{"ueId": filter["ueId"], "sst": filter["singlenssai.sst"]}}, nil
+}
+
+func (s *countingManyDB) getManyCallCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.callsGetMany
+}
+
+func TestCacheGetManyKeysCoverTheWholeFilter(t *testing.T) {
+	const smColl = "subscriptionData.provisionedData.smData"
+	db := &countingManyDB{}
+	c := newCachedDBClient(db)
+
+	ueFilter := func(sst int32, dnn string) bson.M {
+		filter := bson.M{"ueId": "imsi-001010000000001", "servingPlmnId": "00101", "singlenssai.sst": sst}
+		if dnn != "" {
+			filter["dnnconfigurations."+dnn] = bson.M{"$exists": true}
+		}
+		return filter
+	}
+	queries := []bson.M{ueFilter(1, ""), ueFilter(2, ""), ueFilter(1, "internet")}
+	for range 2 {
+		for _, filter := range queries {
+			documents, err := c.RestfulAPIGetMany(context.Background(), smColl, filter)
+			if err != nil || len(documents) != 1 || documents[0]["sst"] != filter["singlenssai.sst"] {
+				t.Fatalf("RestfulAPIGetMany(%v) = %v, %v", filter, documents, err)
+			}
+			// Callers may modify what they are given.
+			documents[0]["sst"] = 0
+		}
+	}
+	if got := db.getManyCallCount(); got != len(queries) {
+		t.Errorf("expected each distinct query to reach the DB once, got %d calls", got)
+	}
+
+	// A write for the UE drops every query cached for it.
+	if _, err := c.RestfulAPIPutOne(context.Background(), smColl, bson.M{"ueId": "imsi-001010000000001", "servingPlmnId": "00101"},
+		map[string]any{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, filter := range queries {
+		if _, err := c.RestfulAPIGetMany(context.Background(), smColl, filter); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := db.getManyCallCount(); got != 2*len(queries) {
+		t.Errorf("expected the write to invalidate every query of the UE, got %d calls", got)
+	}
+
+	// Queries across UEs are not cached.
+	crossUe := bson.M{"$or": bson.A{bson.M{"sharedDnnConfigurationsId": "iot-profile"}}}
+	for range 2 {
+		if _, err := c.RestfulAPIGetMany(context.Background(), smColl, crossUe); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := db.getManyCallCount(); got != 2*len(queries)+2 {
+		t.Errorf("expected queries without a UE to bypass the cache, got %d calls", got)
+	}
+}
+
+func TestCacheKeysSharedDataById(t *testing.T) {
+	const sharedColl = "subscriptionData.sharedData"
+	db := &stubDB{result: map[string]any{"sharedDataId": "iot-profile"}}
+	c := newCachedDBClient(db)
+
+	for _, sharedDataId := range []string{"iot-profile", "iot-profile", "voice-profile"} {
+		if _, err := c.RestfulAPIGetOne(context.Background(), sharedColl, bson.M{"sharedDataId": sharedDataId}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := db.getCallCount(); got != 2 {
+		t.Errorf("expected one DB call per shared data set, got %d", got)
+	}
+
+	if err := c.RestfulAPIDeleteOne(context.Background(), sharedColl, bson.M{"sharedDataId": "iot-profile"}); err != nil {
+		t.Fatal(err)
+	}
+	c.mu.Lock()
+	_, iot := c.entries[cacheKey(sharedColl, bson.M{"sharedDataId": "iot-profile"})]
+	_, voice := c.entries[cacheKey(sharedColl, bson.M{"sharedDataId": "voice-profile"})]
+	c.mu.Unlock()
+	if iot || !voice {
+		t.Errorf("expected only the deleted shared data to be dropped, got iot %v, voice %v", iot, voice)
+	}
+}
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/omec-project/util/mongoapi"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// mongoDBClient implements DBInterface on a mongoapi client. mongoapi only
+// takes a context for some of its writes, so the other operations are
+// reimplemented here with the same semantics.
+type mongoDBClient struct {
+	*mongoapi.MongoClient
+}
+
+// findOne returns the document matching filter without its "_id", or nil
+// when there is none.
+func (c *mongoDBClient) findOne(ctx context.Context, collName string, filter bson.M) (map[string]any, error) {
+	var result map[string]any
+	if err := c.GetCollection(collName).FindOne(ctx, filter).Decode(&result); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// Delete "_id" entry which is auto-inserted by MongoDB
+	delete(result, "_id")
+	return result, nil
+}
+
+func (c *mongoDBClient) RestfulAPIGetOne(ctx context.Context, collName string, filter bson.M) (map[string]any, error) {
+	result, err := c.findOne(ctx, collName, filter)
+	if err != nil {
+		return nil, fmt.Errorf("RestfulAPIGetOne err: %w", err)
+	}
+	return result, nil
+}
+
+func (c *mongoDBClient) RestfulAPIGetMany(ctx context.Context, collName string, filter bson.M) ([]map[string]any, error) {
+	cur, err := c.GetCollection(collName).Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("RestfulAPIGetMany err: %w", err)
+	}
+	defer func() {
+		_ = cur.Close(context.WithoutCancel(ctx))
+	}()
+
+	var resultArray []map[string]any
+	for cur.Next(ctx) {
+		var result map[string]any
+		if err := cur.Decode(&result); err != nil {
+			return nil, fmt.Errorf("RestfulAPIGetMany err: %w", err)
+		}
+		delete(result, "_id")
+		resultArray = append(resultArray, result)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, fmt.Errorf("RestfulAPIGetMany err: %w", err)
+	}
+	return resultArray, nil
+}
+
+// RestfulAPIPutOneTimeout ignores timeout and timeField, as mongoapi does.
+func (c *mongoDBClient) RestfulAPIPutOneTimeout(ctx context.Context, collName string, filter bson.M, putData map[string]any,
+	_ int32, _ string,
+) bool {
+	existing, err := c.findOne(ctx, collName, filter)
+	if err != nil {
+		return false
+	}
+	collection := c.GetCollection(collName)
+	if existing == nil {
+		_, err = collection.InsertOne(ctx, putData)
+	} else {
+		_, err = collection.UpdateOne(ctx, filter, bson.M{"$set": putData})
+	}
+	return err == nil
+}
+
+func (c *mongoDBClient) RestfulAPIPutOne(ctx context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
+	return c.RestfulAPIPutOneWithContext(ctx, collName, filter, putData)
+}
+
+func (c *mongoDBClient) RestfulAPIPutOneNotUpdate(ctx context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
+	existing, err := c.findOne(ctx, collName, filter)
+	if err != nil {
+		return false, fmt.Errorf("RestfulAPIPutOneNotUpdate err: %w", err)
+	}
+	if existing != nil {
+		return true, nil
+	}
+	if _, err := c.GetCollection(collName).InsertOne(ctx, putData); err != nil {
+		return false, fmt.Errorf("RestfulAPIPutOneNotUpdate InsertOne err: %w", err)
+	}
+	return false, nil
+}
+
+func (c *mongoDBClient) RestfulAPIPutMany(ctx context.Context, collName string, filterArray []bson.M, putDataArray []map[string]any) error {
+	collection := c.GetCollection(collName)
+	for i, putData := range putDataArray {
+		filter := filterArray[i]
+		existing, err := c.findOne(ctx, collName, filter)
+		if err != nil {
+			return fmt.Errorf("RestfulAPIPutMany err: %w", err)
+		}
+		if existing != nil {
+			if _, err := collection.UpdateOne(ctx, filter, bson.M{"$set": putData}); err != nil {
+				return fmt.Errorf("RestfulAPIPutMany UpdateOne err: %w", err)
+			}
+		} else if _, err := collection.InsertOne(ctx, putData); err != nil {
+			return fmt.Errorf("RestfulAPIPutMany InsertOne err: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *mongoDBClient) RestfulAPIDeleteOne(ctx context.Context, collName string, filter bson.M) error {
+	return c.RestfulAPIDeleteOneWithContext(ctx, collName, filter)
+}
+
+func (c *mongoDBClient) RestfulAPIDeleteMany(ctx context.Context, collName string, filter bson.M) error {
+	if _, err := c.GetCollection(collName).DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("RestfulAPIDeleteMany err: %w", err)
+	}
+	return nil
+}
+
+func (c *mongoDBClient) RestfulAPIMergePatch(ctx context.Context, collName string, filter bson.M, patchData map[string]any) error {
+	originalData, err := c.findOne(ctx, collName, filter)
+	if err != nil {
+		return fmt.Errorf("RestfulAPIMergePatch getOrigData err: %w", err)
+	}
+	original, err := json.Marshal(originalData)
+	if err != nil {
+		return fmt.Errorf("RestfulAPIMergePatch Marshal err: %w", err)
+	}
+	patchDataByte, err := json.Marshal(patchData)
+	if err != nil {
+		return fmt.Errorf("RestfulAPIMergePatch Marshal err: %w", err)
+	}
+	modified, err := jsonpatch.MergePatch(original, patchDataByte)
+	if err != nil {
+		return fmt.Errorf("RestfulAPIMergePatch MergePatch err: %w", err)
+	}
+	return c.setModified(ctx, collName, filter, "", modified)
+}
+
+func (c *mongoDBClient) RestfulAPIJSONPatch(ctx context.Context, collName string, filter bson.M, patchJSON []byte) error {
+	originalData, err := c.findOne(ctx, collName, filter)
+	if err != nil {
+		return fmt.Errorf("RestfulAPIJSONPatch getOrigData err: %w", err)
+	}
+	return c.applyJSONPatch(ctx, collName, filter, "", originalData, patchJSON)
+}
+
+func (c *mongoDBClient) RestfulAPIJSONPatchExtend(ctx context.Context, collName string, filter bson.M, patchJSON []byte,
+	dataName string,
+) error {
+	originalDataCover, err := c.findOne(ctx, collName, filter)
+	if err != nil {
+		return fmt.Errorf("RestfulAPIJSONPatchExtend getOrigData err: %w", err)
+	}
+	return c.applyJSONPatch(ctx, collName, filter, dataName, originalDataCover[dataName], patchJSON)
+}
+
+// applyJSONPatch applies patchJSON to originalData and stores the result in
+// the document matching filter, under dataName when set.
+func (c *mongoDBClient) applyJSONPatch(ctx context.Context, collName string, filter bson.M, dataName string,
+	originalData any, patchJSON []byte,
+) error {
+	original, err := json.Marshal(originalData)
+	if err != nil {
+		return fmt.Errorf("RestfulAPIJSONPatch Marshal err: %w", err)
+	}
+	patch, err := jsonpatch.DecodePatch(patchJSON)
+	if err != nil {
+		return fmt.Errorf("RestfulAPIJSONPatch DecodePatch err: %w", err)
+	}
+	modified, err := patch.Apply(original)
+	if err != nil {
+		return fmt.Errorf("RestfulAPIJSONPatch Apply err: %w", err)
+	}
+	return c.setModified(ctx, collName, filter, dataName, modified)
+}
+
+// setModified sets the JSON document modified on the document matching
+// filter, under dataName when set.
+func (c *mongoDBClient) setModified(ctx context.Context, collName string, filter bson.M, dataName string,
+	modified []byte,
+) error {
+	var modifiedData map[string]any
+	if err := json.Unmarshal(modified, &modifiedData); err != nil {
+		return fmt.Errorf("unmarshal patched document err: %w", err)
+	}
+	update := bson.M{"$set": modifiedData}
+	if dataName != "" {
+		update = bson.M{"$set": bson.M{dataName: modifiedData}}
+	}
+	if _, err := c.GetCollection(collName).UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("update patched document err: %w", err)
+	}
+	return nil
+}
+
+func (c *mongoDBClient) RestfulAPIPost(ctx context.Context, collName string, filter bson.M, postData map[string]any) (bool, error) {
+	return c.RestfulAPIPostWithContext(ctx, collName, filter, postData)
+}
+
+func (c *mongoDBClient) RestfulAPIPostMany(ctx context.Context, collName string, filter bson.M, postDataArray []any) error {
+	return c.RestfulAPIPostManyWithContext(ctx, collName, filter, postDataArray)
+}
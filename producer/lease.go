@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/util/mongoapi"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	// leaseColl holds one document per lease, naming the replica holding it
+	// and when it lapses unless renewed.
+	leaseColl          = "leases"
+	leaseTTL           = 15 * time.Second
+	leaseRenewInterval = 5 * time.Second
+)
+
+// leaderElection is what runAsLeader needs of a lease.
+type leaderElection interface {
+	acquire(ctx context.Context) (bool, error)
+	release(ctx context.Context) error
+}
+
+// lease elects the one replica that runs a background task shared by all of
+// them, such as following a change stream. Expiry is computed from the
+// server clock, so the replicas' clocks need not agree.
+type lease struct {
+	collection *mongo.Collection
+	name       string
+	// holder identifies this process as the holder of the lease.
+	holder string
+}
+
+func newLease(client *mongoapi.MongoClient, name string) *lease {
+	return &lease{collection: client.GetCollection(leaseColl), name: name, holder: uuid.New().String()}
+}
+
+// acquire takes the lease if it is free or has lapsed, or renews it if this
+// process holds it. It reports false, without error, when another replica
+// holds it.
+func (l *lease) acquire(ctx context.Context) (bool, error) {
+	filter := bson.M{"_id": l.name, "$or": bson.A{
+		bson.M{"holder": l.holder},
+		bson.M{"$expr": bson.M{"$lte": bson.A{"$expiresAt", "$$NOW"}}},
+	}}
+	update := mongo.Pipeline{bson.D{{Key: "$set", Value: bson.M{
+		"holder":    l.holder,
+		"expiresAt": bson.M{"$add": bson.A{"$$NOW", leaseTTL.Milliseconds()}},
+	}}}}
+	_, err := l.collection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// The upsert found the lease held by another replica.
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// release lets the lease lapse now, so that another replica need not wait
+// for it. Its checkpoint is kept for the next holder.
+func (l *lease) release(ctx context.Context) error {
+	update := mongo.Pipeline{bson.D{{Key: "$set", Value: bson.M{"expiresAt": "$$NOW"}}}}
+	_, err := l.collection.UpdateOne(ctx, bson.M{"_id": l.name, "holder": l.holder}, update)
+	return err
+}
+
+// checkpoint stores token with the lease, for whichever replica holds it next
+// to resume from. A nil token clears it. Nothing is stored once the lease is
+// lost.
+func (l *lease) checkpoint(ctx context.Context, token bson.Raw) error {
+	update := bson.M{"$unset": bson.M{"checkpoint": ""}}
+	if token != nil {
+		update = bson.M{"$set": bson.M{"checkpoint": token}}
+	}
+	_, err := l.collection.UpdateOne(ctx, bson.M{"_id": l.name, "holder": l.holder}, update)
+	return err
+}
+
+// lastCheckpoint returns the token last stored with checkpoint, or nil.
+func (l *lease) lastCheckpoint(ctx context.Context) (bson.Raw, error) {
+	var document struct {
+		Checkpoint bson.Raw `bson:"checkpoint"`
+	}
+	err := l.collection.FindOne(ctx, bson.M{"_id": l.name}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	return document.Checkpoint, err
+}
+
+// runAsLeader runs task while this process holds the lease named name, until
+// ctx is cancelled.
+// The lease is renewed every leaseRenewInterval, and task's context is
+// cancelled as soon as a renewal fails, before the lease can lapse and be
+// taken over. task is started again whenever the lease is regained.
+func runAsLeader(ctx context.Context, name string, l leaderElection, task func(ctx context.Context)) {
+	var (
+		stopTask context.CancelFunc
+		taskDone chan struct{}
+	)
+	stop := func() {
+		stopTask()
+		<-taskDone
+		stopTask = nil
+	}
+	defer func() {
+		if stopTask == nil {
+			return
+		}
+		stop()
+		releaseCtx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		if err := l.release(releaseCtx); err != nil {
+			logger.DataRepoLog.Warnf("release lease %s failed: %+v", name, err)
+		}
+	}()
+
+	ticker := time.NewTicker(leaseRenewInterval)
+	defer ticker.Stop()
+	for {
+		acquireCtx, cancel := context.WithTimeout(ctx, leaseRenewInterval)
+		held, err := l.acquire(acquireCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.DataRepoLog.Warnf("renew lease %s failed: %+v", name, err)
+		}
+		switch {
+		case held && stopTask == nil:
+			logger.DataRepoLog.Infof("acquired lease %s", name)
+			var taskCtx context.Context
+			taskCtx, stopTask = context.WithCancel(ctx)
+			taskDone = make(chan struct{})
+			go func() {
+				defer close(taskDone)
+				task(taskCtx)
+			}()
+		case !held && stopTask != nil:
+			logger.DataRepoLog.Warnf("lost lease %s", name)
+			stop()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
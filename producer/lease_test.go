@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fixedElection always or never grants the lease, and records its release.
+type fixedElection struct {
+	held     bool
+	released atomic.Bool
+}
+
+func (e *fixedElection) acquire(context.Context) (bool, error) { return e.held, nil }
+
+func (e *fixedElection) release(context.Context) error {
+	e.released.Store(true)
+	return nil
+}
+
+func TestRunAsLeaderRunsTaskWhileHeld(t *testing.T) {
+	election := &fixedElection{held: true}
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var stopped atomic.Bool
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runAsLeader(ctx, "test", election, func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			stopped.Store(true)
+		})
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("expected the task to start once the lease was acquired")
+	}
+	cancel()
+	<-done
+	if !stopped.Load() {
+		t.Error("expected runAsLeader to wait for the task to stop")
+	}
+	if !election.released.Load() {
+		t.Error("expected the lease to be released")
+	}
+}
+
+func TestRunAsLeaderSkipsTaskWithoutLease(t *testing.T) {
+	election := &fixedElection{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var ran atomic.Bool
+	runAsLeader(ctx, "test", election, func(context.Context) { ran.Store(true) })
+	if ran.Load() {
+		t.Error("expected the task not to run on a replica without the lease")
+	}
+	if election.released.Load() {
+		t.Error("expected a lease that was never held not to be released")
+	}
+}
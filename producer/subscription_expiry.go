@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/omec-project/openapi/v2/models"
+	udr_context "github.com/omec-project/udr/context"
+	"github.com/omec-project/udr/logger"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// expiringSubscription is implemented by every subscription model that
+// carries an expiry: SdmSubscription, EeSubscription,
+// SubscriptionDataSubscriptions and PolicyDataSubscription.
+type expiringSubscription interface {
+	HasExpiry() bool
+	GetExpiry() time.Time
+	SetExpiry(v time.Time)
+}
+
+// clampSubscriptionExpiry shortens the expiry requested for a new or replaced
+// subscription to the configured maximum, and sets it when none was
+// requested, so that subscriptions left behind by a crashed NF eventually
+// expire. It does nothing when no maximum is configured.
+func clampSubscriptionExpiry(subscription expiringSubscription, now time.Time) {
+	maxExpiry := udr_context.UDR_Self().MaxSubscriptionExpiry
+	if maxExpiry <= 0 {
+		return
+	}
+	latest := now.Add(maxExpiry).UTC()
+	if !subscription.HasExpiry() || subscription.GetExpiry().After(latest) {
+		subscription.SetExpiry(latest)
+	}
+}
+
+func subscriptionExpired(subscription expiringSubscription, now time.Time) bool {
+	return subscription.HasExpiry() && !subscription.GetExpiry().After(now)
+}
+
+// subscriptionReaperLease elects the replica removing expired subscriptions
+// from the DB.
+const subscriptionReaperLease = "subscriptionReaper"
+
+// StartSubscriptionReaper removes expired subscriptions every interval until
+// ctx is cancelled. Only the replica holding the subscriptionReaperLease
+// removes them from the DB, so that the replicas do not race to delete the
+// same ones. Every replica drops them from its in-process caches, which
+// would otherwise keep those the leader removed.
+func StartSubscriptionReaper(ctx context.Context, interval time.Duration) {
+	if CommonDBClient == nil || interval <= 0 {
+		return
+	}
+	logger.DataRepoLog.Infof("started subscription reaper every %v", interval)
+	var wg sync.WaitGroup
+	if commonMongoClient != nil {
+		wg.Go(func() {
+			runAsLeader(ctx, subscriptionReaperLease, newLease(commonMongoClient, subscriptionReaperLease),
+				func(ctx context.Context) {
+					backfillSubscriptionExpiry(ctx)
+					every(ctx, interval, reapExpiredSubscriptions)
+				})
+		})
+	}
+	every(ctx, interval, pruneExpiredSubscriptions)
+	wg.Wait()
+	logger.DataRepoLog.Infoln("subscription reaper stopped")
+}
+
+// every calls fn with the current time every interval until ctx is
+// cancelled.
+func every(ctx context.Context, interval time.Duration, fn func(ctx context.Context, now time.Time)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx, time.Now())
+		}
+	}
+}
+
+// pruneExpiredSubscriptions drops the subscriptions whose expiry is not
+// after now from the in-process caches, without touching the DB.
+func pruneExpiredSubscriptions(_ context.Context, now time.Time) {
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.SubscriptionDataSubsMtx.Lock()
+	for subsId, subscriptionDataSubscriptions := range udrSelf.SubscriptionDataSubscriptions {
+		if subscriptionExpired(subscriptionDataSubscriptions, now) {
+			delete(udrSelf.SubscriptionDataSubscriptions, subsId)
+		}
+	}
+	udrSelf.SubscriptionDataSubsMtx.Unlock()
+
+	udrSelf.PolicyDataSubsMtx.Lock()
+	for subsId, policyDataSubscription := range udrSelf.PolicyDataSubscriptions {
+		if subscriptionExpired(policyDataSubscription, now) {
+			delete(udrSelf.PolicyDataSubscriptions, subsId)
+		}
+	}
+	udrSelf.PolicyDataSubsMtx.Unlock()
+}
+
+// backfillSubscriptionExpiry stores the expiry field of subscriptions
+// written before it was introduced, so that reapExpiredSubscriptions finds
+// them. Once they are all done, this is a single indexed query per
+// collection.
+func backfillSubscriptionExpiry(ctx context.Context) {
+	filter := bson.M{
+		subscriptionExpiryField: bson.M{"$exists": false},
+		"subscription.expiry":   bson.M{"$exists": true},
+	}
+	for _, collName := range subscriptionCollections {
+		documents, err := CommonDBClient.RestfulAPIGetMany(ctx, collName, filter)
+		if err != nil {
+			logger.DataRepoLog.Warnln(err)
+			continue
+		}
+		for _, document := range documents {
+			var subscription struct {
+				Expiry *time.Time `json:"expiry"`
+			}
+			if !decodeSubscription(document, &subscription) || subscription.Expiry == nil {
+				continue
+			}
+			keys := bson.M{}
+			for _, key := range []string{"subsId", "ueId", "ueGroupId"} {
+				if value, ok := document[key]; ok {
+					keys[key] = value
+				}
+			}
+			patch := bson.M{subscriptionExpiryField: subscription.Expiry.UTC()}
+			if err := CommonDBClient.RestfulAPIMergePatch(ctx, collName, keys, patch); err != nil {
+				logger.DataRepoLog.Warnln(err)
+			}
+		}
+	}
+}
+
+// reapExpiredSubscriptions removes every stored subscription whose expiry is
+// not after now. Removal goes through the same procedures as a DELETE from
+// the subscribing NF, so the in-process cache and dependent records are
+// cleaned up too.
+func reapExpiredSubscriptions(ctx context.Context, now time.Time) {
+	reapExpired(ctx, SUBSCDATA_SUBS_TO_NOTIFY, now,
+		func() expiringSubscription { return new(models.SubscriptionDataSubscriptions) },
+		func(document map[string]interface{}) *models.ProblemDetails {
+			return RemovesubscriptionDataSubscriptionsProcedure(ctx, documentString(document, "subsId"))
+		})
+	reapExpired(ctx, POLICYDATA_SUBS_TO_NOTIFY, now,
+		func() expiringSubscription { return new(models.PolicyDataSubscription) },
+		func(document map[string]interface{}) *models.ProblemDetails {
+			return PolicyDataSubsToNotifySubsIdDeleteProcedure(ctx, documentString(document, "subsId"))
+		})
+	reapExpired(ctx, SUBSCDATA_CTXDATA_SDM_SUBSCRIPTIONS, now,
+		func() expiringSubscription { return new(models.SdmSubscription) },
+		func(document map[string]interface{}) *models.ProblemDetails {
+			return RemovesdmSubscriptionsProcedure(ctx, documentString(document, "ueId"), documentString(document, "subsId"))
+		})
+	reapExpired(ctx, SUBSCDATA_CTXDATA_EE_SUBSCRIPTIONS, now,
+		func() expiringSubscription { return new(models.EeSubscription) },
+		func(document map[string]interface{}) *models.ProblemDetails {
+			return RemoveeeSubscriptionsProcedure(ctx, documentString(document, "ueId"), documentString(document, "subsId"))
+		})
+	reapExpired(ctx, SUBSCDATA_GROUPDATA_EE_SUBSCRIPTIONS, now,
+		func() expiringSubscription { return new(models.EeSubscription) },
+		func(document map[string]interface{}) *models.ProblemDetails {
+			return RemoveEeGroupSubscriptionsProcedure(ctx, documentString(document, "ueGroupId"), documentString(document, "subsId"))
+		})
+}
+
+func reapExpired(ctx context.Context, collName string, now time.Time, newSubscription func() expiringSubscription,
+	remove func(document map[string]interface{}) *models.ProblemDetails,
+) {
+	documents, err := CommonDBClient.RestfulAPIGetMany(ctx, collName,
+		bson.M{subscriptionExpiryField: bson.M{"$lte": now.UTC()}})
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return
+	}
+	for _, document := range documents {
+		subscription := newSubscription()
+		if !decodeSubscription(document, subscription) || !subscriptionExpired(subscription, now) {
+			continue
+		}
+		if problemDetails := remove(document); problemDetails != nil {
+			logger.DataRepoLog.Warnf("remove expired subscription %s from %s failed: %s",
+				documentString(document, "subsId"), collName, problemDetails.GetDetail())
+			continue
+		}
+		logger.DataRepoLog.Infof("removed expired subscription %s from %s", documentString(document, "subsId"), collName)
+	}
+}
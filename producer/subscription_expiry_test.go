@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/omec-project/openapi/v2/models"
+	udr_context "github.com/omec-project/udr/context"
+	"github.com/omec-project/util/httpwrapper"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestClampSubscriptionExpiry(t *testing.T) {
+	udrSelf := udr_context.UDR_Self()
+	savedMaxExpiry := udrSelf.MaxSubscriptionExpiry
+	t.Cleanup(func() { udrSelf.MaxSubscriptionExpiry = savedMaxExpiry })
+	udrSelf.MaxSubscriptionExpiry = time.Hour
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		requested time.Time
+		want      time.Time
+	}{
+		{name: "no expiry requested", want: now.Add(time.Hour)},
+		{name: "expiry beyond the maximum", requested: now.Add(24 * time.Hour), want: now.Add(time.Hour)},
+		{name: "expiry within the maximum", requested: now.Add(time.Minute), want: now.Add(time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := models.SdmSubscription{}
+			if !tt.requested.IsZero() {
+				subscription.SetExpiry(tt.requested)
+			}
+			clampSubscriptionExpiry(&subscription, now)
+			if got := subscription.GetExpiry(); !got.Equal(tt.want) {
+				t.Errorf("expiry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplacedSubscriptionExpiryIsClamped(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := newMemDB()
+	CommonDBClient = db
+
+	udrSelf := udr_context.UDR_Self()
+	savedMaxExpiry := udrSelf.MaxSubscriptionExpiry
+	t.Cleanup(func() { udrSelf.MaxSubscriptionExpiry = savedMaxExpiry })
+	udrSelf.MaxSubscriptionExpiry = time.Hour
+
+	const ueId = "imsi-001010000000206"
+	sdmSubscription := models.SdmSubscription{}
+	sdmSubscription.SetCallbackReference("http://udm.example/sdm-notify")
+	_, created, pd := CreateSdmSubscriptionsProcedure(context.Background(), sdmSubscription, "", ueId)
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+
+	start := time.Now()
+	sdmSubscription.SetExpiry(start.Add(24 * time.Hour))
+	rsp := HandleUpdatesdmsubscriptions(context.Background(), &httpwrapper.Request{
+		Params: map[string]string{"ueId": ueId, "subsId": created.GetSubscriptionId()},
+		Body:   sdmSubscription,
+	})
+	replaced, ok := rsp.Body.(*models.SdmSubscription)
+	if rsp.Status != http.StatusOK || !ok {
+		t.Fatalf("expected 200 with the stored subscription, got %d %#v", rsp.Status, rsp.Body)
+	}
+	if replaced.GetExpiry().After(time.Now().Add(time.Hour)) {
+		t.Errorf("expected the returned expiry to be clamped to an hour, got %v", replaced.GetExpiry())
+	}
+	document, _ := db.RestfulAPIGetOne(context.Background(), SUBSCDATA_CTXDATA_SDM_SUBSCRIPTIONS,
+		bson.M{"ueId": ueId, "subsId": created.GetSubscriptionId()})
+	if expiresAt, _ := document[subscriptionExpiryField].(time.Time); !expiresAt.Equal(replaced.GetExpiry().UTC()) {
+		t.Errorf("expected the clamped expiry to be stored, got %v", document[subscriptionExpiryField])
+	}
+}
+
+func TestReapExpiredSubscriptions(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	CommonDBClient = newMemDB()
+
+	const ueId = "imsi-001010000000203"
+
+	now := time.Now()
+	expired := models.SdmSubscription{}
+	expired.SetCallbackReference("http://udm-1.example/sdm-notify")
+	expired.SetExpiry(now.Add(-time.Minute))
+	_, _, pd := CreateSdmSubscriptionsProcedure(context.Background(), expired, "", ueId)
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	live := models.SdmSubscription{}
+	live.SetCallbackReference("http://udm-2.example/sdm-notify")
+	live.SetExpiry(now.Add(time.Hour))
+	_, live, pd = CreateSdmSubscriptionsProcedure(context.Background(), live, "", ueId)
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+
+	reapExpiredSubscriptions(context.Background(), now)
+
+	subscriptions, pd := QuerysdmsubscriptionsProcedure(context.Background(), ueId)
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	if len(*subscriptions) != 1 || (*subscriptions)[0].GetSubscriptionId() != live.GetSubscriptionId() {
+		t.Fatalf("expected only subscription %s to remain, got %#v", live.GetSubscriptionId(), *subscriptions)
+	}
+}
+
+func TestPruneExpiredSubscriptionsLeavesTheDB(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := newMemDB()
+	CommonDBClient = db
+
+	udrSelf := udr_context.UDR_Self()
+	savedSubscriptions := udrSelf.SubscriptionDataSubscriptions
+	t.Cleanup(func() { udrSelf.SubscriptionDataSubscriptions = savedSubscriptions })
+	udrSelf.SubscriptionDataSubscriptions = make(map[string]*models.SubscriptionDataSubscriptions)
+
+	now := time.Now()
+	expired := models.NewSubscriptionDataSubscriptions("http://udm.example/notify", []string{})
+	expired.SetUeId("imsi-001010000000205")
+	expired.SetExpiry(now.Add(-time.Minute))
+	if err := putSubscriptionDataSubscription(context.Background(), "expired", expired); err != nil {
+		t.Fatal(err)
+	}
+
+	pruneExpiredSubscriptions(context.Background(), now)
+
+	if _, ok := udrSelf.SubscriptionDataSubscriptions["expired"]; ok {
+		t.Error("expected the expired subscription to be dropped from the cache")
+	}
+	if documents, _ := db.RestfulAPIGetMany(context.Background(), SUBSCDATA_SUBS_TO_NOTIFY, bson.M{"subsId": "expired"}); len(documents) != 1 {
+		t.Error("expected the expired subscription to be left in the DB for the reaper")
+	}
+}
@@ -0,0 +1,401 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/omec-project/openapi/v2/models"
+	udr_context "github.com/omec-project/udr/context"
+	"github.com/omec-project/udr/logger"
+	"github.com/omec-project/udr/util"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Subscriptions are persisted in the collections below so that they survive
+// a restart and are visible to every UDR replica. The subs-to-notify maps in
+// UDRContext act as the in-process cache for notification fan-out: writes go
+// to the DB first and then to the cache, lookups by ID read the DB and
+// refresh the cache, and fan-out refreshes the cache from the DB so
+// subscriptions created through another replica are notified too. SDM and EE subscriptions
+// are read from the DB on every request instead, as any replica may have
+// created or removed one since.
+//
+// Each document carries its keys next to the subscription body, which is
+// stored unchanged under "subscription", and the expiry of the subscription,
+// if any, under subscriptionExpiryField.
+const (
+	subscriptionExpiryField = "expiresAt"
+
+	SUBSCDATA_SUBS_TO_NOTIFY             = "subscriptionData.subsToNotify"
+	POLICYDATA_SUBS_TO_NOTIFY            = "policyData.subsToNotify"
+	SUBSCDATA_CTXDATA_SDM_SUBSCRIPTIONS  = "subscriptionData.contextData.sdmSubscriptions"
+	SUBSCDATA_CTXDATA_EE_SUBSCRIPTIONS   = "subscriptionData.contextData.eeSubscriptions"
+	SUBSCDATA_GROUPDATA_EE_SUBSCRIPTIONS = "subscriptionData.groupData.eeSubscriptions"
+)
+
+// subscriptionCollections lists the collections holding subscriptions.
+var subscriptionCollections = []string{
+	SUBSCDATA_SUBS_TO_NOTIFY,
+	POLICYDATA_SUBS_TO_NOTIFY,
+	SUBSCDATA_CTXDATA_SDM_SUBSCRIPTIONS,
+	SUBSCDATA_CTXDATA_EE_SUBSCRIPTIONS,
+	SUBSCDATA_GROUPDATA_EE_SUBSCRIPTIONS,
+}
+
+func subscriptionDocument(keys bson.M, subscription interface{}) bson.M {
+	document := bson.M{"subscription": util.ToBsonM(subscription)}
+	for k, v := range keys {
+		document[k] = v
+	}
+	if expiring, ok := subscription.(expiringSubscription); ok && expiring.HasExpiry() {
+		document[subscriptionExpiryField] = expiring.GetExpiry().UTC()
+	}
+	return document
+}
+
+// decodeSubscription unmarshals the "subscription" body of a document into
+// subscription, which must be a pointer to the subscription model.
+func decodeSubscription(document map[string]interface{}, subscription interface{}) bool {
+	body, ok := document["subscription"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(util.MapToByte(body), subscription); err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return false
+	}
+	return true
+}
+
+func documentString(document map[string]interface{}, key string) string {
+	value, _ := document[key].(string)
+	return value
+}
+
+func documentStrings(value interface{}) []string {
+	var values []string
+	switch v := value.(type) {
+	case bson.A:
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				values = append(values, str)
+			}
+		}
+	case []interface{}:
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				values = append(values, str)
+			}
+		}
+	}
+	return values
+}
+
+func putSubscriptionDataSubscription(ctx context.Context, subsId string, subscriptionDataSubscriptions *models.SubscriptionDataSubscriptions) error {
+	filter := bson.M{"subsId": subsId}
+	document := subscriptionDocument(bson.M{"subsId": subsId, "ueId": subscriptionDataSubscriptions.GetUeId()},
+		subscriptionDataSubscriptions)
+	if _, err := CommonDBClient.RestfulAPIPutOne(ctx, SUBSCDATA_SUBS_TO_NOTIFY, filter, document); err != nil {
+		return err
+	}
+
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.SubscriptionDataSubsMtx.Lock()
+	udrSelf.SubscriptionDataSubscriptions[subsId] = subscriptionDataSubscriptions
+	udrSelf.SubscriptionDataSubsMtx.Unlock()
+	return nil
+}
+
+// loadSubscriptionDataSubscription reads subsId from the DB, as another
+// replica may have removed or replaced it since it was cached, and refreshes
+// the cache with the result.
+func loadSubscriptionDataSubscription(ctx context.Context, subsId string) (*models.SubscriptionDataSubscriptions, bool) {
+	document, err := CommonDBClient.RestfulAPIGetOne(ctx, SUBSCDATA_SUBS_TO_NOTIFY, bson.M{"subsId": subsId})
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return nil, false
+	}
+
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.SubscriptionDataSubsMtx.Lock()
+	defer udrSelf.SubscriptionDataSubsMtx.Unlock()
+	subscriptionDataSubscriptions := new(models.SubscriptionDataSubscriptions)
+	if document == nil || !decodeSubscription(document, subscriptionDataSubscriptions) {
+		delete(udrSelf.SubscriptionDataSubscriptions, subsId)
+		return nil, false
+	}
+	udrSelf.SubscriptionDataSubscriptions[subsId] = subscriptionDataSubscriptions
+	return subscriptionDataSubscriptions, true
+}
+
+func deleteSubscriptionDataSubscription(ctx context.Context, subsId string) error {
+	if err := CommonDBClient.RestfulAPIDeleteOne(ctx, SUBSCDATA_SUBS_TO_NOTIFY, bson.M{"subsId": subsId}); err != nil {
+		return err
+	}
+
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.SubscriptionDataSubsMtx.Lock()
+	delete(udrSelf.SubscriptionDataSubscriptions, subsId)
+	udrSelf.SubscriptionDataSubsMtx.Unlock()
+	return nil
+}
+
+// refreshSubscriptionDataSubscriptions replaces the cached subscriptions of
+// ueIds with those in the DB, so a notification about them reaches
+// subscriptions created, and skips those removed, through another replica.
+func refreshSubscriptionDataSubscriptions(ctx context.Context, ueIds ...string) {
+	filter := bson.M{"ueId": bson.M{"$in": ueIds}}
+	if len(ueIds) == 1 {
+		filter = bson.M{"ueId": ueIds[0]}
+	}
+	documents, err := CommonDBClient.RestfulAPIGetMany(ctx, SUBSCDATA_SUBS_TO_NOTIFY, filter)
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return
+	}
+
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.SubscriptionDataSubsMtx.Lock()
+	defer udrSelf.SubscriptionDataSubsMtx.Unlock()
+	for subsId, subscriptionDataSubscriptions := range udrSelf.SubscriptionDataSubscriptions {
+		if slices.Contains(ueIds, subscriptionDataSubscriptions.GetUeId()) {
+			delete(udrSelf.SubscriptionDataSubscriptions, subsId)
+		}
+	}
+	for _, document := range documents {
+		subsId, _ := document["subsId"].(string)
+		subscriptionDataSubscriptions := new(models.SubscriptionDataSubscriptions)
+		if subsId != "" && decodeSubscription(document, subscriptionDataSubscriptions) {
+			udrSelf.SubscriptionDataSubscriptions[subsId] = subscriptionDataSubscriptions
+		}
+	}
+}
+
+func putPolicyDataSubscription(ctx context.Context, subsId string, policyDataSubscription *models.PolicyDataSubscription) error {
+	filter := bson.M{"subsId": subsId}
+	document := subscriptionDocument(bson.M{"subsId": subsId}, policyDataSubscription)
+	if _, err := CommonDBClient.RestfulAPIPutOne(ctx, POLICYDATA_SUBS_TO_NOTIFY, filter, document); err != nil {
+		return err
+	}
+
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.PolicyDataSubsMtx.Lock()
+	udrSelf.PolicyDataSubscriptions[subsId] = policyDataSubscription
+	udrSelf.PolicyDataSubsMtx.Unlock()
+	return nil
+}
+
+// loadPolicyDataSubscription is loadSubscriptionDataSubscription for policy
+// data subscriptions.
+func loadPolicyDataSubscription(ctx context.Context, subsId string) (*models.PolicyDataSubscription, bool) {
+	document, err := CommonDBClient.RestfulAPIGetOne(ctx, POLICYDATA_SUBS_TO_NOTIFY, bson.M{"subsId": subsId})
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return nil, false
+	}
+
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.PolicyDataSubsMtx.Lock()
+	defer udrSelf.PolicyDataSubsMtx.Unlock()
+	policyDataSubscription := new(models.PolicyDataSubscription)
+	if document == nil || !decodeSubscription(document, policyDataSubscription) {
+		delete(udrSelf.PolicyDataSubscriptions, subsId)
+		return nil, false
+	}
+	udrSelf.PolicyDataSubscriptions[subsId] = policyDataSubscription
+	return policyDataSubscription, true
+}
+
+func deletePolicyDataSubscription(ctx context.Context, subsId string) error {
+	if err := CommonDBClient.RestfulAPIDeleteOne(ctx, POLICYDATA_SUBS_TO_NOTIFY, bson.M{"subsId": subsId}); err != nil {
+		return err
+	}
+
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.PolicyDataSubsMtx.Lock()
+	delete(udrSelf.PolicyDataSubscriptions, subsId)
+	udrSelf.PolicyDataSubsMtx.Unlock()
+	return nil
+}
+
+// policyDataRefresh serialises the reloads of refreshPolicyDataSubscriptionsSince
+// and records when the last one started.
+var policyDataRefresh struct {
+	sync.Mutex
+	started time.Time
+}
+
+// refreshPolicyDataSubscriptionsSince reloads the policy data subscriptions
+// unless a reload started after requested, which already saw every
+// subscription made by then. A burst of changes thus costs one reload, not
+// one each.
+func refreshPolicyDataSubscriptionsSince(ctx context.Context, requested time.Time) {
+	policyDataRefresh.Lock()
+	defer policyDataRefresh.Unlock()
+	if policyDataRefresh.started.After(requested) {
+		return
+	}
+	policyDataRefresh.started = time.Now()
+	refreshPolicyDataSubscriptions(ctx)
+}
+
+// refreshPolicyDataSubscriptions replaces the cached policy data
+// subscriptions with those in the DB. Every policy data change is notified
+// to every subscriber, so the whole collection is reloaded.
+func refreshPolicyDataSubscriptions(ctx context.Context) {
+	documents, err := CommonDBClient.RestfulAPIGetMany(ctx, POLICYDATA_SUBS_TO_NOTIFY, bson.M{})
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+		return
+	}
+
+	policyDataSubscriptions := make(map[string]*models.PolicyDataSubscription, len(documents))
+	for _, document := range documents {
+		subsId, _ := document["subsId"].(string)
+		policyDataSubscription := new(models.PolicyDataSubscription)
+		if subsId != "" && decodeSubscription(document, policyDataSubscription) {
+			policyDataSubscriptions[subsId] = policyDataSubscription
+		}
+	}
+
+	udrSelf := udr_context.UDR_Self()
+	udrSelf.PolicyDataSubsMtx.Lock()
+	udrSelf.PolicyDataSubscriptions = policyDataSubscriptions
+	udrSelf.PolicyDataSubsMtx.Unlock()
+}
+
+// loadUESubsData reads the SDM and EE subscriptions of ueId from the DB. ok
+// is false if the UE has none.
+func loadUESubsData(ctx context.Context, ueId string) (*udr_context.UESubsData, bool) {
+	filter := bson.M{"ueId": ueId}
+	sdmDocuments, err := CommonDBClient.RestfulAPIGetMany(ctx, SUBSCDATA_CTXDATA_SDM_SUBSCRIPTIONS, filter)
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+	}
+	eeDocuments, err := CommonDBClient.RestfulAPIGetMany(ctx, SUBSCDATA_CTXDATA_EE_SUBSCRIPTIONS, filter)
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+	}
+	if len(sdmDocuments) == 0 && len(eeDocuments) == 0 {
+		return nil, false
+	}
+
+	UESubsData := &udr_context.UESubsData{
+		SdmSubscriptions:         make(map[string]*models.SdmSubscription),
+		EeSubscriptionCollection: make(map[string]*udr_context.EeSubscriptionCollection),
+	}
+	for _, document := range sdmDocuments {
+		subsId, _ := document["subsId"].(string)
+		sdmSubscription := new(models.SdmSubscription)
+		if subsId != "" && decodeSubscription(document, sdmSubscription) {
+			UESubsData.SdmSubscriptions[subsId] = sdmSubscription
+		}
+	}
+	for _, document := range eeDocuments {
+		subsId, _ := document["subsId"].(string)
+		eeSubscription := new(models.EeSubscription)
+		if subsId == "" || !decodeSubscription(document, eeSubscription) {
+			continue
+		}
+		eeSubscriptionCollection := &udr_context.EeSubscriptionCollection{EeSubscriptions: eeSubscription}
+		if infos, ok := document["amfSubscriptionInfos"]; ok && infos != nil {
+			raw, err := json.Marshal(infos)
+			if err == nil {
+				err = json.Unmarshal(raw, &eeSubscriptionCollection.AmfSubscriptionInfos)
+			}
+			if err != nil {
+				logger.DataRepoLog.Warnln(err)
+			}
+		}
+		UESubsData.EeSubscriptionCollection[subsId] = eeSubscriptionCollection
+	}
+	return UESubsData, true
+}
+
+// loadOrCreateUESubsData is loadUESubsData for writers: a UE without
+// subscriptions gets an empty UESubsData.
+func loadOrCreateUESubsData(ctx context.Context, ueId string) *udr_context.UESubsData {
+	if UESubsData, ok := loadUESubsData(ctx, ueId); ok {
+		return UESubsData
+	}
+	return new(udr_context.UESubsData)
+}
+
+func putSdmSubscription(ctx context.Context, ueId string, subsId string, sdmSubscription *models.SdmSubscription) error {
+	filter := bson.M{"ueId": ueId, "subsId": subsId}
+	document := subscriptionDocument(filter, sdmSubscription)
+	_, err := CommonDBClient.RestfulAPIPutOne(ctx, SUBSCDATA_CTXDATA_SDM_SUBSCRIPTIONS, filter, document)
+	return err
+}
+
+func deleteSdmSubscription(ctx context.Context, ueId string, subsId string) error {
+	return CommonDBClient.RestfulAPIDeleteOne(ctx, SUBSCDATA_CTXDATA_SDM_SUBSCRIPTIONS, bson.M{"ueId": ueId, "subsId": subsId})
+}
+
+func putEeSubscription(ctx context.Context, ueId string, subsId string, eeSubscriptionCollection *udr_context.EeSubscriptionCollection) error {
+	filter := bson.M{"ueId": ueId, "subsId": subsId}
+	document := subscriptionDocument(filter, eeSubscriptionCollection.EeSubscriptions)
+	if eeSubscriptionCollection.AmfSubscriptionInfos != nil {
+		var amfSubscriptionInfos []interface{}
+		raw, err := json.Marshal(eeSubscriptionCollection.AmfSubscriptionInfos)
+		if err == nil {
+			err = json.Unmarshal(raw, &amfSubscriptionInfos)
+		}
+		if err != nil {
+			return err
+		}
+		document["amfSubscriptionInfos"] = amfSubscriptionInfos
+	}
+	_, err := CommonDBClient.RestfulAPIPutOne(ctx, SUBSCDATA_CTXDATA_EE_SUBSCRIPTIONS, filter, document)
+	return err
+}
+
+func deleteEeSubscription(ctx context.Context, ueId string, subsId string) error {
+	return CommonDBClient.RestfulAPIDeleteOne(ctx, SUBSCDATA_CTXDATA_EE_SUBSCRIPTIONS, bson.M{"ueId": ueId, "subsId": subsId})
+}
+
+// loadUEGroupSubsData reads the EE subscriptions of ueGroupId from the DB. ok
+// is false if the group has none.
+func loadUEGroupSubsData(ctx context.Context, ueGroupId string) (*udr_context.UEGroupSubsData, bool) {
+	documents, err := CommonDBClient.RestfulAPIGetMany(ctx, SUBSCDATA_GROUPDATA_EE_SUBSCRIPTIONS, bson.M{"ueGroupId": ueGroupId})
+	if err != nil {
+		logger.DataRepoLog.Warnln(err)
+	}
+	if len(documents) == 0 {
+		return nil, false
+	}
+
+	UEGroupSubsData := &udr_context.UEGroupSubsData{
+		EeSubscriptions: make(map[string]*models.EeSubscription),
+		MemberUeIds:     make(map[string][]string),
+	}
+	for _, document := range documents {
+		subsId, _ := document["subsId"].(string)
+		eeSubscription := new(models.EeSubscription)
+		if subsId != "" && decodeSubscription(document, eeSubscription) {
+			UEGroupSubsData.EeSubscriptions[subsId] = eeSubscription
+			UEGroupSubsData.MemberUeIds[subsId] = documentStrings(document["memberUeIds"])
+		}
+	}
+	return UEGroupSubsData, true
+}
+
+func putEeGroupSubscription(ctx context.Context, ueGroupId string, subsId string, eeSubscription *models.EeSubscription,
+	memberUeIds []string,
+) error {
+	filter := bson.M{"ueGroupId": ueGroupId, "subsId": subsId}
+	document := subscriptionDocument(filter, eeSubscription)
+	document["memberUeIds"] = memberUeIds
+	_, err := CommonDBClient.RestfulAPIPutOne(ctx, SUBSCDATA_GROUPDATA_EE_SUBSCRIPTIONS, filter, document)
+	return err
+}
+
+func deleteEeGroupSubscription(ctx context.Context, ueGroupId string, subsId string) error {
+	return CommonDBClient.RestfulAPIDeleteOne(ctx, SUBSCDATA_GROUPDATA_EE_SUBSCRIPTIONS, bson.M{"ueGroupId": ueGroupId, "subsId": subsId})
+}
@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omec-project/openapi/v2/models"
+	udr_context "github.com/omec-project/udr/context"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// memDB keeps documents per collection and matches filters on equality of
+// every filter field, on a $lte bound of a time, on $in a list of strings,
+// or on any branch of an $or, which is all the subscription store relies on.
+type memDB struct {
+	stubDB
+	mu          sync.Mutex
+	collections map[string][]map[string]any
+}
+
+func newMemDB() *memDB {
+	return &memDB{collections: make(map[string][]map[string]any)}
+}
+
+func matchesFilter(document map[string]any, filter bson.M) bool {
+	for k, v := range filter {
+		if k == "$or" {
+			if !matchesAnyFilter(document, v.(bson.A)) {
+				return false
+			}
+			continue
+		}
+		if bound, ok := v.(bson.M); ok {
+			if !matchesBound(document[k], bound) {
+				return false
+			}
+			continue
+		}
+		if document[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesBound(value any, bound bson.M) bool {
+	if values, ok := bound["$in"].([]string); ok {
+		s, _ := value.(string)
+		return slices.Contains(values, s)
+	}
+	t, ok := value.(time.Time)
+	return ok && !t.After(bound["$lte"].(time.Time))
+}
+
+func matchesAnyFilter(document map[string]any, filters bson.A) bool {
+	for _, filter := range filters {
+		if matchesFilter(document, filter.(bson.M)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memDB) RestfulAPIGetOne(ctx context.Context, collName string, filter bson.M) (map[string]any, error) {
+	documents, _ := m.RestfulAPIGetMany(ctx, collName, filter)
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	return documents[0], nil
+}
+
+func (m *memDB) RestfulAPIGetMany(ctx context.Context, collName string, filter bson.M) ([]map[string]any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var documents []map[string]any
+	for _, document := range m.collections[collName] {
+		if matchesFilter(document, filter) {
+			documents = append(documents, maps.Clone(document))
+		}
+	}
+	return documents, nil
+}
+
+func (m *memDB) RestfulAPIPutOne(ctx context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, document := range m.collections[collName] {
+		if matchesFilter(document, filter) {
+			m.collections[collName][i] = maps.Clone(putData)
+			return true, nil
+		}
+	}
+	m.collections[collName] = append(m.collections[collName], maps.Clone(putData))
+	return false, nil
+}
+
+func (m *memDB) RestfulAPIDeleteOne(ctx context.Context, collName string, filter bson.M) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, document := range m.collections[collName] {
+		if matchesFilter(document, filter) {
+			m.collections[collName] = append(m.collections[collName][:i], m.collections[collName][i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func TestSdmSubscriptionsAreReadFromDB(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	CommonDBClient = newMemDB()
+
+	const ueId = "imsi-001010000000201"
+	sdmSubscription := models.SdmSubscription{}
+	sdmSubscription.SetCallbackReference("http://udm.example/sdm-notify")
+	_, created, pd := CreateSdmSubscriptionsProcedure(context.Background(), sdmSubscription, "", ueId)
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+
+	// A subscription written straight to the DB stands in for one created
+	// through another replica.
+	other := models.SdmSubscription{}
+	other.SetCallbackReference("http://udm.example/other-notify")
+	if err := putSdmSubscription(context.Background(), ueId, "other", &other); err != nil {
+		t.Fatal(err)
+	}
+
+	subscriptions, pd := QuerysdmsubscriptionsProcedure(context.Background(), ueId)
+	if pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	if len(*subscriptions) != 2 {
+		t.Fatalf("expected both stored subscriptions to be loaded from the DB, got %#v", *subscriptions)
+	}
+
+	if pd := RemovesdmSubscriptionsProcedure(context.Background(), ueId, "other"); pd != nil {
+		t.Fatalf("unexpected problem details: %+v", pd)
+	}
+	if err := deleteSdmSubscription(context.Background(), ueId, created.GetSubscriptionId()); err != nil {
+		t.Fatal(err)
+	}
+	if _, pd := QuerysdmsubscriptionsProcedure(context.Background(), ueId); pd == nil {
+		t.Fatal("expected the removed subscriptions to be gone from the DB")
+	}
+}
+
+func TestSubscriptionDataSubscriptionsAreRefreshedFromDB(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := newMemDB()
+	CommonDBClient = db
+
+	udrSelf := udr_context.UDR_Self()
+	savedSubscriptions := udrSelf.SubscriptionDataSubscriptions
+	t.Cleanup(func() { udrSelf.SubscriptionDataSubscriptions = savedSubscriptions })
+	udrSelf.SubscriptionDataSubscriptions = make(map[string]*models.SubscriptionDataSubscriptions)
+
+	const ueId = "imsi-001010000000202"
+	stale := models.NewSubscriptionDataSubscriptions("http://udm-1.example/notify", []string{})
+	stale.SetUeId(ueId)
+	udrSelf.SubscriptionDataSubscriptions["stale"] = stale
+
+	// A subscription created through another replica exists only in the DB.
+	stored := models.NewSubscriptionDataSubscriptions("http://udm-2.example/notify", []string{})
+	stored.SetUeId(ueId)
+	if _, err := db.RestfulAPIPutOne(context.Background(), SUBSCDATA_SUBS_TO_NOTIFY, bson.M{"subsId": "7"},
+		subscriptionDocument(bson.M{"subsId": "7", "ueId": ueId}, stored)); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshSubscriptionDataSubscriptions(context.Background(), ueId)
+
+	if _, ok := udrSelf.SubscriptionDataSubscriptions["stale"]; ok {
+		t.Error("expected a subscription missing from the DB to be dropped from the cache")
+	}
+	got, ok := udrSelf.SubscriptionDataSubscriptions["7"]
+	if !ok || got.GetCallbackReference() != "http://udm-2.example/notify" {
+		t.Errorf("expected the stored subscription to be cached, got %#v", got)
+	}
+}
+
+func TestSubscriptionDataSubscriptionIDsAreUniqueUUIDs(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	CommonDBClient = newMemDB()
+
+	udrSelf := udr_context.UDR_Self()
+	savedSubscriptions := udrSelf.SubscriptionDataSubscriptions
+	t.Cleanup(func() { udrSelf.SubscriptionDataSubscriptions = savedSubscriptions })
+	udrSelf.SubscriptionDataSubscriptions = make(map[string]*models.SubscriptionDataSubscriptions)
+
+	prefix := udrSelf.GetIPv4GroupUri(udr_context.NUDR_DR) + "/subscription-data/subs-to-notify/"
+	seen := make(map[string]bool)
+	for range 3 {
+		subscription := models.NewSubscriptionDataSubscriptions("http://udm.example/notify", []string{})
+		location, pd := PostSubscriptionDataSubscriptionsProcedure(context.Background(), *subscription)
+		if pd != nil {
+			t.Fatalf("unexpected problem details: %+v", pd)
+		}
+		if !strings.HasPrefix(location, prefix) {
+			t.Errorf("expected the Location header to start with %q, got %q", prefix, location)
+		}
+		subsId := path.Base(location)
+		if _, err := uuid.Parse(subsId); err != nil {
+			t.Errorf("expected a UUID subscription ID, got %q", subsId)
+		}
+		if seen[subsId] {
+			t.Errorf("subscription ID %q handed out twice", subsId)
+		}
+		seen[subsId] = true
+	}
+}
+
+func TestPolicyDataSubscriptionsRemovedElsewhereAreNotFound(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := newMemDB()
+	CommonDBClient = db
+
+	udrSelf := udr_context.UDR_Self()
+	savedSubscriptions := udrSelf.PolicyDataSubscriptions
+	t.Cleanup(func() { udrSelf.PolicyDataSubscriptions = savedSubscriptions })
+	udrSelf.PolicyDataSubscriptions = make(map[string]*models.PolicyDataSubscription)
+
+	// A subscription still cached here but removed through another replica.
+	udrSelf.PolicyDataSubscriptions["removed"] = models.NewPolicyDataSubscription("http://pcf.example/notify")
+
+	subscription := models.NewPolicyDataSubscription("http://pcf.example/notify")
+	if _, pd := PolicyDataSubsToNotifySubsIdPutProcedure(context.Background(), "removed", *subscription); pd == nil ||
+		pd.GetStatus() != http.StatusNotFound {
+		t.Errorf("expected 404 replacing a removed subscription, got %+v", pd)
+	}
+	if documents, _ := db.RestfulAPIGetMany(context.Background(), POLICYDATA_SUBS_TO_NOTIFY, bson.M{}); len(documents) != 0 {
+		t.Errorf("expected the removed subscription not to be stored again, got %#v", documents)
+	}
+	if _, ok := udrSelf.PolicyDataSubscriptions["removed"]; ok {
+		t.Error("expected the removed subscription to be dropped from the cache")
+	}
+	if pd := PolicyDataSubsToNotifySubsIdDeleteProcedure(context.Background(), "removed"); pd == nil ||
+		pd.GetStatus() != http.StatusNotFound {
+		t.Errorf("expected 404 removing a removed subscription, got %+v", pd)
+	}
+}
+
+func TestPolicyDataSubscriptionsReloadOncePerBurst(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := &countingManyDB{}
+	CommonDBClient = db
+
+	udrSelf := udr_context.UDR_Self()
+	savedSubscriptions := udrSelf.PolicyDataSubscriptions
+	t.Cleanup(func() { udrSelf.PolicyDataSubscriptions = savedSubscriptions })
+
+	// Changes made before a reload starts are all covered by it.
+	requested := time.Now()
+	for range 3 {
+		refreshPolicyDataSubscriptionsSince(context.Background(), requested)
+	}
+	if got := db.getManyCallCount(); got != 1 {
+		t.Fatalf("expected 1 reload for the burst, got %d", got)
+	}
+
+	refreshPolicyDataSubscriptionsSince(context.Background(), time.Now())
+	if got := db.getManyCallCount(); got != 2 {
+		t.Errorf("expected a change after the reload to reload again, got %d", got)
+	}
+}
+
+func TestSubscriptionDataSubscriptionsOfManyUesAreRefreshedInOneQuery(t *testing.T) {
+	savedClient := CommonDBClient
+	t.Cleanup(func() { CommonDBClient = savedClient })
+	db := newMemDB()
+	CommonDBClient = db
+
+	udrSelf := udr_context.UDR_Self()
+	savedSubscriptions := udrSelf.SubscriptionDataSubscriptions
+	t.Cleanup(func() { udrSelf.SubscriptionDataSubscriptions = savedSubscriptions })
+	udrSelf.SubscriptionDataSubscriptions = make(map[string]*models.SubscriptionDataSubscriptions)
+
+	ueIds := []string{"imsi-001010000000206", "imsi-001010000000207", "imsi-001010000000208"}
+	for _, ueId := range ueIds[:2] {
+		subscription := models.NewSubscriptionDataSubscriptions("http://udm.example/notify", []string{})
+		subscription.SetUeId(ueId)
+		if _, err := db.RestfulAPIPutOne(context.Background(), SUBSCDATA_SUBS_TO_NOTIFY, bson.M{"subsId": ueId},
+			subscriptionDocument(bson.M{"subsId": ueId, "ueId": ueId}, subscription)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	refreshSubscriptionDataSubscriptions(context.Background(), ueIds...)
+
+	if got := len(udrSelf.SubscriptionDataSubscriptions); got != 2 {
+		t.Errorf("expected the subscriptions of both subscribed UEs to be loaded, got %d", got)
+	}
+}
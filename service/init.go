@@ -9,11 +9,13 @@ package service
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"sync"
 	"syscall"
+	"time"
 
 	openapiLogger "github.com/omec-project/openapi/v2/logger"
 	"github.com/omec-project/openapi/v2/models"
@@ -25,6 +27,7 @@ import (
 	"github.com/omec-project/udr/nfregistration"
 	"github.com/omec-project/udr/polling"
 	"github.com/omec-project/udr/producer"
+	"github.com/omec-project/udr/producer/callback"
 	"github.com/omec-project/udr/util"
 	"github.com/omec-project/util/http2_util"
 	utilLogger "github.com/omec-project/util/logger"
@@ -97,6 +100,13 @@ func (udr *UDR) Start() {
 	mongodb := config.Configuration.Mongodb
 	logger.InitLog.Infof("udr config info: Version[%s] Description[%s]", config.Info.Version, config.Info.Description)
 
+	// The DB caches are sized from the context, so initialise it first.
+	self := udrContext.UDR_Self()
+	util.InitUdrContext(self)
+	if err := callback.ConfigureClient(self); err != nil {
+		logger.InitLog.Fatalf("callback client setup failed: %+v", err)
+	}
+
 	// Connect to MongoDB
 	producer.ConnectMongo(mongodb.Url, mongodb.Name, mongodb.AuthUrl, mongodb.AuthKeysDbName)
 	logger.InitLog.Infoln("server started")
@@ -105,15 +115,17 @@ func (udr *UDR) Start() {
 
 	datarepository.AddService(router)
 
+	if self.AdminPort != 0 {
+		go serveAdmin(self.AdminPort)
+	}
 	go metrics.InitMetrics()
 
-	self := udrContext.UDR_Self()
-	util.InitUdrContext(self)
+	go producer.SendDataRestorationNotifications(context.Background())
 
 	plmnConfigChan := make(chan []models.PlmnId, 1)
 	ctx, cancelServices := context.WithCancel(context.Background())
 	var wg sync.WaitGroup
-	wg.Add(2)
+	wg.Add(6)
 	go func() {
 		defer wg.Done()
 		polling.StartPollingService(ctx, factory.UdrConfig.Configuration.WebuiUri, plmnConfigChan)
@@ -122,6 +134,22 @@ func (udr *UDR) Start() {
 		defer wg.Done()
 		nfregistration.StartNfRegistrationService(ctx, plmnConfigChan)
 	}()
+	go func() {
+		defer wg.Done()
+		producer.StartSubscriptionReaper(ctx, self.SubscriptionReaperInterval)
+	}()
+	go func() {
+		defer wg.Done()
+		producer.StartProvisionedDataWatcher(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		producer.StartCacheInvalidationListeners(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		producer.StartCacheSweeper(ctx)
+	}()
 
 	addr := fmt.Sprintf("%s:%d", self.BindingIPv4, self.SBIPort)
 
@@ -160,6 +188,23 @@ func (udr *UDR) Start() {
 	}
 }
 
+// serveAdmin serves the operator endpoints. They are unauthenticated, so
+// they listen on the loopback interface only.
+func serveAdmin(port int) {
+	mux := http.NewServeMux()
+	mux.Handle(producer.CacheAdminPath, producer.CacheAdminHandler())
+	mux.Handle(producer.BulkWriteAdminPath, producer.BulkWriteAdminHandler())
+	server := &http.Server{
+		Addr:              fmt.Sprintf("127.0.0.1:%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	logger.InitLog.Infof("admin endpoints listening on %s", server.Addr)
+	if err := server.ListenAndServe(); err != nil {
+		logger.InitLog.Errorf("could not open admin port: %+v", err)
+	}
+}
+
 func (udr *UDR) Terminate(cancelServices context.CancelFunc, wg *sync.WaitGroup) {
 	logger.InitLog.Infoln("terminating UDR")
 	cancelServices()
@@ -8,6 +8,7 @@ package util
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/omec-project/openapi/v2/models"
@@ -38,9 +39,34 @@ func InitUdrContext(context *context.UDRContext) {
 			if tls.Pem != "" {
 				context.PEM = tls.Pem
 			}
+			if tls.Ca != "" {
+				context.CA = tls.Ca
+			}
 		}
 		setBindingIPv4(context, sbi)
 	}
+	context.SubscriptionReaperInterval = factory.UDR_DEFAULT_SUBSCRIPTION_REAPER_INTERVAL * time.Second
+	if subscriptions := configuration.Subscriptions; subscriptions != nil {
+		context.MaxSubscriptionExpiry = time.Duration(subscriptions.MaxExpiry) * time.Second
+		if subscriptions.ReaperInterval > 0 {
+			context.SubscriptionReaperInterval = time.Duration(subscriptions.ReaperInterval) * time.Second
+		}
+	}
+	if cache := configuration.Cache; cache != nil {
+		context.CacheMaxEntries = cache.MaxEntries
+		context.CacheTTL = time.Duration(cache.TTL) * time.Second
+		context.CacheSweepInterval = time.Duration(cache.SweepInterval) * time.Second
+		context.CacheNegativeTTL = time.Duration(cache.NegativeTTL) * time.Second
+		if cache.Collections != nil {
+			context.CacheCollections = make(map[string]time.Duration, len(cache.Collections))
+			for collName, ttl := range cache.Collections {
+				context.CacheCollections[collName] = time.Duration(ttl) * time.Second
+			}
+		}
+	}
+	if admin := configuration.Admin; admin != nil {
+		context.AdminPort = admin.Port
+	}
 	if configuration.NrfUri != "" {
 		context.NrfUri = configuration.NrfUri
 	} else {
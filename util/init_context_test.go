@@ -6,6 +6,7 @@ package util
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/omec-project/openapi/v2/models"
@@ -303,3 +304,85 @@ func TestInitUdrContext_NilSbi(t *testing.T) {
 		t.Errorf("SBIPort = %d, want %d (default)", ctx.SBIPort, factory.UDR_DEFAULT_PORT_INT)
 	}
 }
+
+func TestInitUdrContext_SubscriptionsConfig(t *testing.T) {
+	origUdrConfig := factory.UdrConfig
+	t.Cleanup(func() { factory.UdrConfig = origUdrConfig })
+
+	factory.UdrConfig = newBaseConfig()
+	ctx := &context.UDRContext{}
+	InitUdrContext(ctx)
+	if ctx.MaxSubscriptionExpiry != 0 {
+		t.Errorf("MaxSubscriptionExpiry = %v, want 0", ctx.MaxSubscriptionExpiry)
+	}
+	if want := factory.UDR_DEFAULT_SUBSCRIPTION_REAPER_INTERVAL * time.Second; ctx.SubscriptionReaperInterval != want {
+		t.Errorf("SubscriptionReaperInterval = %v, want %v", ctx.SubscriptionReaperInterval, want)
+	}
+
+	factory.UdrConfig.Configuration.Subscriptions = &factory.Subscriptions{MaxExpiry: 3600, ReaperInterval: 30}
+	ctx = &context.UDRContext{}
+	InitUdrContext(ctx)
+	if ctx.MaxSubscriptionExpiry != time.Hour {
+		t.Errorf("MaxSubscriptionExpiry = %v, want %v", ctx.MaxSubscriptionExpiry, time.Hour)
+	}
+	if ctx.SubscriptionReaperInterval != 30*time.Second {
+		t.Errorf("SubscriptionReaperInterval = %v, want %v", ctx.SubscriptionReaperInterval, 30*time.Second)
+	}
+}
+
+func TestInitUdrContext_CacheConfig(t *testing.T) {
+	origUdrConfig := factory.UdrConfig
+	t.Cleanup(func() { factory.UdrConfig = origUdrConfig })
+
+	factory.UdrConfig = newBaseConfig()
+	ctx := &context.UDRContext{}
+	InitUdrContext(ctx)
+	if ctx.CacheMaxEntries != 0 || ctx.CacheTTL != 0 || ctx.CacheSweepInterval != 0 ||
+		ctx.CacheNegativeTTL != 0 || ctx.CacheCollections != nil {
+		t.Errorf("expected the cache defaults to be left to the producer, got %+v", ctx)
+	}
+
+	factory.UdrConfig.Configuration.Cache = &factory.Cache{
+		MaxEntries:    500000,
+		TTL:           60,
+		SweepInterval: 10,
+		NegativeTTL:   -1,
+		Collections:   map[string]int{"subscriptionData.provisionedData.amData": 120},
+	}
+	ctx = &context.UDRContext{}
+	InitUdrContext(ctx)
+	if ctx.CacheMaxEntries != 500000 {
+		t.Errorf("CacheMaxEntries = %d, want 500000", ctx.CacheMaxEntries)
+	}
+	if ctx.CacheTTL != time.Minute {
+		t.Errorf("CacheTTL = %v, want %v", ctx.CacheTTL, time.Minute)
+	}
+	if ctx.CacheSweepInterval != 10*time.Second {
+		t.Errorf("CacheSweepInterval = %v, want %v", ctx.CacheSweepInterval, 10*time.Second)
+	}
+	if ctx.CacheNegativeTTL != -time.Second {
+		t.Errorf("CacheNegativeTTL = %v, want %v", ctx.CacheNegativeTTL, -time.Second)
+	}
+	if got := ctx.CacheCollections["subscriptionData.provisionedData.amData"]; len(ctx.CacheCollections) != 1 || got != 2*time.Minute {
+		t.Errorf("CacheCollections = %v", ctx.CacheCollections)
+	}
+}
+
+func TestInitUdrContext_AdminConfig(t *testing.T) {
+	origUdrConfig := factory.UdrConfig
+	t.Cleanup(func() { factory.UdrConfig = origUdrConfig })
+
+	factory.UdrConfig = newBaseConfig()
+	ctx := &context.UDRContext{}
+	InitUdrContext(ctx)
+	if ctx.AdminPort != 0 {
+		t.Errorf("AdminPort = %d, want 0 (disabled)", ctx.AdminPort)
+	}
+
+	factory.UdrConfig.Configuration.Admin = &factory.Admin{Port: 9089}
+	ctx = &context.UDRContext{}
+	InitUdrContext(ctx)
+	if ctx.AdminPort != 9089 {
+		t.Errorf("AdminPort = %d, want 9089", ctx.AdminPort)
+	}
+}